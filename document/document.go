@@ -37,8 +37,9 @@ const (
 	MaxAspectRatio = 4.0  // 4:1
 )
 
-// AllowedFormats maps document types to their allowed file extensions.
-var AllowedFormats = map[string][]string{
+// allowedFormats maps document types to their allowed file extensions.
+// Access it through GetAllowedFormats or AllowedFormatsForAll, which return copies.
+var allowedFormats = map[string][]string{
 	DocTypeDriverLicense:       {"jpg", "jpeg", "png", "pdf"},
 	DocTypeVehicleRegistration: {"jpg", "jpeg", "png", "pdf"},
 	DocTypeInsurance:           {"jpg", "jpeg", "png", "pdf"},
@@ -159,14 +160,24 @@ func ValidateImage(width, height int, size int64, docType string) error {
 	return nil
 }
 
-// GetAllowedFormats returns the allowed file formats for a document type.
+// GetAllowedFormats returns a copy of the allowed file formats for a document type.
 func GetAllowedFormats(docType string) []string {
-	if formats, ok := AllowedFormats[docType]; ok {
-		return formats
+	if formats, ok := allowedFormats[docType]; ok {
+		return append([]string(nil), formats...)
 	}
 	return nil
 }
 
+// AllowedFormatsForAll returns a deep copy of the allowed file formats for
+// every document type, keyed by document type.
+func AllowedFormatsForAll() map[string][]string {
+	all := make(map[string][]string, len(allowedFormats))
+	for docType, formats := range allowedFormats {
+		all[docType] = append([]string(nil), formats...)
+	}
+	return all
+}
+
 // IsAllowedFormat checks if a file extension is allowed for a document type.
 func IsAllowedFormat(extension, docType string) bool {
 	ext := strings.ToLower(strings.TrimPrefix(extension, "."))
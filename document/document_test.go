@@ -225,6 +225,46 @@ func TestValidateImage(t *testing.T) {
 	}
 }
 
+func TestAllowedFormatsForAll(t *testing.T) {
+	all := AllowedFormatsForAll()
+	if len(all) != len(AllDocTypes()) {
+		t.Fatalf("AllowedFormatsForAll() has %d types, want %d", len(all), len(AllDocTypes()))
+	}
+	for _, docType := range AllDocTypes() {
+		if len(all[docType]) != len(GetAllowedFormats(docType)) {
+			t.Errorf("AllowedFormatsForAll()[%q] = %v, want %v", docType, all[docType], GetAllowedFormats(docType))
+		}
+	}
+
+	t.Run("mutation does not leak", func(t *testing.T) {
+		all[DocTypeProfilePhoto] = append(all[DocTypeProfilePhoto], "gif")
+		all[DocTypeDriverLicense][0] = "exe"
+		delete(all, DocTypeInsurance)
+
+		fresh := AllowedFormatsForAll()
+		if len(fresh[DocTypeProfilePhoto]) != 3 {
+			t.Errorf("profile_photo formats = %v, want 3 formats", fresh[DocTypeProfilePhoto])
+		}
+		if fresh[DocTypeDriverLicense][0] != "jpg" {
+			t.Errorf("driver_license formats = %v, want jpg first", fresh[DocTypeDriverLicense])
+		}
+		if _, ok := fresh[DocTypeInsurance]; !ok {
+			t.Error("insurance formats missing after deleting from copy")
+		}
+		if err := ValidateFormat("gif", DocTypeProfilePhoto); err == nil {
+			t.Error("ValidateFormat(gif) should still fail")
+		}
+	})
+}
+
+func TestGetAllowedFormats_ReturnsCopy(t *testing.T) {
+	formats := GetAllowedFormats(DocTypeDriverLicense)
+	formats[0] = "exe"
+	if got := GetAllowedFormats(DocTypeDriverLicense)[0]; got != "jpg" {
+		t.Errorf("GetAllowedFormats() exposed internal state: first format = %q", got)
+	}
+}
+
 func TestGetAllowedFormats(t *testing.T) {
 	tests := []struct {
 		name           string
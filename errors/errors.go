@@ -3,7 +3,10 @@ package errors
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	stderrors "errors"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -23,8 +26,30 @@ const (
 	CodeInvalidOption = "INVALID_OPTION"
 	// CodeOutsideServiceArea indicates location is not in a serviceable area.
 	CodeOutsideServiceArea = "OUTSIDE_SERVICE_AREA"
+	// CodeNotAllowed indicates the value is valid but not permitted, e.g. a pickup in an exclusion zone.
+	CodeNotAllowed = "NOT_ALLOWED"
+	// CodeDuplicate indicates the value repeats one that was already used.
+	CodeDuplicate = "DUPLICATE"
+	// CodeConflict indicates the value conflicts with the current state, e.g. an invalid status transition.
+	CodeConflict = "CONFLICT"
 )
 
+// Severity classifies how a validation failure should be handled.
+type Severity string
+
+// Severity levels. An empty Severity is treated as SeverityError.
+const (
+	// SeverityError marks a failure that must block the request.
+	SeverityError Severity = "error"
+	// SeverityWarning marks a failure that should be surfaced but need not block the request.
+	SeverityWarning Severity = "warning"
+)
+
+// ErrValidation is a sentinel matched by ValidationError and ValidationErrors
+// through errors.Is, so callers can detect validation failures without a type
+// assertion, even when the error has been wrapped.
+var ErrValidation = stderrors.New("validation failed")
+
 // ValidationError represents a single validation failure.
 type ValidationError struct {
 	// Field is the JSON field name that failed validation.
@@ -35,6 +60,11 @@ type ValidationError struct {
 	Message string `json:"message"`
 	// Value is the invalid value (masked if sensitive).
 	Value interface{} `json:"value,omitempty"`
+	// Params holds additional structured context about the failure. It is
+	// held by pointer so ValidationError stays comparable with ==.
+	Params *Params `json:"params,omitempty"`
+	// Severity is the failure's severity; empty means SeverityError.
+	Severity Severity `json:"severity,omitempty"`
 }
 
 // Error implements the error interface.
@@ -45,6 +75,93 @@ func (e ValidationError) Error() string {
 	return fmt.Sprintf("%s: %s", e.Field, e.Message)
 }
 
+// Is reports whether target is ErrValidation.
+func (e ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// WithParam returns a copy of the error with the given parameter set.
+// The receiver's Params are not modified.
+func (e ValidationError) WithParam(key string, value interface{}) ValidationError {
+	values := e.Params.Map()
+	if values == nil {
+		values = make(map[string]interface{}, 1)
+	}
+	values[key] = value
+	e.Params = &Params{values: values}
+	return e
+}
+
+// Param returns the parameter stored under key, or nil if it is not set.
+func (e ValidationError) Param(key string) interface{} {
+	return e.Params.Get(key)
+}
+
+// WithSeverity returns a copy of the error with the given severity.
+func (e ValidationError) WithSeverity(severity Severity) ValidationError {
+	e.Severity = severity
+	return e
+}
+
+// IsWarning returns true if the error has SeverityWarning.
+func (e ValidationError) IsWarning() bool {
+	return e.Severity == SeverityWarning
+}
+
+// Params is an immutable set of structured error parameters. A nil *Params
+// is valid and empty. Build it with ValidationError.WithParam.
+type Params struct {
+	values map[string]interface{}
+}
+
+// Get returns the parameter stored under key, or nil if it is not set.
+func (p *Params) Get(key string) interface{} {
+	if p == nil {
+		return nil
+	}
+	return p.values[key]
+}
+
+// Len returns the number of parameters.
+func (p *Params) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.values)
+}
+
+// Map returns a copy of the parameters, or nil if there are none.
+func (p *Params) Map() map[string]interface{} {
+	if p.Len() == 0 {
+		return nil
+	}
+	values := make(map[string]interface{}, len(p.values))
+	for k, v := range p.values {
+		values[k] = v
+	}
+	return values
+}
+
+// String implements fmt.Stringer.
+func (p *Params) String() string {
+	return fmt.Sprint(p.Map())
+}
+
+// MarshalJSON implements json.Marshaler, encoding the parameters as an object.
+func (p *Params) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Map())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *Params) UnmarshalJSON(data []byte) error {
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	p.values = values
+	return nil
+}
+
 // New creates a new ValidationError.
 func New(field, code, message string) ValidationError {
 	return ValidationError{
@@ -187,6 +304,15 @@ func OutsideServiceAreaWithValue(field string, lat, lon float64) ValidationError
 	}
 }
 
+// NotAllowed creates a NOT_ALLOWED validation error.
+func NotAllowed(field string) ValidationError {
+	return ValidationError{
+		Field:   field,
+		Code:    CodeNotAllowed,
+		Message: fmt.Sprintf("%s is not allowed", field),
+	}
+}
+
 // ValidationErrors is a collection of validation errors.
 type ValidationErrors []ValidationError
 
@@ -206,6 +332,11 @@ func (ve ValidationErrors) Error() string {
 	return fmt.Sprintf("%d validation errors: %s", len(ve), strings.Join(msgs, "; "))
 }
 
+// Is reports whether target is ErrValidation.
+func (ve ValidationErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
 // HasErrors returns true if there are any validation errors.
 func (ve ValidationErrors) HasErrors() bool {
 	return len(ve) > 0
@@ -264,6 +395,39 @@ func (ve ValidationErrors) Fields() []string {
 	return fields
 }
 
+// GroupByField groups the errors by field, preserving their order within each field.
+// Returns an empty map if there are no errors.
+func (ve ValidationErrors) GroupByField() FieldErrorMap {
+	m := make(FieldErrorMap)
+	for _, e := range ve {
+		m[e.Field] = append(m[e.Field], e)
+	}
+	return m
+}
+
+// ToFieldMap is an alias for GroupByField.
+func (ve ValidationErrors) ToFieldMap() FieldErrorMap {
+	return ve.GroupByField()
+}
+
+// ToSlice returns a copy of the errors as a plain slice.
+// The result is never nil, even when there are no errors.
+func (ve ValidationErrors) ToSlice() []ValidationError {
+	out := make([]ValidationError, len(ve))
+	copy(out, ve)
+	return out
+}
+
+// ToInterfaces returns the errors as a []interface{}, each element holding a
+// ValidationError. The result is never nil.
+func (ve ValidationErrors) ToInterfaces() []interface{} {
+	out := make([]interface{}, len(ve))
+	for i, e := range ve {
+		out[i] = e
+	}
+	return out
+}
+
 // Add appends a validation error to the collection.
 func (ve *ValidationErrors) Add(err ValidationError) {
 	*ve = append(*ve, err)
@@ -274,6 +438,20 @@ func (ve *ValidationErrors) AddAll(errs ValidationErrors) {
 	*ve = append(*ve, errs...)
 }
 
+// Append returns a new collection with err appended, leaving the receiver
+// unchanged: errs = errs.Append(Required("phone")).
+func (ve ValidationErrors) Append(err ValidationError) ValidationErrors {
+	return ve.AppendAll(ValidationErrors{err})
+}
+
+// AppendAll returns a new collection with errs appended, leaving the receiver
+// unchanged. The result never shares its backing array with the receiver.
+func (ve ValidationErrors) AppendAll(errs ValidationErrors) ValidationErrors {
+	out := make(ValidationErrors, 0, len(ve)+len(errs))
+	out = append(out, ve...)
+	return append(out, errs...)
+}
+
 // MarshalJSON implements json.Marshaler for API responses.
 func (ve ValidationErrors) MarshalJSON() ([]byte, error) {
 	if len(ve) == 0 {
@@ -282,6 +460,37 @@ func (ve ValidationErrors) MarshalJSON() ([]byte, error) {
 	return json.Marshal([]ValidationError(ve))
 }
 
+// xmlValidationErrors is the XML root element for ValidationErrors.
+type xmlValidationErrors struct {
+	XMLName xml.Name             `xml:"errors"`
+	Errors  []xmlValidationError `xml:"error"`
+}
+
+// xmlValidationError is the XML representation of a ValidationError.
+type xmlValidationError struct {
+	Field   string `xml:"field,attr"`
+	Code    string `xml:"code,attr"`
+	Message string `xml:"message,attr"`
+}
+
+// XML marshals the errors as <errors><error field="..." code="..." message="..."/></errors>
+// for XML-consuming clients. An empty collection produces <errors/>.
+func (ve ValidationErrors) XML() ([]byte, error) {
+	if len(ve) == 0 {
+		return []byte("<errors/>"), nil
+	}
+
+	doc := xmlValidationErrors{Errors: make([]xmlValidationError, 0, len(ve))}
+	for _, e := range ve {
+		doc.Errors = append(doc.Errors, xmlValidationError{
+			Field:   e.Field,
+			Code:    e.Code,
+			Message: e.Message,
+		})
+	}
+	return xml.Marshal(doc)
+}
+
 // ToError returns the ValidationErrors as an error interface, or nil if empty.
 func (ve ValidationErrors) ToError() error {
 	if len(ve) == 0 {
@@ -289,3 +498,31 @@ func (ve ValidationErrors) ToError() error {
 	}
 	return ve
 }
+
+// FieldErrorMap holds validation errors grouped by field name.
+type FieldErrorMap map[string]ValidationErrors
+
+// Fields returns the field names that have errors, sorted alphabetically.
+func (m FieldErrorMap) Fields() []string {
+	fields := make([]string, 0, len(m))
+	for field := range m {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Get returns the errors for the given field, or nil if there are none.
+func (m FieldErrorMap) Get(field string) ValidationErrors {
+	return m[field]
+}
+
+// Has returns true if there is at least one error for the given field.
+func (m FieldErrorMap) Has(field string) bool {
+	return len(m[field]) > 0
+}
+
+// First returns the first error for the given field, or nil if there are none.
+func (m FieldErrorMap) First(field string) *ValidationError {
+	return m[field].First()
+}
@@ -2,6 +2,12 @@ package errors
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -37,6 +43,83 @@ func TestValidationError_Error(t *testing.T) {
 	}
 }
 
+func TestValidationError_WithParam(t *testing.T) {
+	base := New("area", CodeOutsideServiceArea, "area is outside the service area")
+
+	withOne := base.WithParam("nearest_area", "maputo")
+	withTwo := withOne.WithParam("distance_km", 3.2)
+
+	if base.Params != nil {
+		t.Errorf("base.Params = %v, want nil", base.Params)
+	}
+	if withOne.Params.Len() != 1 || withOne.Param("nearest_area") != "maputo" {
+		t.Errorf("withOne.Params = %v", withOne.Params)
+	}
+	if withTwo.Params.Len() != 2 || withTwo.Param("distance_km") != 3.2 {
+		t.Errorf("withTwo.Params = %v", withTwo.Params)
+	}
+	if withTwo.Field != base.Field || withTwo.Code != base.Code || withTwo.Message != base.Message {
+		t.Errorf("WithParam() changed other fields: %+v", withTwo)
+	}
+}
+
+func TestValidationError_Comparable(t *testing.T) {
+	err := New("area", CodeOutsideServiceArea, "area is outside the service area").
+		WithParam("nearest_area", "maputo")
+	same := err
+
+	if err != same {
+		t.Error("copies of an error with params should compare equal")
+	}
+	if err == err.WithParam("nearest_area", "maputo") {
+		t.Error("WithParam() should return an error with distinct params")
+	}
+}
+
+func TestParams_JSONRoundTrip(t *testing.T) {
+	original := New("area", CodeOutsideServiceArea, "area is outside the service area").
+		WithParam("nearest_area", "maputo")
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded ValidationError
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, original) {
+		t.Errorf("round trip = %+v, want %+v", decoded, original)
+	}
+}
+
+func TestValidationError_WithSeverity(t *testing.T) {
+	base := New("luggage", CodeOutOfRange, "too many large bags with fragile items")
+	if base.IsWarning() {
+		t.Error("IsWarning() = true for default severity")
+	}
+
+	warning := base.WithSeverity(SeverityWarning)
+	if !warning.IsWarning() {
+		t.Error("IsWarning() = false after WithSeverity(SeverityWarning)")
+	}
+	if base.Severity != "" {
+		t.Errorf("base.Severity = %q, want empty", base.Severity)
+	}
+
+	data, err := json.Marshal(warning)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"severity":"warning"`) {
+		t.Errorf("json.Marshal() = %s, want severity", data)
+	}
+	data, _ = json.Marshal(base)
+	if strings.Contains(string(data), "severity") {
+		t.Errorf("json.Marshal() = %s, want severity omitted", data)
+	}
+}
+
 func TestNew(t *testing.T) {
 	err := New("field", CodeRequired, "field is required")
 	if err.Field != "field" {
@@ -207,6 +290,19 @@ func TestOutsideServiceAreaWithValue(t *testing.T) {
 	}
 }
 
+func TestNotAllowed(t *testing.T) {
+	err := NotAllowed("pickup_location")
+	if err.Field != "pickup_location" {
+		t.Errorf("Field = %v, want pickup_location", err.Field)
+	}
+	if err.Code != CodeNotAllowed {
+		t.Errorf("Code = %v, want %v", err.Code, CodeNotAllowed)
+	}
+	if err.Message != "pickup_location is not allowed" {
+		t.Errorf("Message = %v", err.Message)
+	}
+}
+
 func TestValidationErrors_Error(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -390,6 +486,55 @@ func TestValidationErrors_Fields(t *testing.T) {
 	}
 }
 
+func TestValidationErrors_ToSlice(t *testing.T) {
+	t.Run("nil errors", func(t *testing.T) {
+		var errors ValidationErrors
+		got := errors.ToSlice()
+		if got == nil || len(got) != 0 {
+			t.Errorf("ToSlice() = %#v, want empty non-nil slice", got)
+		}
+	})
+
+	t.Run("returns a copy", func(t *testing.T) {
+		errors := ValidationErrors{Required("email"), TooShort("password", 8)}
+		got := errors.ToSlice()
+		if len(got) != 2 || got[0].Field != "email" || got[1].Field != "password" {
+			t.Fatalf("ToSlice() = %v", got)
+		}
+		got[0].Field = "changed"
+		if errors[0].Field != "email" {
+			t.Error("ToSlice() shares storage with the original")
+		}
+	})
+}
+
+func TestValidationErrors_ToInterfaces(t *testing.T) {
+	t.Run("nil errors", func(t *testing.T) {
+		var errors ValidationErrors
+		got := errors.ToInterfaces()
+		if got == nil || len(got) != 0 {
+			t.Errorf("ToInterfaces() = %#v, want empty non-nil slice", got)
+		}
+	})
+
+	t.Run("with errors", func(t *testing.T) {
+		errors := ValidationErrors{Required("email"), TooShort("password", 8)}
+		got := errors.ToInterfaces()
+		if len(got) != 2 {
+			t.Fatalf("ToInterfaces() returned %d items, want 2", len(got))
+		}
+		for i, item := range got {
+			ve, ok := item.(ValidationError)
+			if !ok {
+				t.Fatalf("ToInterfaces()[%d] = %T, want ValidationError", i, item)
+			}
+			if ve.Field != errors[i].Field {
+				t.Errorf("ToInterfaces()[%d].Field = %v, want %v", i, ve.Field, errors[i].Field)
+			}
+		}
+	})
+}
+
 func TestValidationErrors_Add(t *testing.T) {
 	var errors ValidationErrors
 	errors.Add(Required("email"))
@@ -421,6 +566,48 @@ func TestValidationErrors_AddAll(t *testing.T) {
 	}
 }
 
+func TestValidationErrors_Append(t *testing.T) {
+	var empty ValidationErrors
+	one := empty.Append(Required("phone"))
+	if len(empty) != 0 || len(one) != 1 || one[0].Field != "phone" {
+		t.Fatalf("Append() = %v (receiver %v), want [phone]", one, empty)
+	}
+
+	// Appending twice to the same collection must not let the results share storage.
+	base := make(ValidationErrors, 1, 4)
+	base[0] = Required("email")
+	a := base.Append(TooShort("password", 8))
+	b := base.Append(InvalidFormat("phone", "+258XXXXXXXXX"))
+	if len(base) != 1 {
+		t.Errorf("len(base) = %d, want 1", len(base))
+	}
+	if a[1].Field != "password" || b[1].Field != "phone" {
+		t.Errorf("Append() results = %v, %v; want independent collections", a, b)
+	}
+}
+
+func TestValidationErrors_AppendAll(t *testing.T) {
+	base := ValidationErrors{Required("email")}
+	more := ValidationErrors{TooShort("password", 8), InvalidFormat("phone", "+258XXXXXXXXX")}
+
+	got := base.AppendAll(more)
+	if fields := got.Fields(); !reflect.DeepEqual(fields, []string{"email", "password", "phone"}) {
+		t.Errorf("AppendAll() fields = %v, want [email password phone]", fields)
+	}
+	if len(base) != 1 || len(more) != 2 {
+		t.Errorf("AppendAll() modified its inputs: %v, %v", base, more)
+	}
+
+	got[0].Field = "changed"
+	if base[0].Field != "email" {
+		t.Error("AppendAll() result shares storage with the receiver")
+	}
+
+	if got := ValidationErrors(nil).AppendAll(nil); len(got) != 0 {
+		t.Errorf("AppendAll() of nils = %v, want empty", got)
+	}
+}
+
 func TestValidationErrors_MarshalJSON(t *testing.T) {
 	t.Run("empty errors", func(t *testing.T) {
 		var errors ValidationErrors
@@ -499,6 +686,73 @@ func TestValidationErrors_MarshalJSON(t *testing.T) {
 		if _, exists := result[0]["value"]; exists {
 			t.Error("value field should be omitted when empty")
 		}
+		if _, exists := result[0]["params"]; exists {
+			t.Error("params field should be omitted when empty")
+		}
+	})
+
+	t.Run("with params field", func(t *testing.T) {
+		errors := ValidationErrors{
+			New("location", CodeOutsideServiceArea, "location is outside the service area").
+				WithParam("nearest_area", "maputo"),
+		}
+		data, err := json.Marshal(errors)
+		if err != nil {
+			t.Fatalf("MarshalJSON() error = %v", err)
+		}
+
+		want := `[{"field":"location","code":"OUTSIDE_SERVICE_AREA","message":"location is outside the service area","params":{"nearest_area":"maputo"}}]`
+		if string(data) != want {
+			t.Errorf("MarshalJSON() = %s, want %s", string(data), want)
+		}
+	})
+}
+
+func TestValidationErrors_XML(t *testing.T) {
+	t.Run("empty errors", func(t *testing.T) {
+		var errs ValidationErrors
+		data, err := errs.XML()
+		if err != nil {
+			t.Fatalf("XML() error = %v", err)
+		}
+		if string(data) != "<errors/>" {
+			t.Errorf("XML() = %s, want <errors/>", string(data))
+		}
+	})
+
+	t.Run("with errors", func(t *testing.T) {
+		errs := ValidationErrors{
+			Required("email"),
+			InvalidFormatWithValue("phone", "+258XXXXXXXXX", "123"),
+		}
+		data, err := errs.XML()
+		if err != nil {
+			t.Fatalf("XML() error = %v", err)
+		}
+
+		want := `<errors>` +
+			`<error field="email" code="REQUIRED" message="email is required"></error>` +
+			`<error field="phone" code="INVALID_FORMAT" message="phone has invalid format, expected +258XXXXXXXXX"></error>` +
+			`</errors>`
+		if string(data) != want {
+			t.Errorf("XML() = %s, want %s", string(data), want)
+		}
+	})
+
+	t.Run("escapes special characters", func(t *testing.T) {
+		errs := ValidationErrors{New("name", CodeInvalidFormat, `must not contain "<" or "&"`)}
+		data, err := errs.XML()
+		if err != nil {
+			t.Fatalf("XML() error = %v", err)
+		}
+
+		var decoded xmlValidationErrors
+		if err := xml.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("xml.Unmarshal() error = %v", err)
+		}
+		if len(decoded.Errors) != 1 || decoded.Errors[0].Message != `must not contain "<" or "&"` {
+			t.Errorf("round trip = %+v", decoded.Errors)
+		}
 	})
 }
 
@@ -526,6 +780,40 @@ func TestValidationErrors_ToError(t *testing.T) {
 	})
 }
 
+func TestErrValidation_Is(t *testing.T) {
+	errs := ValidationErrors{Required("name"), InvalidFormat("email", "email address")}
+	single := Required("name")
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"ValidationErrors", errs, true},
+		{"ValidationErrors via ToError", errs.ToError(), true},
+		{"ValidationError", single, true},
+		{"wrapped once", fmt.Errorf("create user: %w", errs), true},
+		{"wrapped twice", fmt.Errorf("handler: %w", fmt.Errorf("create user: %w", errs)), true},
+		{"wrapped ValidationError", fmt.Errorf("handler: %w", fmt.Errorf("lookup: %w", single)), true},
+		{"joined", stderrors.Join(io.EOF, fmt.Errorf("create user: %w", errs)), true},
+		{"not wrapped with %w", fmt.Errorf("create user: %v", errs), false},
+		{"unrelated error", io.EOF, false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stderrors.Is(tt.err, ErrValidation); got != tt.want {
+				t.Errorf("errors.Is(%v, ErrValidation) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+
+	if stderrors.Is(errs, io.EOF) {
+		t.Error("errors.Is(ValidationErrors, io.EOF) = true, want false")
+	}
+}
+
 func TestErrorCodes(t *testing.T) {
 	// Verify all error codes are defined correctly
 	codes := []string{
@@ -536,6 +824,9 @@ func TestErrorCodes(t *testing.T) {
 		CodeTooLong,
 		CodeInvalidOption,
 		CodeOutsideServiceArea,
+		CodeNotAllowed,
+		CodeDuplicate,
+		CodeConflict,
 	}
 
 	expected := []string{
@@ -546,6 +837,9 @@ func TestErrorCodes(t *testing.T) {
 		"TOO_LONG",
 		"INVALID_OPTION",
 		"OUTSIDE_SERVICE_AREA",
+		"NOT_ALLOWED",
+		"DUPLICATE",
+		"CONFLICT",
 	}
 
 	for i, code := range codes {
@@ -554,3 +848,79 @@ func TestErrorCodes(t *testing.T) {
 		}
 	}
 }
+
+func TestValidationErrors_GroupByField(t *testing.T) {
+	errors := ValidationErrors{
+		Required("email"),
+		TooShort("password", 8),
+		InvalidFormat("email", "valid email"),
+	}
+
+	grouped := errors.GroupByField()
+	if len(grouped) != 2 {
+		t.Fatalf("GroupByField() has %d fields, want 2", len(grouped))
+	}
+	email := grouped["email"]
+	if len(email) != 2 || email[0].Code != CodeRequired || email[1].Code != CodeInvalidFormat {
+		t.Errorf("GroupByField()[email] = %v", email)
+	}
+
+	if got := (ValidationErrors{}).GroupByField(); got == nil || len(got) != 0 {
+		t.Errorf("GroupByField() on empty = %v, want empty map", got)
+	}
+
+	toMap := errors.ToFieldMap()
+	if len(toMap) != len(grouped) || len(toMap["email"]) != 2 {
+		t.Errorf("ToFieldMap() = %v, want %v", toMap, grouped)
+	}
+}
+
+func TestFieldErrorMap(t *testing.T) {
+	m := ValidationErrors{
+		TooShort("password", 8),
+		Required("email"),
+		InvalidFormat("email", "valid email"),
+	}.ToFieldMap()
+
+	t.Run("Fields", func(t *testing.T) {
+		fields := m.Fields()
+		if len(fields) != 2 || fields[0] != "email" || fields[1] != "password" {
+			t.Errorf("Fields() = %v, want [email password]", fields)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		if got := m.Get("email"); len(got) != 2 {
+			t.Errorf("Get(email) = %v, want 2 errors", got)
+		}
+		if got := m.Get("phone"); got != nil {
+			t.Errorf("Get(phone) = %v, want nil", got)
+		}
+	})
+
+	t.Run("Has", func(t *testing.T) {
+		if !m.Has("password") {
+			t.Error("Has(password) = false, want true")
+		}
+		if m.Has("phone") {
+			t.Error("Has(phone) = true, want false")
+		}
+	})
+
+	t.Run("First", func(t *testing.T) {
+		first := m.First("email")
+		if first == nil || first.Code != CodeRequired {
+			t.Errorf("First(email) = %v, want REQUIRED", first)
+		}
+		if got := m.First("phone"); got != nil {
+			t.Errorf("First(phone) = %v, want nil", got)
+		}
+	})
+
+	t.Run("nil map", func(t *testing.T) {
+		var empty FieldErrorMap
+		if len(empty.Fields()) != 0 || empty.Has("email") || empty.First("email") != nil {
+			t.Error("nil FieldErrorMap should behave as empty")
+		}
+	})
+}
@@ -0,0 +1,48 @@
+// Package proto converts validation errors to and from google.rpc.BadRequest field violations.
+// It lives in its own package so the core errors package stays free of Protobuf dependencies.
+package proto
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// ToProtoErrors converts ValidationErrors to BadRequest field violations.
+// Field maps to field, Message to description, and Code to reason.
+// The invalid Value is not carried over. Returns nil if errs is empty.
+func ToProtoErrors(errs valerrors.ValidationErrors) []*errdetails.BadRequest_FieldViolation {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(errs))
+	for _, e := range errs {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       e.Field,
+			Description: e.Message,
+			Reason:      e.Code,
+		})
+	}
+	return violations
+}
+
+// FromProtoErrors converts BadRequest field violations back to ValidationErrors.
+// Nil violations are skipped. Returns nil if there are no violations.
+func FromProtoErrors(violations []*errdetails.BadRequest_FieldViolation) valerrors.ValidationErrors {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	result := make(valerrors.ValidationErrors, 0, len(violations))
+	for _, v := range violations {
+		if v == nil {
+			continue
+		}
+		result = append(result, valerrors.New(v.GetField(), v.GetReason(), v.GetDescription()))
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
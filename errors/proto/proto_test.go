@@ -0,0 +1,94 @@
+package proto
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestToProtoErrors(t *testing.T) {
+	errs := valerrors.ValidationErrors{
+		valerrors.Required("phone"),
+		valerrors.OutOfRangeWithValue("rating", 1, 5, 6),
+	}
+
+	violations := ToProtoErrors(errs)
+	if len(violations) != 2 {
+		t.Fatalf("ToProtoErrors() returned %d violations, want 2", len(violations))
+	}
+
+	tests := []struct {
+		field       string
+		description string
+		reason      string
+	}{
+		{"phone", "phone is required", valerrors.CodeRequired},
+		{"rating", "rating must be between 1 and 5", valerrors.CodeOutOfRange},
+	}
+
+	for i, tt := range tests {
+		v := violations[i]
+		if v.GetField() != tt.field {
+			t.Errorf("violations[%d].Field = %q, want %q", i, v.GetField(), tt.field)
+		}
+		if v.GetDescription() != tt.description {
+			t.Errorf("violations[%d].Description = %q, want %q", i, v.GetDescription(), tt.description)
+		}
+		if v.GetReason() != tt.reason {
+			t.Errorf("violations[%d].Reason = %q, want %q", i, v.GetReason(), tt.reason)
+		}
+	}
+}
+
+func TestToProtoErrors_Empty(t *testing.T) {
+	if got := ToProtoErrors(nil); got != nil {
+		t.Errorf("ToProtoErrors(nil) = %v, want nil", got)
+	}
+	if got := ToProtoErrors(valerrors.ValidationErrors{}); got != nil {
+		t.Errorf("ToProtoErrors(empty) = %v, want nil", got)
+	}
+}
+
+func TestFromProtoErrors(t *testing.T) {
+	violations := []*errdetails.BadRequest_FieldViolation{
+		{Field: "email", Description: "email has invalid format", Reason: valerrors.CodeInvalidFormat},
+		nil,
+		{Field: "name", Description: "name is required"},
+	}
+
+	errs := FromProtoErrors(violations)
+	if len(errs) != 2 {
+		t.Fatalf("FromProtoErrors() returned %d errors, want 2", len(errs))
+	}
+
+	if errs[0].Field != "email" || errs[0].Code != valerrors.CodeInvalidFormat || errs[0].Message != "email has invalid format" {
+		t.Errorf("errs[0] = %+v", errs[0])
+	}
+	if errs[1].Field != "name" || errs[1].Code != "" || errs[1].Message != "name is required" {
+		t.Errorf("errs[1] = %+v", errs[1])
+	}
+}
+
+func TestFromProtoErrors_Empty(t *testing.T) {
+	if got := FromProtoErrors(nil); got != nil {
+		t.Errorf("FromProtoErrors(nil) = %v, want nil", got)
+	}
+	if got := FromProtoErrors([]*errdetails.BadRequest_FieldViolation{nil}); got != nil {
+		t.Errorf("FromProtoErrors([nil]) = %v, want nil", got)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	original := valerrors.ValidationErrors{
+		valerrors.InvalidOption("area", []string{"maputo", "beira"}),
+		valerrors.TooLong("review", 500),
+	}
+
+	got := FromProtoErrors(ToProtoErrors(original))
+	if !reflect.DeepEqual(got, original) {
+		t.Errorf("round trip = %+v, want %+v", got, original)
+	}
+}
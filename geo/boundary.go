@@ -0,0 +1,289 @@
+package geo
+
+// mozambiqueBoundary is a simplified outline of Mozambique's national border,
+// traced clockwise from the mouth of the Rovuma river. Land borders follow the
+// boundary closely; the coastline is drawn slightly offshore so coastal cities
+// and islands are not clipped.
+var mozambiqueBoundary = Polygon{
+	// Tanzania border along the Rovuma river
+	{Lat: -10.47, Lon: 40.44},
+	{Lat: -10.52, Lon: 40.30},
+	{Lat: -10.57, Lon: 40.15},
+	{Lat: -10.63, Lon: 40.02},
+	{Lat: -10.70, Lon: 39.90},
+	{Lat: -10.80, Lon: 39.77},
+	{Lat: -10.90, Lon: 39.65},
+	{Lat: -10.98, Lon: 39.52},
+	{Lat: -11.05, Lon: 39.40},
+	{Lat: -11.12, Lon: 39.25},
+	{Lat: -11.20, Lon: 39.10},
+	{Lat: -11.28, Lon: 38.95},
+	{Lat: -11.35, Lon: 38.80},
+	{Lat: -11.40, Lon: 38.65},
+	{Lat: -11.43, Lon: 38.50},
+	{Lat: -11.45, Lon: 38.35},
+	{Lat: -11.46, Lon: 38.20},
+	{Lat: -11.52, Lon: 38.05},
+	{Lat: -11.56, Lon: 37.90},
+	{Lat: -11.58, Lon: 37.75},
+	{Lat: -11.60, Lon: 37.60},
+	{Lat: -11.62, Lon: 37.45},
+	{Lat: -11.62, Lon: 37.30},
+	{Lat: -11.64, Lon: 37.15},
+	{Lat: -11.65, Lon: 37.00},
+	{Lat: -11.67, Lon: 36.85},
+	{Lat: -11.68, Lon: 36.70},
+	{Lat: -11.70, Lon: 36.55},
+	{Lat: -11.72, Lon: 36.40},
+	{Lat: -11.65, Lon: 36.25},
+	{Lat: -11.55, Lon: 36.10},
+	{Lat: -11.50, Lon: 35.95},
+	{Lat: -11.45, Lon: 35.80},
+	{Lat: -11.42, Lon: 35.65},
+	{Lat: -11.40, Lon: 35.50},
+	{Lat: -11.45, Lon: 35.35},
+	{Lat: -11.50, Lon: 35.20},
+	{Lat: -11.55, Lon: 35.08},
+	{Lat: -11.57, Lon: 34.96},
+
+	// Malawi border through Lake Niassa
+	{Lat: -11.60, Lon: 34.62},
+	{Lat: -11.90, Lon: 34.61},
+	{Lat: -12.20, Lon: 34.60},
+	{Lat: -12.50, Lon: 34.64},
+	{Lat: -12.80, Lon: 34.68},
+	{Lat: -13.10, Lon: 34.71},
+	{Lat: -13.30, Lon: 34.74},
+	{Lat: -13.50, Lon: 34.80},
+
+	// Malawi eastern border
+	{Lat: -13.52, Lon: 35.00},
+	{Lat: -13.55, Lon: 35.20},
+	{Lat: -13.65, Lon: 35.35},
+	{Lat: -13.75, Lon: 35.45},
+	{Lat: -13.90, Lon: 35.50},
+	{Lat: -14.05, Lon: 35.55},
+	{Lat: -14.20, Lon: 35.57},
+	{Lat: -14.40, Lon: 35.60},
+	{Lat: -14.60, Lon: 35.68},
+	{Lat: -14.80, Lon: 35.75},
+	{Lat: -15.00, Lon: 35.80},
+	{Lat: -15.15, Lon: 35.85},
+	{Lat: -15.30, Lon: 35.90},
+	{Lat: -15.45, Lon: 35.85},
+	{Lat: -15.60, Lon: 35.80},
+	{Lat: -15.75, Lon: 35.78},
+	{Lat: -15.90, Lon: 35.75},
+	{Lat: -16.10, Lon: 35.72},
+	{Lat: -16.20, Lon: 35.60},
+	{Lat: -16.30, Lon: 35.48},
+	{Lat: -16.40, Lon: 35.35},
+	{Lat: -16.48, Lon: 35.25},
+	{Lat: -16.55, Lon: 35.15},
+	{Lat: -16.70, Lon: 35.20},
+	{Lat: -16.90, Lon: 35.27},
+	{Lat: -17.13, Lon: 35.30},
+
+	// Malawi southern and western border
+	{Lat: -17.00, Lon: 35.15},
+	{Lat: -16.85, Lon: 35.00},
+	{Lat: -16.70, Lon: 34.85},
+	{Lat: -16.55, Lon: 34.70},
+	{Lat: -16.40, Lon: 34.55},
+	{Lat: -16.20, Lon: 34.40},
+	{Lat: -16.00, Lon: 34.35},
+	{Lat: -15.80, Lon: 34.40},
+	{Lat: -15.62, Lon: 34.47},
+	{Lat: -15.45, Lon: 34.52},
+	{Lat: -15.30, Lon: 34.55},
+	{Lat: -15.15, Lon: 34.57},
+	{Lat: -15.00, Lon: 34.57},
+	{Lat: -14.80, Lon: 34.52},
+	{Lat: -14.65, Lon: 34.47},
+	{Lat: -14.50, Lon: 34.40},
+	{Lat: -14.38, Lon: 34.28},
+	{Lat: -14.30, Lon: 34.15},
+	{Lat: -14.25, Lon: 34.00},
+	{Lat: -14.22, Lon: 33.85},
+	{Lat: -14.18, Lon: 33.70},
+	{Lat: -14.10, Lon: 33.50},
+	{Lat: -14.05, Lon: 33.35},
+	{Lat: -14.00, Lon: 33.22},
+
+	// Zambia border
+	{Lat: -14.08, Lon: 33.05},
+	{Lat: -14.15, Lon: 32.90},
+	{Lat: -14.25, Lon: 32.70},
+	{Lat: -14.35, Lon: 32.50},
+	{Lat: -14.40, Lon: 32.30},
+	{Lat: -14.45, Lon: 32.10},
+	{Lat: -14.50, Lon: 31.90},
+	{Lat: -14.55, Lon: 31.70},
+	{Lat: -14.62, Lon: 31.50},
+	{Lat: -14.70, Lon: 31.30},
+	{Lat: -14.78, Lon: 31.10},
+	{Lat: -14.85, Lon: 30.90},
+	{Lat: -14.90, Lon: 30.70},
+	{Lat: -14.95, Lon: 30.50},
+	{Lat: -14.98, Lon: 30.35},
+	{Lat: -15.00, Lon: 30.22},
+	{Lat: -15.15, Lon: 30.25},
+	{Lat: -15.30, Lon: 30.30},
+	{Lat: -15.45, Lon: 30.36},
+	{Lat: -15.62, Lon: 30.42},
+
+	// Zimbabwe border
+	{Lat: -15.68, Lon: 30.55},
+	{Lat: -15.75, Lon: 30.70},
+	{Lat: -15.82, Lon: 30.85},
+	{Lat: -15.90, Lon: 31.00},
+	{Lat: -15.98, Lon: 31.18},
+	{Lat: -16.05, Lon: 31.35},
+	{Lat: -16.12, Lon: 31.52},
+	{Lat: -16.20, Lon: 31.68},
+	{Lat: -16.28, Lon: 31.85},
+	{Lat: -16.35, Lon: 32.00},
+	{Lat: -16.45, Lon: 32.20},
+	{Lat: -16.55, Lon: 32.40},
+	{Lat: -16.62, Lon: 32.55},
+	{Lat: -16.70, Lon: 32.70},
+	{Lat: -16.82, Lon: 32.80},
+	{Lat: -16.95, Lon: 32.87},
+	{Lat: -17.10, Lon: 32.97},
+	{Lat: -17.30, Lon: 33.00},
+	{Lat: -17.50, Lon: 33.02},
+	{Lat: -17.70, Lon: 33.02},
+	{Lat: -17.90, Lon: 33.02},
+	{Lat: -18.05, Lon: 33.00},
+	{Lat: -18.20, Lon: 32.98},
+	{Lat: -18.35, Lon: 32.96},
+	{Lat: -18.50, Lon: 32.95},
+	{Lat: -18.65, Lon: 32.93},
+	{Lat: -18.80, Lon: 32.90},
+	{Lat: -18.90, Lon: 32.80},
+	{Lat: -18.98, Lon: 32.73},
+	{Lat: -19.10, Lon: 32.78},
+	{Lat: -19.20, Lon: 32.82},
+	{Lat: -19.35, Lon: 32.85},
+	{Lat: -19.50, Lon: 32.88},
+	{Lat: -19.62, Lon: 32.95},
+	{Lat: -19.75, Lon: 33.05},
+	{Lat: -19.88, Lon: 33.05},
+	{Lat: -20.00, Lon: 33.02},
+	{Lat: -20.10, Lon: 32.97},
+	{Lat: -20.20, Lon: 32.90},
+	{Lat: -20.32, Lon: 32.82},
+	{Lat: -20.43, Lon: 32.74},
+	{Lat: -20.55, Lon: 32.65},
+	{Lat: -20.70, Lon: 32.55},
+	{Lat: -20.85, Lon: 32.50},
+	{Lat: -21.00, Lon: 32.45},
+	{Lat: -21.15, Lon: 32.42},
+	{Lat: -21.30, Lon: 32.40},
+	{Lat: -21.45, Lon: 32.30},
+	{Lat: -21.60, Lon: 32.20},
+	{Lat: -21.75, Lon: 32.05},
+	{Lat: -21.90, Lon: 31.90},
+	{Lat: -22.00, Lon: 31.80},
+	{Lat: -22.10, Lon: 31.70},
+	{Lat: -22.20, Lon: 31.57},
+	{Lat: -22.30, Lon: 31.45},
+	{Lat: -22.42, Lon: 31.30},
+
+	// South Africa border along the Lebombo mountains
+	{Lat: -22.55, Lon: 31.37},
+	{Lat: -22.70, Lon: 31.42},
+	{Lat: -22.85, Lon: 31.48},
+	{Lat: -23.00, Lon: 31.55},
+	{Lat: -23.15, Lon: 31.62},
+	{Lat: -23.30, Lon: 31.68},
+	{Lat: -23.45, Lon: 31.74},
+	{Lat: -23.60, Lon: 31.80},
+	{Lat: -23.75, Lon: 31.86},
+	{Lat: -23.90, Lon: 31.92},
+	{Lat: -24.05, Lon: 31.96},
+	{Lat: -24.25, Lon: 31.98},
+	{Lat: -24.45, Lon: 31.99},
+	{Lat: -24.65, Lon: 31.99},
+	{Lat: -24.85, Lon: 31.99},
+	{Lat: -25.05, Lon: 31.98},
+	{Lat: -25.25, Lon: 31.98},
+	{Lat: -25.44, Lon: 31.97},
+	{Lat: -25.60, Lon: 31.98},
+	{Lat: -25.78, Lon: 31.98},
+	{Lat: -25.95, Lon: 31.97},
+
+	// eSwatini border
+	{Lat: -26.05, Lon: 32.02},
+	{Lat: -26.20, Lon: 32.07},
+	{Lat: -26.35, Lon: 32.08},
+	{Lat: -26.50, Lon: 32.10},
+	{Lat: -26.65, Lon: 32.12},
+	{Lat: -26.84, Lon: 32.13},
+
+	// South Africa border to Ponta do Ouro
+	{Lat: -26.86, Lon: 32.35},
+	{Lat: -26.86, Lon: 32.60},
+	{Lat: -26.87, Lon: 32.89},
+
+	// Coastline, slightly offshore, south to north
+	{Lat: -26.87, Lon: 32.95},
+	{Lat: -26.60, Lon: 32.95},
+	{Lat: -26.30, Lon: 32.97},
+	{Lat: -26.10, Lon: 33.00},
+	{Lat: -25.95, Lon: 33.02},
+	{Lat: -25.85, Lon: 32.90},
+	{Lat: -25.70, Lon: 32.90},
+	{Lat: -25.45, Lon: 33.15},
+	{Lat: -25.25, Lon: 33.50},
+	{Lat: -25.10, Lon: 33.85},
+	{Lat: -24.95, Lon: 34.25},
+	{Lat: -24.80, Lon: 34.65},
+	{Lat: -24.60, Lon: 35.05},
+	{Lat: -24.40, Lon: 35.30},
+	{Lat: -24.10, Lon: 35.50},
+	{Lat: -23.80, Lon: 35.62},
+	{Lat: -23.50, Lon: 35.58},
+	{Lat: -23.20, Lon: 35.60},
+	{Lat: -22.90, Lon: 35.65},
+	{Lat: -22.50, Lon: 35.62},
+	{Lat: -22.10, Lon: 35.60},
+	{Lat: -21.70, Lon: 35.55},
+	{Lat: -21.50, Lon: 35.30},
+	{Lat: -21.25, Lon: 35.22},
+	{Lat: -20.98, Lon: 35.20},
+	{Lat: -20.70, Lon: 35.00},
+	{Lat: -20.45, Lon: 34.90},
+	{Lat: -20.20, Lon: 34.85},
+	{Lat: -19.85, Lon: 34.97},
+	{Lat: -19.50, Lon: 35.15},
+	{Lat: -19.20, Lon: 35.50},
+	{Lat: -18.90, Lon: 35.90},
+	{Lat: -18.62, Lon: 36.55},
+	{Lat: -18.30, Lon: 36.85},
+	{Lat: -17.95, Lon: 37.05},
+	{Lat: -17.60, Lon: 37.40},
+	{Lat: -17.35, Lon: 37.85},
+	{Lat: -17.30, Lon: 38.25},
+	{Lat: -16.95, Lon: 38.75},
+	{Lat: -16.80, Lon: 39.35},
+	{Lat: -16.50, Lon: 39.65},
+	{Lat: -16.25, Lon: 40.05},
+	{Lat: -15.90, Lon: 40.25},
+	{Lat: -15.50, Lon: 40.45},
+	{Lat: -15.03, Lon: 40.82},
+	{Lat: -14.80, Lon: 40.85},
+	{Lat: -14.50, Lon: 40.82},
+	{Lat: -14.20, Lon: 40.65},
+	{Lat: -13.80, Lon: 40.62},
+	{Lat: -13.40, Lon: 40.62},
+	{Lat: -12.96, Lon: 40.62},
+	{Lat: -12.35, Lon: 40.70},
+	{Lat: -12.00, Lon: 40.65},
+	{Lat: -11.60, Lon: 40.55},
+	{Lat: -11.33, Lon: 40.50},
+	{Lat: -11.00, Lon: 40.60},
+	{Lat: -10.77, Lon: 40.60},
+	{Lat: -10.68, Lon: 40.72},
+	{Lat: -10.46, Lon: 40.52},
+}
@@ -0,0 +1,46 @@
+package geo
+
+// City is a named city center.
+type City struct {
+	Name string
+	Lat  float64
+	Lon  float64
+}
+
+// MozambiqueCities lists the 15 largest cities in Mozambique by population,
+// largest first, with the coordinates of their centers.
+var MozambiqueCities = []City{
+	{Name: "Maputo", Lat: -25.9692, Lon: 32.5732},
+	{Name: "Matola", Lat: -25.9622, Lon: 32.4589},
+	{Name: "Nampula", Lat: -15.1165, Lon: 39.2666},
+	{Name: "Beira", Lat: -19.8436, Lon: 34.8389},
+	{Name: "Chimoio", Lat: -19.1164, Lon: 33.4833},
+	{Name: "Tete", Lat: -16.1564, Lon: 33.5867},
+	{Name: "Quelimane", Lat: -17.8786, Lon: 36.8883},
+	{Name: "Nacala", Lat: -14.5428, Lon: 40.6728},
+	{Name: "Mocuba", Lat: -16.8392, Lon: 36.9856},
+	{Name: "Lichinga", Lat: -13.3128, Lon: 35.2406},
+	{Name: "Gurué", Lat: -15.4667, Lon: 36.9833},
+	{Name: "Xai-Xai", Lat: -25.0519, Lon: 33.6442},
+	{Name: "Pemba", Lat: -12.9740, Lon: 40.5178},
+	{Name: "Maxixe", Lat: -23.8597, Lon: 35.3472},
+	{Name: "Cuamba", Lat: -14.8031, Lon: 36.5372},
+}
+
+// FindNearestCity returns the entry of MozambiqueCities closest to the
+// coordinates by Haversine distance, and the distance to it in kilometers.
+// Returns an error for invalid coordinates. Points outside Mozambique are
+// still matched, which helps explain rejected locations.
+func FindNearestCity(lat, lon float64) (name string, distKM float64, err error) {
+	if err := ValidateCoordinates(lat, lon); err != nil {
+		return "", 0, err
+	}
+
+	for i, c := range MozambiqueCities {
+		d := haversineKM(lat, lon, c.Lat, c.Lon)
+		if i == 0 || d < distKM {
+			name, distKM = c.Name, d
+		}
+	}
+	return name, distKM, nil
+}
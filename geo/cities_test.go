@@ -0,0 +1,64 @@
+package geo
+
+import (
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestMozambiqueCities(t *testing.T) {
+	if len(MozambiqueCities) != 15 {
+		t.Errorf("MozambiqueCities has %d entries, want 15", len(MozambiqueCities))
+	}
+
+	seen := make(map[string]bool)
+	for _, c := range MozambiqueCities {
+		if seen[c.Name] {
+			t.Errorf("city %q is listed twice", c.Name)
+		}
+		seen[c.Name] = true
+		if err := ValidateInMozambique(c.Lat, c.Lon); err != nil {
+			t.Errorf("city %q (%v, %v) is outside Mozambique: %v", c.Name, c.Lat, c.Lon, err)
+		}
+	}
+}
+
+func TestFindNearestCity(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		wantCity string
+		maxKM    float64
+	}{
+		{"Maputo center", -25.9692, 32.5732, "Maputo", 0.001},
+		{"Matola outskirts", -25.95, 32.43, "Matola", 5},
+		{"near Beira", -19.9, 34.9, "Beira", 10},
+		{"Tete", -16.15, 33.6, "Tete", 5},
+		{"Quelimane", -17.87, 36.9, "Quelimane", 5},
+		{"Pemba", -12.97, 40.5, "Pemba", 5},
+		{"outside Mozambique", -26.2041, 28.0473, "Matola", 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, dist, err := FindNearestCity(tt.lat, tt.lon)
+			if err != nil {
+				t.Fatalf("FindNearestCity() error = %v", err)
+			}
+			if name != tt.wantCity {
+				t.Errorf("FindNearestCity(%v, %v) = %q, want %q", tt.lat, tt.lon, name, tt.wantCity)
+			}
+			if dist < 0 || dist > tt.maxKM {
+				t.Errorf("FindNearestCity(%v, %v) distance = %v km, want at most %v", tt.lat, tt.lon, dist, tt.maxKM)
+			}
+		})
+	}
+}
+
+func TestFindNearestCity_Invalid(t *testing.T) {
+	_, _, err := FindNearestCity(-100, 32.5)
+	ve, ok := err.(valerrors.ValidationError)
+	if !ok || ve.Field != "latitude" || ve.Code != valerrors.CodeOutOfRange {
+		t.Errorf("FindNearestCity() error = %v, want latitude/OUT_OF_RANGE", err)
+	}
+}
@@ -0,0 +1,194 @@
+package geo
+
+import (
+	"fmt"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// Country names returned by IdentifyCountry.
+const (
+	CountryMozambique  = "Mozambique"
+	CountrySouthAfrica = "South Africa"
+	CountryESwatini    = "eSwatini"
+	CountryZimbabwe    = "Zimbabwe"
+	CountryZambia      = "Zambia"
+	CountryMalawi      = "Malawi"
+	CountryTanzania    = "Tanzania"
+	CountryOcean       = "ocean"
+	CountryUnknown     = "unknown"
+)
+
+// neighborCountry is a coarse outline of a country bordering Mozambique.
+type neighborCountry struct {
+	name     string
+	boundary Polygon
+}
+
+// neighborCountries are checked in order after Mozambique itself, so smaller
+// countries come before the larger outlines that surround them. The outlines
+// are rough and overlap Mozambique along shared borders; they are only meant
+// to label points already rejected by ValidateInMozambique.
+var neighborCountries = []neighborCountry{
+	{CountryESwatini, Polygon{
+		{Lat: -25.72, Lon: 31.30},
+		{Lat: -25.95, Lon: 32.00},
+		{Lat: -26.30, Lon: 32.10},
+		{Lat: -26.85, Lon: 32.10},
+		{Lat: -27.30, Lon: 31.95},
+		{Lat: -27.30, Lon: 31.20},
+		{Lat: -26.90, Lon: 30.80},
+		{Lat: -26.30, Lon: 30.80},
+		{Lat: -25.90, Lon: 31.00},
+	}},
+	{CountryMalawi, Polygon{
+		{Lat: -9.37, Lon: 33.00},
+		{Lat: -9.60, Lon: 33.95},
+		{Lat: -11.50, Lon: 34.30},
+		{Lat: -12.50, Lon: 34.60},
+		{Lat: -14.40, Lon: 35.10},
+		{Lat: -14.50, Lon: 35.90},
+		{Lat: -15.90, Lon: 35.80},
+		{Lat: -17.10, Lon: 35.30},
+		{Lat: -16.50, Lon: 34.90},
+		{Lat: -15.30, Lon: 34.40},
+		{Lat: -14.40, Lon: 34.40},
+		{Lat: -14.00, Lon: 33.60},
+		{Lat: -13.50, Lon: 32.70},
+		{Lat: -12.30, Lon: 33.50},
+		{Lat: -10.80, Lon: 33.25},
+		{Lat: -9.40, Lon: 32.90},
+	}},
+	{CountryZimbabwe, Polygon{
+		{Lat: -17.90, Lon: 25.30},
+		{Lat: -17.30, Lon: 27.00},
+		{Lat: -16.00, Lon: 28.80},
+		{Lat: -15.60, Lon: 30.40},
+		{Lat: -16.00, Lon: 31.30},
+		{Lat: -16.50, Lon: 32.90},
+		{Lat: -18.00, Lon: 33.00},
+		{Lat: -19.50, Lon: 32.80},
+		{Lat: -21.30, Lon: 32.40},
+		{Lat: -22.40, Lon: 31.30},
+		{Lat: -22.20, Lon: 29.40},
+		{Lat: -21.00, Lon: 28.00},
+		{Lat: -20.10, Lon: 27.30},
+		{Lat: -18.60, Lon: 25.90},
+	}},
+	{CountryZambia, Polygon{
+		{Lat: -8.20, Lon: 28.90},
+		{Lat: -8.50, Lon: 31.00},
+		{Lat: -9.30, Lon: 32.90},
+		{Lat: -10.50, Lon: 33.30},
+		{Lat: -12.20, Lon: 33.40},
+		{Lat: -13.50, Lon: 32.90},
+		{Lat: -14.00, Lon: 33.20},
+		{Lat: -15.00, Lon: 30.30},
+		{Lat: -15.60, Lon: 30.40},
+		{Lat: -16.00, Lon: 28.80},
+		{Lat: -17.30, Lon: 27.00},
+		{Lat: -17.90, Lon: 25.30},
+		{Lat: -17.50, Lon: 23.20},
+		{Lat: -16.00, Lon: 22.00},
+		{Lat: -13.00, Lon: 22.00},
+		{Lat: -13.00, Lon: 24.00},
+		{Lat: -11.00, Lon: 24.00},
+		{Lat: -11.20, Lon: 25.30},
+		{Lat: -12.30, Lon: 27.60},
+		{Lat: -11.50, Lon: 28.40},
+		{Lat: -9.00, Lon: 28.50},
+	}},
+	{CountryTanzania, Polygon{
+		{Lat: -1.00, Lon: 30.50},
+		{Lat: -1.00, Lon: 33.90},
+		{Lat: -4.70, Lon: 39.20},
+		{Lat: -6.80, Lon: 39.60},
+		{Lat: -10.47, Lon: 40.44},
+		{Lat: -11.50, Lon: 38.50},
+		{Lat: -11.50, Lon: 35.50},
+		{Lat: -11.30, Lon: 34.90},
+		{Lat: -9.60, Lon: 33.95},
+		{Lat: -9.37, Lon: 33.00},
+		{Lat: -8.50, Lon: 31.00},
+		{Lat: -8.20, Lon: 30.70},
+		{Lat: -6.00, Lon: 29.50},
+		{Lat: -4.50, Lon: 29.60},
+		{Lat: -2.50, Lon: 30.50},
+	}},
+	{CountrySouthAfrica, Polygon{
+		{Lat: -22.40, Lon: 31.30},
+		{Lat: -22.20, Lon: 29.40},
+		{Lat: -23.00, Lon: 27.50},
+		{Lat: -24.70, Lon: 25.90},
+		{Lat: -25.50, Lon: 25.00},
+		{Lat: -24.80, Lon: 20.00},
+		{Lat: -28.60, Lon: 20.00},
+		{Lat: -28.60, Lon: 16.50},
+		{Lat: -31.50, Lon: 18.30},
+		{Lat: -34.40, Lon: 18.40},
+		{Lat: -34.80, Lon: 20.00},
+		{Lat: -34.00, Lon: 25.60},
+		{Lat: -32.00, Lon: 29.00},
+		{Lat: -29.00, Lon: 32.30},
+		{Lat: -26.90, Lon: 32.90},
+		{Lat: -25.95, Lon: 31.98},
+		{Lat: -24.00, Lon: 31.90},
+	}},
+}
+
+// southernOceans covers the Mozambique Channel and the seas off South Africa,
+// stopping short of Madagascar. Its inland edge runs through Mozambique and
+// its neighbors, which are matched first.
+var southernOceans = Polygon{
+	{Lat: -4.70, Lon: 39.20},
+	{Lat: -4.70, Lon: 42.00},
+	{Lat: -15.50, Lon: 43.50},
+	{Lat: -23.50, Lon: 43.00},
+	{Lat: -26.00, Lon: 44.50},
+	{Lat: -36.00, Lon: 45.00},
+	{Lat: -36.00, Lon: 15.00},
+	{Lat: -28.60, Lon: 16.00},
+	{Lat: -29.50, Lon: 17.50},
+	{Lat: -27.00, Lon: 32.00},
+	{Lat: -20.00, Lon: 34.00},
+	{Lat: -12.00, Lon: 37.00},
+	{Lat: -6.00, Lon: 37.00},
+}
+
+// IdentifyCountry returns a coarse guess of the country containing the
+// coordinates: CountryMozambique, one of its six neighbors, CountryOcean for
+// the surrounding seas, or CountryUnknown for anywhere else, including invalid
+// coordinates. The neighbor outlines are approximate and suited to support
+// triage and fraud signals, not to border decisions.
+func IdentifyCountry(lat, lon float64) string {
+	if ValidateCoordinates(lat, lon) != nil {
+		return CountryUnknown
+	}
+	if ValidateInMozambique(lat, lon) == nil {
+		return CountryMozambique
+	}
+	for _, c := range neighborCountries {
+		if c.boundary.Contains(lat, lon) {
+			return c.name
+		}
+	}
+	if southernOceans.Contains(lat, lon) {
+		return CountryOcean
+	}
+	return CountryUnknown
+}
+
+// withCountryHint adds the identified country to an OUTSIDE_SERVICE_AREA error.
+// The error is returned unchanged when the country is unknown.
+func withCountryHint(ve valerrors.ValidationError, lat, lon float64) valerrors.ValidationError {
+	switch country := IdentifyCountry(lat, lon); country {
+	case CountryUnknown, CountryMozambique:
+		return ve
+	case CountryOcean:
+		ve.Message += " (appears to be offshore)"
+		return ve.WithParam("country", country)
+	default:
+		ve.Message += fmt.Sprintf(" (appears to be in %s)", country)
+		return ve.WithParam("country", country)
+	}
+}
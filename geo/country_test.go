@@ -0,0 +1,72 @@
+package geo
+
+import (
+	"strings"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestIdentifyCountry(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     string
+	}{
+		{"Maputo", -25.9692, 32.5732, CountryMozambique},
+		{"Johannesburg", -26.2041, 28.0473, CountrySouthAfrica},
+		{"Mbabane", -26.3054, 31.1367, CountryESwatini},
+		{"Harare", -17.8252, 31.0335, CountryZimbabwe},
+		{"Lusaka", -15.3875, 28.3228, CountryZambia},
+		{"Lilongwe", -13.9626, 33.7741, CountryMalawi},
+		{"Dodoma", -6.1630, 35.7516, CountryTanzania},
+		{"Mozambique Channel", -20.0, 38.0, CountryOcean},
+		{"Antananarivo", -18.8792, 47.5079, CountryUnknown},
+		{"New York", 40.7128, -74.0060, CountryUnknown},
+		{"invalid coordinates", 91, 0, CountryUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IdentifyCountry(tt.lat, tt.lon); got != tt.want {
+				t.Errorf("IdentifyCountry(%v, %v) = %q, want %q", tt.lat, tt.lon, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateInMozambique_WithCountryHint(t *testing.T) {
+	tests := []struct {
+		name        string
+		lat, lon    float64
+		wantCountry string
+		wantMessage string
+	}{
+		{"South Africa", -26.2041, 28.0473, CountrySouthAfrica, "appears to be in South Africa"},
+		{"offshore", -20.0, 38.0, CountryOcean, "appears to be offshore"},
+		{"unknown", 40.7128, -74.0060, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateInMozambique(tt.lat, tt.lon, WithCountryHint())
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Code != valerrors.CodeOutsideServiceArea {
+				t.Fatalf("ValidateInMozambique() error = %v, want OUTSIDE_SERVICE_AREA", err)
+			}
+			if got, _ := ve.Param("country").(string); got != tt.wantCountry {
+				t.Errorf("Params[country] = %q, want %q", got, tt.wantCountry)
+			}
+			if tt.wantMessage != "" && !strings.Contains(ve.Message, tt.wantMessage) {
+				t.Errorf("Message = %q, want it to contain %q", ve.Message, tt.wantMessage)
+			}
+		})
+	}
+
+	t.Run("message unchanged without option", func(t *testing.T) {
+		ve, _ := ValidateInMozambique(-26.2041, 28.0473).(valerrors.ValidationError)
+		if ve.Params != nil || ve.Message != "location is outside the service area" {
+			t.Errorf("ValidateInMozambique() = %+v, want plain error", ve)
+		}
+	})
+}
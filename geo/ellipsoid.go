@@ -0,0 +1,125 @@
+package geo
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// WGS-84 ellipsoid parameters.
+const (
+	wgs84SemiMajorM   = 6378137.0
+	wgs84Flattening   = 1 / 298.257223563
+	wgs84SemiMinorM   = wgs84SemiMajorM * (1 - wgs84Flattening)
+	vincentyTolerance = 1e-12
+	vincentyMaxIter   = 200
+)
+
+// DistanceMode selects the earth model used by CalculateDistance and
+// RouteLengthKM.
+type DistanceMode int32
+
+// Supported distance modes.
+const (
+	// DistanceHaversine treats the earth as a sphere. It is the default.
+	DistanceHaversine DistanceMode = iota
+	// DistanceEllipsoidal uses Vincenty's formula on the WGS-84 ellipsoid,
+	// accurate to within millimeters, for billing-grade distances.
+	DistanceEllipsoidal
+)
+
+// distanceMode holds the current DistanceMode.
+var distanceMode atomic.Int32
+
+// SetDistanceMode sets the earth model used by CalculateDistance and
+// RouteLengthKM for the whole process. Geofencing and proximity checks always
+// use the spherical model. It is safe for concurrent use.
+func SetDistanceMode(mode DistanceMode) {
+	distanceMode.Store(int32(mode))
+}
+
+// GetDistanceMode returns the current DistanceMode.
+func GetDistanceMode() DistanceMode {
+	return DistanceMode(distanceMode.Load())
+}
+
+// CalculateDistanceEllipsoidal returns the distance in kilometers between two
+// points on the WGS-84 ellipsoid using Vincenty's inverse formula. For nearly
+// antipodal points, where the iteration does not converge, it falls back to
+// the Haversine distance.
+func CalculateDistanceEllipsoidal(lat1, lon1, lat2, lon2 float64) (float64, error) {
+	if err := ValidateCoordinates(lat1, lon1); err != nil {
+		return 0, err
+	}
+	if err := ValidateCoordinates(lat2, lon2); err != nil {
+		return 0, err
+	}
+	if d, ok := vincentyKM(lat1, lon1, lat2, lon2); ok {
+		return d, nil
+	}
+	return haversineKM(lat1, lon1, lat2, lon2), nil
+}
+
+// modeDistanceKM returns the distance between two valid points using the
+// current DistanceMode.
+func modeDistanceKM(lat1, lon1, lat2, lon2 float64) float64 {
+	if GetDistanceMode() == DistanceEllipsoidal {
+		if d, ok := vincentyKM(lat1, lon1, lat2, lon2); ok {
+			return d
+		}
+	}
+	return haversineKM(lat1, lon1, lat2, lon2)
+}
+
+// vincentyKM solves the inverse geodesic problem on the WGS-84 ellipsoid.
+// It reports false when the iteration fails to converge.
+func vincentyKM(lat1, lon1, lat2, lon2 float64) (float64, bool) {
+	const f = wgs84Flattening
+	toRad := math.Pi / 180
+
+	l := math.Remainder((lon2-lon1)*toRad, 2*math.Pi)
+	sinU1, cosU1 := math.Sincos(math.Atan((1 - f) * math.Tan(lat1*toRad)))
+	sinU2, cosU2 := math.Sincos(math.Atan((1 - f) * math.Tan(lat2*toRad)))
+
+	lambda := l
+	var sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+	converged := false
+	for i := 0; i < vincentyMaxIter; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			return 0, true // coincident points
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0 // both points on the equator
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+
+		c := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+		prev := lambda
+		lambda = l + (1-c)*f*sinAlpha*
+			(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda) > math.Pi {
+			break // antipodal region; the series diverges
+		}
+		if math.Abs(lambda-prev) < vincentyTolerance {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return 0, false
+	}
+
+	uSq := cosSqAlpha * (wgs84SemiMajorM*wgs84SemiMajorM - wgs84SemiMinorM*wgs84SemiMinorM) /
+		(wgs84SemiMinorM * wgs84SemiMinorM)
+	a := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	b := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	return wgs84SemiMinorM * a * (sigma - deltaSigma) / 1000, true
+}
@@ -0,0 +1,129 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// dms converts degrees, minutes, and seconds to decimal degrees.
+func dms(deg, min, sec float64) float64 {
+	return math.Copysign(math.Abs(deg)+min/60+sec/3600, deg)
+}
+
+func TestCalculateDistanceEllipsoidal(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		wantKM                 float64
+		tolKM                  float64
+	}{
+		// Vincenty (1975) / Geoscience Australia reference line.
+		{
+			"Flinders Peak to Buninyong",
+			dms(-37, 57, 3.72030), dms(144, 25, 29.52440),
+			dms(-37, 39, 10.15610), dms(143, 55, 35.38390),
+			54.972271, 1e-5,
+		},
+		// One degree of longitude on the equator is a*pi/180.
+		{"one degree along the equator", 0, 0, 0, 1, 111.319490793, 1e-6},
+		// WGS-84 meridian quadrant.
+		{"equator to pole", 0, 0, 90, 0, 10001.965729, 1e-5},
+		{"across the antimeridian", 0, 179.5, 0, -179.5, 111.319490793, 1e-6},
+		{"coincident points", -25.9692, 32.5732, -25.9692, 32.5732, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CalculateDistanceEllipsoidal(tt.lat1, tt.lon1, tt.lat2, tt.lon2)
+			if err != nil {
+				t.Fatalf("CalculateDistanceEllipsoidal() error = %v", err)
+			}
+			if math.Abs(got-tt.wantKM) > tt.tolKM {
+				t.Errorf("CalculateDistanceEllipsoidal() = %.9f km, want %.9f km (±%v)", got, tt.wantKM, tt.tolKM)
+			}
+		})
+	}
+}
+
+func TestCalculateDistanceEllipsoidal_Antipodal(t *testing.T) {
+	if _, ok := vincentyKM(0, 0, 0.5, 179.7); ok {
+		t.Fatal("vincentyKM() converged for a nearly antipodal pair")
+	}
+
+	got, err := CalculateDistanceEllipsoidal(0, 0, 0.5, 179.7)
+	if err != nil {
+		t.Fatalf("CalculateDistanceEllipsoidal() error = %v", err)
+	}
+	if want := haversineKM(0, 0, 0.5, 179.7); got != want {
+		t.Errorf("CalculateDistanceEllipsoidal() = %v, want Haversine fallback %v", got, want)
+	}
+}
+
+func TestCalculateDistanceEllipsoidal_Invalid(t *testing.T) {
+	_, err := CalculateDistanceEllipsoidal(-25.9, 32.5, 91, 32.5)
+	ve, ok := err.(valerrors.ValidationError)
+	if !ok || ve.Field != "latitude" || ve.Code != valerrors.CodeOutOfRange {
+		t.Errorf("CalculateDistanceEllipsoidal() error = %v, want latitude/OUT_OF_RANGE", err)
+	}
+}
+
+func TestCalculateDistanceEllipsoidal_MaputoPemba(t *testing.T) {
+	const (
+		maputoLat, maputoLon = -25.9692, 32.5732
+		pembaLat, pembaLon   = -12.9740, 40.5178
+	)
+
+	ellipsoidal, err := CalculateDistanceEllipsoidal(maputoLat, maputoLon, pembaLat, pembaLon)
+	if err != nil {
+		t.Fatalf("CalculateDistanceEllipsoidal() error = %v", err)
+	}
+	spherical := haversineKM(maputoLat, maputoLon, pembaLat, pembaLon)
+
+	// At these latitudes the ellipsoid's meridians are shorter than the
+	// sphere's, so a mostly north-south ride measures shorter.
+	divergence := (spherical - ellipsoidal) / ellipsoidal
+	if divergence < 0.002 || divergence > 0.006 {
+		t.Errorf("Haversine %.3f km vs ellipsoidal %.3f km: divergence %.4f%%, want 0.2-0.6%%",
+			spherical, ellipsoidal, divergence*100)
+	}
+}
+
+func TestSetDistanceMode(t *testing.T) {
+	t.Cleanup(func() { SetDistanceMode(DistanceHaversine) })
+
+	if GetDistanceMode() != DistanceHaversine {
+		t.Fatalf("GetDistanceMode() = %v, want DistanceHaversine by default", GetDistanceMode())
+	}
+
+	route := []Point{{-25.9692, 32.5732}, {-19.8436, 34.8389}, {-12.9740, 40.5178}}
+	spherical, _ := CalculateDistance(-25.9692, 32.5732, -12.9740, 40.5178)
+	sphericalRoute, _ := RouteLengthKM(route)
+
+	SetDistanceMode(DistanceEllipsoidal)
+	if GetDistanceMode() != DistanceEllipsoidal {
+		t.Fatalf("GetDistanceMode() = %v, want DistanceEllipsoidal", GetDistanceMode())
+	}
+
+	got, err := CalculateDistance(-25.9692, 32.5732, -12.9740, 40.5178)
+	if err != nil {
+		t.Fatalf("CalculateDistance() error = %v", err)
+	}
+	want, _ := CalculateDistanceEllipsoidal(-25.9692, 32.5732, -12.9740, 40.5178)
+	if got != want || got == spherical {
+		t.Errorf("CalculateDistance() in ellipsoidal mode = %v, want %v", got, want)
+	}
+
+	routeLength, err := RouteLengthKM(route)
+	if err != nil {
+		t.Fatalf("RouteLengthKM() error = %v", err)
+	}
+	if routeLength >= sphericalRoute {
+		t.Errorf("RouteLengthKM() in ellipsoidal mode = %v, want shorter than spherical %v", routeLength, sphericalRoute)
+	}
+
+	if _, err := CalculateDistance(91, 0, 0, 0); err == nil {
+		t.Error("CalculateDistance() with invalid coordinates returned nil in ellipsoidal mode")
+	}
+}
@@ -2,12 +2,23 @@
 package geo
 
 import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/Dorico-Dynamics/txova-go-types/geo"
 
 	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
 )
 
 // Mozambique bounding box coordinates.
+// These describe only the rectangle enclosing the country, which also covers
+// parts of neighboring countries; use MozambiqueBoundary for the border itself.
+// ValidateInMozambique uses the box as a fast pre-filter.
 const (
 	MozambiqueMinLat = -26.9
 	MozambiqueMaxLat = -10.3
@@ -15,16 +26,309 @@ const (
 	MozambiqueMaxLon = 41.0
 )
 
+// earthRadiusKM is the mean Earth radius used for Haversine distances.
+const earthRadiusKM = 6371.0
+
+// DefaultCoordinatePrecision is the minimum number of decimal places
+// ValidateCoordinatesStrict requires by default.
+const DefaultCoordinatePrecision = 3
+
+// DefaultMaxSpeedKMH is the default plausibility cap for movement between GPS
+// pings. Faster movement suggests spoofed readings; ValidateSpeedBetweenLocations
+// applies it.
+const DefaultMaxSpeedKMH = 200.0
+
+// MaxPlausibleSpeedKMH is the speed cap of ValidateSpeedBetweenLocations.
+//
+// Deprecated: use DefaultMaxSpeedKMH.
+const MaxPlausibleSpeedKMH = DefaultMaxSpeedKMH
+
+// Default operational metadata for Mozambican service areas.
+const (
+	DefaultTimezone = "Africa/Maputo"
+	DefaultCurrency = "MZN"
+)
+
+// kmPerDegreeLat is the approximate length of one degree of latitude.
+const kmPerDegreeLat = earthRadiusKM * math.Pi / 180
+
+// AreaShape identifies how a service area's boundary is defined.
+type AreaShape int
+
+// Supported service area shapes.
+const (
+	// ShapeBox is a latitude/longitude bounding box (MinLat..MaxLat, MinLon..MaxLon).
+	ShapeBox AreaShape = iota
+	// ShapeCircle is a circle of RadiusKM around (CenterLat, CenterLon).
+	ShapeCircle
+)
+
 // ServiceArea represents a geographic service area.
+// The zero Shape is ShapeBox, so areas defined only by their bounds remain boxes.
 type ServiceArea struct {
 	Name   string
 	MinLat float64
 	MaxLat float64
 	MinLon float64
 	MaxLon float64
+
+	// Shape selects the containment mode for the area.
+	Shape AreaShape
+	// CenterLat, CenterLon, and RadiusKM define a ShapeCircle area.
+	CenterLat float64
+	CenterLon float64
+	RadiusKM  float64
+
+	// Timezone is the IANA time zone name used for scheduling, e.g. "Africa/Maputo".
+	Timezone string
+	// Currency is the ISO 4217 code fares are charged in, e.g. "MZN".
+	Currency string
+	// Active reports whether the area is currently operating. Inactive areas
+	// are skipped by ValidateAnyServiceArea, e.g. while paused during an incident.
+	Active bool
+	// LaunchDate is when operations in the area started.
+	LaunchDate time.Time
+
+	// Priority orders overlapping areas in FindServiceAreas; higher values
+	// come first. More specific areas should have higher priority.
+	Priority int
+}
+
+// NewCircularServiceArea creates a service area covering every point within
+// radiusKM of the given center. The Min/Max bounds are set to the circle's
+// bounding box. The area is active, in the Africa/Maputo time zone, and
+// charges in MZN.
+func NewCircularServiceArea(name string, lat, lon, radiusKM float64) ServiceArea {
+	sa := ServiceArea{
+		Name:      name,
+		Shape:     ShapeCircle,
+		CenterLat: lat,
+		CenterLon: lon,
+		RadiusKM:  radiusKM,
+		Timezone:  DefaultTimezone,
+		Currency:  DefaultCurrency,
+		Active:    true,
+	}
+	sa.MinLat, sa.MaxLat, sa.MinLon, sa.MaxLon = sa.bounds()
+	return sa
+}
+
+// Contains reports whether the coordinates fall inside the area.
+// Boundaries are inclusive: a point on a box edge or exactly RadiusKM from a
+// circle's center is inside.
+func (sa ServiceArea) Contains(lat, lon float64) bool {
+	switch sa.Shape {
+	case ShapeCircle:
+		return haversineKM(sa.CenterLat, sa.CenterLon, lat, lon) <= sa.RadiusKM
+	default:
+		return BoundingBoxContains(sa.MinLat, sa.MaxLat, sa.MinLon, sa.MaxLon, lat, lon)
+	}
+}
+
+// BoundingBoxContains reports whether the coordinates fall inside the box.
+// All edges are inclusive.
+func BoundingBoxContains(minLat, maxLat, minLon, maxLon, lat, lon float64) bool {
+	return lat >= minLat && lat <= maxLat && lon >= minLon && lon <= maxLon
+}
+
+// BoundingBoxIntersects reports whether the bounding boxes of two service areas overlap.
+// Boxes that only touch along an edge are considered intersecting.
+// Circular areas are compared using their bounding boxes.
+func BoundingBoxIntersects(a, b ServiceArea) bool {
+	aMinLat, aMaxLat, aMinLon, aMaxLon := a.bounds()
+	bMinLat, bMaxLat, bMinLon, bMaxLon := b.bounds()
+	return aMinLat <= bMaxLat && bMinLat <= aMaxLat && aMinLon <= bMaxLon && bMinLon <= aMaxLon
+}
+
+// MaxServiceAreaOverlap is the largest fraction of a new service area that may
+// be covered by an existing area when registering or updating it.
+const MaxServiceAreaOverlap = 0.5
+
+// overlapSamples is the grid resolution used by OverlapFraction for circles.
+const overlapSamples = 64
+
+// Overlaps reports whether the interiors of the two areas intersect.
+// Areas that only touch along an edge or at a single point do not overlap.
+func (sa ServiceArea) Overlaps(other ServiceArea) bool {
+	switch {
+	case sa.Shape == ShapeCircle && other.Shape == ShapeCircle:
+		return haversineKM(sa.CenterLat, sa.CenterLon, other.CenterLat, other.CenterLon) < sa.RadiusKM+other.RadiusKM
+	case sa.Shape == ShapeCircle:
+		return other.distanceKM(sa.CenterLat, sa.CenterLon) < sa.RadiusKM && !other.isDegenerate()
+	case other.Shape == ShapeCircle:
+		return sa.distanceKM(other.CenterLat, other.CenterLon) < other.RadiusKM && !sa.isDegenerate()
+	default:
+		return sa.MinLat < other.MaxLat && other.MinLat < sa.MaxLat &&
+			sa.MinLon < other.MaxLon && other.MinLon < sa.MaxLon
+	}
+}
+
+// Overlaps reports whether the interiors of two areas intersect.
+// It is equivalent to a.Overlaps(b).
+func Overlaps(a, b ServiceArea) bool {
+	return a.Overlaps(b)
+}
+
+// OverlappingAreas returns the names of registered areas, active or not, whose
+// interiors intersect sa, sorted by name. Use it to review a proposed area
+// before registering it.
+func OverlappingAreas(sa ServiceArea) []string {
+	serviceAreasMu.RLock()
+	defer serviceAreasMu.RUnlock()
+
+	var names []string
+	for name, existing := range serviceAreas {
+		if sa.Overlaps(existing) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isDegenerate returns true for boxes with no area.
+func (sa ServiceArea) isDegenerate() bool {
+	return sa.Shape != ShapeCircle && (sa.MaxLat <= sa.MinLat || sa.MaxLon <= sa.MinLon)
+}
+
+// OverlapFraction returns the fraction of area a that is covered by area b,
+// from 0 (disjoint) to 1 (a lies entirely within b).
+// Boxes are compared exactly in degree space; when either area is a circle the
+// fraction is estimated by sampling a grid over a's bounding box.
+func OverlapFraction(a, b ServiceArea) float64 {
+	if !a.Overlaps(b) {
+		return 0
+	}
+
+	if a.Shape != ShapeCircle && b.Shape != ShapeCircle {
+		latSpan := math.Min(a.MaxLat, b.MaxLat) - math.Max(a.MinLat, b.MinLat)
+		lonSpan := math.Min(a.MaxLon, b.MaxLon) - math.Max(a.MinLon, b.MinLon)
+		return (latSpan * lonSpan) / ((a.MaxLat - a.MinLat) * (a.MaxLon - a.MinLon))
+	}
+
+	minLat, maxLat, minLon, maxLon := a.bounds()
+	inA, inBoth := 0, 0
+	for i := 0; i < overlapSamples; i++ {
+		lat := minLat + (float64(i)+0.5)*(maxLat-minLat)/overlapSamples
+		for j := 0; j < overlapSamples; j++ {
+			lon := minLon + (float64(j)+0.5)*(maxLon-minLon)/overlapSamples
+			if !a.Contains(lat, lon) {
+				continue
+			}
+			inA++
+			if b.Contains(lat, lon) {
+				inBoth++
+			}
+		}
+	}
+	if inA == 0 {
+		return 0
+	}
+	return float64(inBoth) / float64(inA)
+}
+
+// distanceKM returns the distance in kilometers from the point to the area's boundary,
+// or 0 if the point is inside the area.
+func (sa ServiceArea) distanceKM(lat, lon float64) float64 {
+	if sa.Shape == ShapeCircle {
+		return math.Max(0, haversineKM(sa.CenterLat, sa.CenterLon, lat, lon)-sa.RadiusKM)
+	}
+	if sa.Contains(lat, lon) {
+		return 0
+	}
+	nearestLat := math.Max(sa.MinLat, math.Min(lat, sa.MaxLat))
+	nearestLon := math.Max(sa.MinLon, math.Min(lon, sa.MaxLon))
+	return haversineKM(lat, lon, nearestLat, nearestLon)
+}
+
+// bounds returns the bounding box of the area.
+// For circles it is derived from the center and radius.
+func (sa ServiceArea) bounds() (minLat, maxLat, minLon, maxLon float64) {
+	if sa.Shape != ShapeCircle {
+		return sa.MinLat, sa.MaxLat, sa.MinLon, sa.MaxLon
+	}
+	dLat := sa.RadiusKM / kmPerDegreeLat
+	dLon := sa.RadiusKM / (kmPerDegreeLat * math.Cos(sa.CenterLat*math.Pi/180))
+	return sa.CenterLat - dLat, sa.CenterLat + dLat, sa.CenterLon - dLon, sa.CenterLon + dLon
+}
+
+// AreaKM2 returns the approximate surface area in square kilometers. Boxes use
+// the exact area of a latitude/longitude rectangle on a spherical earth;
+// circles use πr². Degenerate boxes have zero area.
+func (sa ServiceArea) AreaKM2() float64 {
+	if sa.Shape == ShapeCircle {
+		return math.Pi * sa.RadiusKM * sa.RadiusKM
+	}
+	if sa.isDegenerate() {
+		return 0
+	}
+	toRad := math.Pi / 180
+	dLon := (sa.MaxLon - sa.MinLon) * toRad
+	return earthRadiusKM * earthRadiusKM * dLon * (math.Sin(sa.MaxLat*toRad) - math.Sin(sa.MinLat*toRad))
+}
+
+// Dimensions returns the east-west and north-south extent of the area in
+// kilometers. A box's width is measured along its middle latitude; a circle's
+// dimensions are both its diameter. Degenerate boxes have zero dimensions.
+func (sa ServiceArea) Dimensions() (widthKM, heightKM float64) {
+	if sa.Shape == ShapeCircle {
+		return 2 * sa.RadiusKM, 2 * sa.RadiusKM
+	}
+	if sa.isDegenerate() {
+		return 0, 0
+	}
+	midLat := (sa.MinLat + sa.MaxLat) / 2
+	widthKM = (sa.MaxLon - sa.MinLon) * kmPerDegreeLat * math.Cos(midLat*math.Pi/180)
+	heightKM = (sa.MaxLat - sa.MinLat) * kmPerDegreeLat
+	return widthKM, heightKM
+}
+
+// AreaStats summarizes the size of a service area.
+type AreaStats struct {
+	AreaKM2  float64
+	WidthKM  float64
+	HeightKM float64
+}
+
+// GetServiceAreaStats returns the size of every registered service area,
+// active or not, keyed by area name.
+func GetServiceAreaStats() map[string]AreaStats {
+	serviceAreasMu.RLock()
+	defer serviceAreasMu.RUnlock()
+
+	stats := make(map[string]AreaStats, len(serviceAreas))
+	for name, sa := range serviceAreas {
+		width, height := sa.Dimensions()
+		stats[name] = AreaStats{AreaKM2: sa.AreaKM2(), WidthKM: width, HeightKM: height}
+	}
+	return stats
+}
+
+// boxCenter returns the midpoint of the area's bounding box.
+func (sa ServiceArea) boxCenter() (lat, lon float64) {
+	minLat, maxLat, minLon, maxLon := sa.bounds()
+	return (minLat + maxLat) / 2, (minLon + maxLon) / 2
+}
+
+// haversineKM returns the great-circle distance between two points in kilometers.
+// Coordinates are assumed to be valid.
+func haversineKM(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	dPhi := phi2 - phi1
+	dLambda := (lon2 - lon1) * math.Pi / 180
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * earthRadiusKM * math.Asin(math.Min(1, math.Sqrt(a)))
 }
 
-// Predefined service areas for Txova operations.
+// serviceAreasMu guards serviceAreas. Readers take the read lock; registration
+// functions take the write lock.
+var serviceAreasMu sync.RWMutex
+
+// serviceAreas is the registry of service areas keyed by area name.
+// It is pre-populated with the built-in Txova operating areas.
 var serviceAreas = map[string]ServiceArea{
 	"maputo": {
 		Name:   "Maputo",
@@ -32,6 +336,12 @@ var serviceAreas = map[string]ServiceArea{
 		MaxLat: -25.8,
 		MinLon: 32.3,
 		MaxLon: 32.7,
+
+		Timezone:   DefaultTimezone,
+		Currency:   DefaultCurrency,
+		Active:     true,
+		LaunchDate: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+		Priority:   10,
 	},
 	"matola": {
 		Name:   "Matola",
@@ -39,6 +349,13 @@ var serviceAreas = map[string]ServiceArea{
 		MaxLat: -25.9,
 		MinLon: 32.3,
 		MaxLon: 32.5,
+
+		Timezone:   DefaultTimezone,
+		Currency:   DefaultCurrency,
+		Active:     true,
+		LaunchDate: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+		// Matola lies within Maputo's box and has its own fare table.
+		Priority: 20,
 	},
 	"beira": {
 		Name:   "Beira",
@@ -46,12 +363,33 @@ var serviceAreas = map[string]ServiceArea{
 		MaxLat: -19.7,
 		MinLon: 34.8,
 		MaxLon: 34.9,
+
+		Timezone:   DefaultTimezone,
+		Currency:   DefaultCurrency,
+		Active:     true,
+		LaunchDate: time.Date(2025, time.September, 1, 0, 0, 0, 0, time.UTC),
+		Priority:   10,
 	},
 }
 
+// cityCenters holds the reference center of each built-in area, used for
+// "within N km of the city center" rules.
+var cityCenters = map[string]Point{
+	"maputo": {Lat: -25.9692, Lon: 32.5732}, // Praça da Independência
+	"matola": {Lat: -25.9622, Lon: 32.4589},
+	"beira":  {Lat: -19.8436, Lon: 34.8389},
+}
+
 // ValidateCoordinates checks if latitude and longitude are within valid global ranges.
 // Latitude must be between -90 and 90, longitude between -180 and 180.
+// NaN and infinite values are rejected as INVALID_FORMAT.
 func ValidateCoordinates(lat, lon float64) error {
+	if math.IsNaN(lat) || math.IsInf(lat, 0) {
+		return valerrors.InvalidFormatWithValue("latitude", "finite number", lat)
+	}
+	if math.IsNaN(lon) || math.IsInf(lon, 0) {
+		return valerrors.InvalidFormatWithValue("longitude", "finite number", lon)
+	}
 	if lat < geo.MinLatitude || lat > geo.MaxLatitude {
 		return valerrors.OutOfRangeWithValue("latitude", geo.MinLatitude, geo.MaxLatitude, lat)
 	}
@@ -61,24 +399,129 @@ func ValidateCoordinates(lat, lon float64) error {
 	return nil
 }
 
+// ValidateCoordinatesStrict applies ValidateCoordinates and additionally rejects
+// likely-bogus payloads: exactly (0, 0) and values with fewer than
+// DefaultCoordinatePrecision decimal places. Use WithMinPrecision to change
+// the precision requirement; WithMinPrecision(0) disables it.
+func ValidateCoordinatesStrict(lat, lon float64, opts ...Option) error {
+	if err := ValidateCoordinates(lat, lon); err != nil {
+		return err
+	}
+	if lat == 0 && lon == 0 {
+		return valerrors.NewWithValue("location", valerrors.CodeInvalidFormat,
+			"location (0, 0) is not a plausible coordinate", fmt.Sprintf("%.6f, %.6f", lat, lon))
+	}
+
+	minPrecision := applyOptions(opts).minPrecision
+	expected := fmt.Sprintf("at least %d decimal places", minPrecision)
+	if decimalPlaces(lat) < minPrecision {
+		return valerrors.InvalidFormatWithValue("latitude", expected, lat)
+	}
+	if decimalPlaces(lon) < minPrecision {
+		return valerrors.InvalidFormatWithValue("longitude", expected, lon)
+	}
+	return nil
+}
+
+// decimalPlaces returns the number of decimal places in the shortest
+// representation of v, e.g. 3 for -25.969 and 0 for 32.
+func decimalPlaces(v float64) int {
+	str := strconv.FormatFloat(v, 'f', -1, 64)
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		return len(str) - i - 1
+	}
+	return 0
+}
+
 // ValidateInMozambique checks if coordinates are within Mozambique's borders.
-func ValidateInMozambique(lat, lon float64) error {
+// Points are checked against the bounding box first, then against the
+// simplified national border returned by MozambiqueBoundary.
+// With WithCountryHint, the OUTSIDE_SERVICE_AREA error names the country the
+// point appears to be in, as reported by IdentifyCountry.
+func ValidateInMozambique(lat, lon float64, opts ...Option) error {
 	// First validate global ranges
 	if err := ValidateCoordinates(lat, lon); err != nil {
 		return err
 	}
 
 	// Check Mozambique bounds
-	if lat < MozambiqueMinLat || lat > MozambiqueMaxLat ||
-		lon < MozambiqueMinLon || lon > MozambiqueMaxLon {
-		return valerrors.OutsideServiceAreaWithValue("location", lat, lon)
+	if !BoundingBoxContains(MozambiqueMinLat, MozambiqueMaxLat, MozambiqueMinLon, MozambiqueMaxLon, lat, lon) ||
+		!mozambiqueBoundary.Contains(lat, lon) {
+		ve := valerrors.OutsideServiceAreaWithValue("location", lat, lon)
+		if applyOptions(opts).countryHint {
+			ve = withCountryHint(ve, lat, lon)
+		}
+		return ve
 	}
 
 	return nil
 }
 
+// MozambiqueBoundary returns a copy of the simplified national border polygon.
+func MozambiqueBoundary() Polygon {
+	boundary := make(Polygon, len(mozambiqueBoundary))
+	copy(boundary, mozambiqueBoundary)
+	return boundary
+}
+
+// clampNudgeDegrees is how far past the border a clamped point is moved, in
+// degrees (about 0.1 m), so it lands strictly inside the boundary polygon.
+const clampNudgeDegrees = 1e-6
+
+// ClampToMozambique snaps coordinates outside Mozambique to the nearest point
+// on its border and reports whether they were moved. Coordinates already
+// inside, or that fail ValidateCoordinates, are returned unchanged.
+//
+// Clamping hides GPS noise near the border for display and map matching.
+// Do not use clamped coordinates for billing or fraud decisions.
+func ClampToMozambique(lat, lon float64) (clampedLat, clampedLon float64, wasClamped bool) {
+	if ValidateCoordinates(lat, lon) != nil || inMozambique(lat, lon) {
+		return lat, lon, false
+	}
+
+	edge := mozambiqueBoundary.nearestEdgePoint(lat, lon)
+	dLat, dLon := edge.Lat-lat, edge.Lon-lon
+	norm := math.Hypot(dLat, dLon)
+	if norm == 0 {
+		return edge.Lat, edge.Lon, true
+	}
+	// Step past the edge, away from the original point, until strictly inside.
+	for nudge := clampNudgeDegrees; nudge < 1e-2; nudge *= 10 {
+		inLat := edge.Lat + dLat/norm*nudge
+		inLon := edge.Lon + dLon/norm*nudge
+		if inMozambique(inLat, inLon) {
+			return inLat, inLon, true
+		}
+	}
+	return edge.Lat, edge.Lon, true
+}
+
+// SnapIfWithin clamps coordinates to Mozambique's border when they lie outside
+// it by at most toleranceKM. Points inside Mozambique are returned unchanged.
+// Points farther out return the OUTSIDE_SERVICE_AREA error from
+// ValidateInMozambique. Like ClampToMozambique, this is meant for display and
+// map matching, not billing.
+func SnapIfWithin(lat, lon, toleranceKM float64) (float64, float64, error) {
+	if !(toleranceKM >= 0) || math.IsInf(toleranceKM, 1) {
+		return lat, lon, valerrors.InvalidFormatWithValue("tolerance", "non-negative number of kilometers", toleranceKM)
+	}
+	err := ValidateInMozambique(lat, lon)
+	if err == nil {
+		return lat, lon, nil
+	}
+	if ve, ok := err.(valerrors.ValidationError); !ok || ve.Code != valerrors.CodeOutsideServiceArea {
+		return lat, lon, err
+	}
+
+	clampedLat, clampedLon, _ := ClampToMozambique(lat, lon)
+	if haversineKM(lat, lon, clampedLat, clampedLon) > toleranceKM {
+		return lat, lon, err
+	}
+	return clampedLat, clampedLon, nil
+}
+
 // ValidateServiceArea checks if coordinates are within a specific service area.
-// The area parameter should be one of: "maputo", "matola", "beira".
+// The area parameter should be the name of a registered area, e.g. "maputo", "matola", "beira".
 func ValidateServiceArea(lat, lon float64, area string) error {
 	// First validate global ranges
 	if err := ValidateCoordinates(lat, lon); err != nil {
@@ -86,13 +529,13 @@ func ValidateServiceArea(lat, lon float64, area string) error {
 	}
 
 	// Get service area bounds
-	sa, exists := serviceAreas[area]
+	sa, exists := lookupServiceArea(area)
 	if !exists {
 		return valerrors.InvalidOptionWithValue("area", GetServiceAreas(), area)
 	}
 
 	// Check if within service area
-	if lat < sa.MinLat || lat > sa.MaxLat || lon < sa.MinLon || lon > sa.MaxLon {
+	if !sa.Contains(lat, lon) {
 		return valerrors.OutsideServiceAreaWithValue("location", lat, lon)
 	}
 
@@ -100,50 +543,456 @@ func ValidateServiceArea(lat, lon float64, area string) error {
 }
 
 // ValidateAnyServiceArea checks if coordinates are within any active service area.
-func ValidateAnyServiceArea(lat, lon float64) error {
+// Areas with Active set to false are ignored.
+// With WithNearestArea, the OUTSIDE_SERVICE_AREA error names the nearest active
+// area and the distance to it in its message and Params.
+func ValidateAnyServiceArea(lat, lon float64, opts ...Option) error {
+	return validateAnyServiceArea(lat, lon, false, opts)
+}
+
+// ValidateAnyServiceAreaIncludingInactive is like ValidateAnyServiceArea but
+// also accepts coordinates inside inactive areas.
+func ValidateAnyServiceAreaIncludingInactive(lat, lon float64, opts ...Option) error {
+	return validateAnyServiceArea(lat, lon, true, opts)
+}
+
+func validateAnyServiceArea(lat, lon float64, includeInactive bool, opts []Option) error {
 	// First validate global ranges
 	if err := ValidateCoordinates(lat, lon); err != nil {
 		return err
 	}
 
+	serviceAreasMu.RLock()
+	defer serviceAreasMu.RUnlock()
+
 	// Check all service areas
 	for _, sa := range serviceAreas {
-		if lat >= sa.MinLat && lat <= sa.MaxLat && lon >= sa.MinLon && lon <= sa.MaxLon {
+		if (sa.Active || includeInactive) && sa.Contains(lat, lon) {
 			return nil
 		}
 	}
 
-	return valerrors.OutsideServiceAreaWithValue("location", lat, lon)
+	ve := valerrors.OutsideServiceAreaWithValue("location", lat, lon)
+	if applyOptions(opts).nearestArea {
+		if name, distance, ok := nearestServiceAreaLocked(lat, lon, includeInactive); ok {
+			ve.Message = fmt.Sprintf("%s (%.1f km from %s)", ve.Message, distance, name)
+			ve = ve.WithParam("nearest_area", name).WithParam("distance_km", distance)
+		}
+	}
+	return ve
+}
+
+// ValidateServiceAreaRadius checks that coordinates lie within radiusKM of the
+// center of the named area's bounding box. The boundary is inclusive.
+// Returns INVALID_OPTION for unknown areas and OUTSIDE_SERVICE_AREA, with the
+// distance in Param("distance_km"), when the point is too far.
+func ValidateServiceAreaRadius(key string, lat, lon, radiusKM float64) error {
+	if !(radiusKM > 0) || math.IsInf(radiusKM, 1) {
+		return valerrors.InvalidFormatWithValue("radius", "positive number of kilometers", radiusKM)
+	}
+	if err := ValidateCoordinates(lat, lon); err != nil {
+		return err
+	}
+	sa, exists := lookupServiceArea(key)
+	if !exists {
+		return valerrors.InvalidOptionWithValue("area", GetServiceAreas(), key)
+	}
+
+	centerLat, centerLon := sa.boxCenter()
+	distance, err := CalculateDistance(centerLat, centerLon, lat, lon)
+	if err != nil {
+		return err
+	}
+	if distance > radiusKM {
+		ve := valerrors.OutsideServiceAreaWithValue("location", lat, lon)
+		return ve.WithParam("distance_km", distance)
+	}
+	return nil
+}
+
+// CityCenter returns the reference center of the named service area.
+// Built-in areas use their city center; other areas use the circle center or
+// the middle of their bounding box.
+// Returns false if the area is not registered.
+func CityCenter(name string) (Point, bool) {
+	sa, exists := lookupServiceArea(name)
+	if !exists {
+		return Point{}, false
+	}
+	if center, ok := cityCenters[name]; ok {
+		return center, true
+	}
+	if sa.Shape == ShapeCircle {
+		return Point{Lat: sa.CenterLat, Lon: sa.CenterLon}, true
+	}
+	lat, lon := sa.boxCenter()
+	return Point{Lat: lat, Lon: lon}, true
+}
+
+// DistanceFromCenterKM returns the distance in kilometers from the coordinates
+// to the named area's CityCenter.
+// Returns INVALID_OPTION listing the registered areas for unknown names.
+func DistanceFromCenterKM(area string, lat, lon float64) (float64, error) {
+	if err := ValidateCoordinates(lat, lon); err != nil {
+		return 0, err
+	}
+	center, ok := CityCenter(area)
+	if !ok {
+		return 0, valerrors.InvalidOptionWithValue("area", GetServiceAreas(), area)
+	}
+	return haversineKM(center.Lat, center.Lon, lat, lon), nil
+}
+
+// NearestServiceArea returns the registered service area closest to the coordinates
+// and the distance in kilometers from the point to that area's boundary.
+// The distance is 0 when the point is inside an area.
+// Returns an error for invalid coordinates or when no service areas are registered.
+func NearestServiceArea(lat, lon float64) (name string, distanceKM float64, err error) {
+	if err := ValidateCoordinates(lat, lon); err != nil {
+		return "", 0, err
+	}
+
+	serviceAreasMu.RLock()
+	defer serviceAreasMu.RUnlock()
+
+	name, distanceKM, ok := nearestServiceAreaLocked(lat, lon, true)
+	if !ok {
+		return "", 0, valerrors.OutsideServiceAreaWithValue("location", lat, lon)
+	}
+	return name, distanceKM, nil
+}
+
+// nearestServiceAreaLocked finds the closest registered area, breaking ties by name.
+// Inactive areas are skipped unless includeInactive is set.
+// The caller must hold serviceAreasMu.
+func nearestServiceAreaLocked(lat, lon float64, includeInactive bool) (string, float64, bool) {
+	names := serviceAreaNamesLocked()
+	sort.Strings(names)
+
+	nearest := ""
+	minDistance := math.Inf(1)
+	for _, name := range names {
+		sa := serviceAreas[name]
+		if !sa.Active && !includeInactive {
+			continue
+		}
+		d := sa.distanceKM(lat, lon)
+		if d < minDistance {
+			nearest = name
+			minDistance = d
+		}
+	}
+	return nearest, minDistance, nearest != ""
 }
 
-// GetServiceAreas returns a list of all active service area names.
+// GetServiceAreas returns a list of all registered service area names,
+// including inactive ones.
 func GetServiceAreas() []string {
-	areas := make([]string, 0, len(serviceAreas))
-	for name := range serviceAreas {
-		areas = append(areas, name)
+	serviceAreasMu.RLock()
+	defer serviceAreasMu.RUnlock()
+
+	return serviceAreaNamesLocked()
+}
+
+// ActiveServiceAreas returns the names of the active service areas, sorted alphabetically.
+func ActiveServiceAreas() []string {
+	serviceAreasMu.RLock()
+	defer serviceAreasMu.RUnlock()
+
+	var areas []string
+	for name, sa := range serviceAreas {
+		if sa.Active {
+			areas = append(areas, name)
+		}
 	}
+	sort.Strings(areas)
 	return areas
 }
 
-// GetServiceArea returns the service area configuration for a given area name.
+// GetServiceArea returns the service area configuration for a given area name,
+// including its operational metadata. The returned value is a copy; modifying it does not affect the registry.
 // Returns nil if the area doesn't exist.
 func GetServiceArea(name string) *ServiceArea {
-	sa, exists := serviceAreas[name]
+	sa, exists := lookupServiceArea(name)
 	if !exists {
 		return nil
 	}
 	return &sa
 }
 
-// FindServiceArea returns the name of the service area containing the coordinates.
-// Returns empty string if not in any service area.
+// FindServiceArea returns the name of the active service area containing the
+// coordinates. When areas overlap, the highest-priority area is returned, with
+// ties broken by name, so the result is stable across calls.
+// Returns empty string if not in any active service area.
 func FindServiceArea(lat, lon float64) string {
+	areas := FindServiceAreas(lat, lon)
+	if len(areas) == 0 {
+		return ""
+	}
+	return areas[0]
+}
+
+// FindServiceAreas returns the names of all active service areas containing
+// the coordinates, sorted by descending Priority and then by name. Areas with
+// Active set to false are ignored, as in ValidateAnyServiceArea.
+// Returns nil if not in any active service area.
+func FindServiceAreas(lat, lon float64) []string {
+	serviceAreasMu.RLock()
+	defer serviceAreasMu.RUnlock()
+
+	var areas []string
 	for name, sa := range serviceAreas {
-		if lat >= sa.MinLat && lat <= sa.MaxLat && lon >= sa.MinLon && lon <= sa.MaxLon {
-			return name
+		if sa.Active && sa.Contains(lat, lon) {
+			areas = append(areas, name)
+		}
+	}
+	sort.Slice(areas, func(i, j int) bool {
+		pi, pj := serviceAreas[areas[i]].Priority, serviceAreas[areas[j]].Priority
+		if pi != pj {
+			return pi > pj
+		}
+		return areas[i] < areas[j]
+	})
+	return areas
+}
+
+// RegisterServiceArea adds a new service area to the registry under the given name.
+// Returns an error if the name is empty, already registered, if the area fails
+// Validate, if the time zone cannot be loaded, or if more than
+// MaxServiceAreaOverlap of it is covered by an existing area with the same or
+// a higher Priority. A nested area with a higher Priority than the area
+// around it, like the built-in Matola inside Maputo, is allowed. With
+// WithoutOverlaps, any overlap with an existing area is rejected.
+// Safe for concurrent use with validation functions.
+func RegisterServiceArea(name string, sa ServiceArea, opts ...Option) error {
+	if err := validateRegistration(name, sa); err != nil {
+		return err
+	}
+
+	serviceAreasMu.Lock()
+	defer serviceAreasMu.Unlock()
+
+	if _, exists := serviceAreas[name]; exists {
+		return valerrors.NewWithValue("name", valerrors.CodeInvalidOption, "service area is already registered", name)
+	}
+	if err := checkOverlapLocked(name, sa, nil, applyOptions(opts).noOverlap); err != nil {
+		return err
+	}
+	serviceAreas[name] = sa
+	return nil
+}
+
+// UpdateServiceArea replaces the configuration of an already registered service area.
+// Returns an error if the area doesn't exist, if the area fails Validate, if
+// the time zone cannot be loaded, or if the update makes more than
+// MaxServiceAreaOverlap of it covered by another area, as in
+// RegisterServiceArea. Overlaps that the update does not increase are
+// allowed, so an area can be paused or reprioritized without moving it. With
+// WithoutOverlaps, any overlap with another area is rejected.
+// Safe for concurrent use with validation functions.
+func UpdateServiceArea(name string, sa ServiceArea, opts ...Option) error {
+	if err := validateRegistration(name, sa); err != nil {
+		return err
+	}
+
+	serviceAreasMu.Lock()
+	defer serviceAreasMu.Unlock()
+
+	previous, exists := serviceAreas[name]
+	if !exists {
+		return valerrors.InvalidOptionWithValue("name", serviceAreaNamesLocked(), name)
+	}
+	if err := checkOverlapLocked(name, sa, &previous, applyOptions(opts).noOverlap); err != nil {
+		return err
+	}
+	serviceAreas[name] = sa
+	return nil
+}
+
+// UnregisterServiceArea removes a service area from the registry.
+// Removing an area that doesn't exist is a no-op.
+// Safe for concurrent use with validation functions.
+func UnregisterServiceArea(name string) {
+	serviceAreasMu.Lock()
+	defer serviceAreasMu.Unlock()
+
+	delete(serviceAreas, name)
+}
+
+// validateRegistration checks that a service area can be added to the registry.
+func validateRegistration(name string, sa ServiceArea) error {
+	if name == "" {
+		return valerrors.Required("name")
+	}
+	if err := sa.Validate(); err != nil {
+		return err
+	}
+	if _, err := time.LoadLocation(sa.Timezone); err != nil {
+		return valerrors.InvalidFormatWithValue("timezone", "IANA time zone name", sa.Timezone)
+	}
+	return nil
+}
+
+// Validate checks that the area's geometry is sane: circles need a valid
+// center and a positive radius, boxes need valid, ordered bounds with a
+// non-zero height and width, and the whole area must lie within Mozambique's
+// bounding box. RegisterServiceArea and UpdateServiceArea call it.
+func (sa ServiceArea) Validate() error {
+	if sa.Shape == ShapeCircle {
+		if err := ValidateCoordinates(sa.CenterLat, sa.CenterLon); err != nil {
+			return scopePointError("center", err)
+		}
+		if !(sa.RadiusKM > 0) || math.IsInf(sa.RadiusKM, 1) {
+			return valerrors.NewWithValue("radius_km", valerrors.CodeOutOfRange, "radius_km must be positive", sa.RadiusKM)
+		}
+	} else {
+		if err := ValidateCoordinates(sa.MinLat, sa.MinLon); err != nil {
+			return scopePointError("min", err)
+		}
+		if err := ValidateCoordinates(sa.MaxLat, sa.MaxLon); err != nil {
+			return scopePointError("max", err)
+		}
+		if sa.isDegenerate() {
+			ve := valerrors.InvalidFormat("bounds", "MinLat < MaxLat and MinLon < MaxLon")
+			ve.Value = fmt.Sprintf("%.6f, %.6f, %.6f, %.6f", sa.MinLat, sa.MaxLat, sa.MinLon, sa.MaxLon)
+			return ve
 		}
 	}
-	return ""
+
+	minLat, maxLat, minLon, maxLon := sa.bounds()
+	if minLat < MozambiqueMinLat || maxLat > MozambiqueMaxLat ||
+		minLon < MozambiqueMinLon || maxLon > MozambiqueMaxLon {
+		return valerrors.OutsideServiceArea("area")
+	}
+	return nil
+}
+
+// checkOverlapLocked rejects sa if another registered area covers more than
+// MaxServiceAreaOverlap of it, or any of it when strict is set. Without
+// strict, an overlap is allowed when sa has a higher Priority than the other
+// area, so lookups settle it, or when previous, the area being updated,
+// already had the same geometry or at least as much overlap. Areas are
+// checked in name order. The caller must hold serviceAreasMu.
+func checkOverlapLocked(name string, sa ServiceArea, previous *ServiceArea, strict bool) error {
+	names := serviceAreaNamesLocked()
+	sort.Strings(names)
+
+	for _, existing := range names {
+		if existing == name {
+			continue
+		}
+		other := serviceAreas[existing]
+		fraction := OverlapFraction(sa, other)
+		rejected := strict && sa.Overlaps(other)
+		if !rejected && fraction > MaxServiceAreaOverlap {
+			rejected = sa.Priority <= other.Priority &&
+				(previous == nil || (!sameGeometry(*previous, sa) && fraction > OverlapFraction(*previous, other)))
+		}
+		if rejected {
+			ve := valerrors.NewWithValue("area", valerrors.CodeDuplicate,
+				fmt.Sprintf("service area overlaps %s by %.0f%%", existing, fraction*100), name)
+			return ve.WithParam("overlaps", existing).WithParam("overlap_fraction", fraction)
+		}
+	}
+	return nil
+}
+
+// sameGeometry reports whether a and b cover the same region.
+func sameGeometry(a, b ServiceArea) bool {
+	if a.Shape != b.Shape {
+		return false
+	}
+	if a.Shape == ShapeCircle {
+		return a.CenterLat == b.CenterLat && a.CenterLon == b.CenterLon && a.RadiusKM == b.RadiusKM
+	}
+	return a.MinLat == b.MinLat && a.MaxLat == b.MaxLat && a.MinLon == b.MinLon && a.MaxLon == b.MaxLon
+}
+
+// lookupServiceArea returns a copy of the named service area.
+func lookupServiceArea(name string) (ServiceArea, bool) {
+	serviceAreasMu.RLock()
+	defer serviceAreasMu.RUnlock()
+
+	sa, exists := serviceAreas[name]
+	return sa, exists
+}
+
+// serviceAreaNamesLocked returns the registered area names.
+// The caller must hold serviceAreasMu.
+func serviceAreaNamesLocked() []string {
+	areas := make([]string, 0, len(serviceAreas))
+	for name := range serviceAreas {
+		areas = append(areas, name)
+	}
+	return areas
+}
+
+// exclusionZonesMu guards exclusionZones.
+var exclusionZonesMu sync.RWMutex
+
+// exclusionZones is the registry of no-pickup zones keyed by zone name.
+// Zones typically lie inside a service area, e.g. an airport apron.
+var exclusionZones = map[string]ServiceArea{}
+
+// RegisterExclusionZone adds a no-pickup zone under the given name.
+// Zones use the same shapes as service areas.
+// Returns an error if the name is empty, already registered, or if the zone
+// extends outside Mozambique.
+// Safe for concurrent use with validation functions.
+func RegisterExclusionZone(name string, zone ServiceArea) error {
+	if err := validateRegistration(name, zone); err != nil {
+		return err
+	}
+
+	exclusionZonesMu.Lock()
+	defer exclusionZonesMu.Unlock()
+
+	if _, exists := exclusionZones[name]; exists {
+		return valerrors.NewWithValue("name", valerrors.CodeInvalidOption, "exclusion zone is already registered", name)
+	}
+	exclusionZones[name] = zone
+	return nil
+}
+
+// UnregisterExclusionZone removes an exclusion zone from the registry.
+// Removing a zone that doesn't exist is a no-op.
+func UnregisterExclusionZone(name string) {
+	exclusionZonesMu.Lock()
+	defer exclusionZonesMu.Unlock()
+
+	delete(exclusionZones, name)
+}
+
+// IsInExclusionZone returns the name of the exclusion zone containing the
+// coordinates. When zones overlap, the alphabetically first name is returned.
+func IsInExclusionZone(lat, lon float64) (string, bool) {
+	exclusionZonesMu.RLock()
+	defer exclusionZonesMu.RUnlock()
+
+	found := ""
+	for name, zone := range exclusionZones {
+		if zone.Contains(lat, lon) && (found == "" || name < found) {
+			found = name
+		}
+	}
+	return found, found != ""
+}
+
+// ValidatePickupAllowed checks that coordinates are inside a service area and
+// outside every exclusion zone. A pickup inside an exclusion zone returns a
+// NOT_ALLOWED error naming the zone in Params ("exclusion_zone").
+func ValidatePickupAllowed(lat, lon float64) error {
+	if err := ValidateAnyServiceArea(lat, lon); err != nil {
+		return err
+	}
+
+	if zone, ok := IsInExclusionZone(lat, lon); ok {
+		ve := valerrors.NotAllowed("location")
+		ve.Message = fmt.Sprintf("pickup is not allowed in %s", zone)
+		ve.Value = fmt.Sprintf("%.6f, %.6f", lat, lon)
+		return ve.WithParam("exclusion_zone", zone)
+	}
+	return nil
 }
 
 // IsInMozambique returns true if the coordinates are within Mozambique.
@@ -156,8 +1005,691 @@ func IsInServiceArea(lat, lon float64) bool {
 	return ValidateAnyServiceArea(lat, lon) == nil
 }
 
+// ValidateManyInMozambique validates every point with ValidateInMozambique and
+// returns one error per invalid point. Coordinate errors are scoped to the
+// point, e.g. "points[17].latitude"; points outside the country are reported
+// as OUTSIDE_SERVICE_AREA on "points[17]".
+// Returns nil if every point is valid, including for an empty slice.
+func ValidateManyInMozambique(points []Point) valerrors.ValidationErrors {
+	var errs valerrors.ValidationErrors
+	for i, p := range points {
+		if inMozambique(p.Lat, p.Lon) {
+			continue
+		}
+		errs.Add(manyPointError(i, p))
+	}
+	return errs
+}
+
+// ValidateManyInServiceAreas validates that every point is inside an active
+// service area and returns one error per invalid point, using the same field
+// paths as ValidateManyInMozambique.
+// The registry is read once, so areas registered during the call are not seen.
+// Returns nil if every point is valid, including for an empty slice.
+func ValidateManyInServiceAreas(points []Point) valerrors.ValidationErrors {
+	if len(points) == 0 {
+		return nil
+	}
+	areas := activeServiceAreasSnapshot()
+
+	var errs valerrors.ValidationErrors
+	for i, p := range points {
+		if containsAny(areas, p.Lat, p.Lon) {
+			continue
+		}
+		errs.Add(manyPointError(i, p))
+	}
+	return errs
+}
+
+// CountValid counts the points that ValidateInMozambique would accept and
+// reject, without constructing any errors. Intended for metrics.
+func CountValid(points []Point) (valid, invalid int) {
+	for _, p := range points {
+		if inMozambique(p.Lat, p.Lon) {
+			valid++
+		}
+	}
+	return valid, len(points) - valid
+}
+
+// inMozambique reports whether the coordinates are inside the country border.
+// NaN and out-of-range values fail the bounding box comparison.
+func inMozambique(lat, lon float64) bool {
+	return BoundingBoxContains(MozambiqueMinLat, MozambiqueMaxLat, MozambiqueMinLon, MozambiqueMaxLon, lat, lon) &&
+		mozambiqueBoundary.Contains(lat, lon)
+}
+
+// manyPointError builds the error for a rejected batch point.
+func manyPointError(i int, p Point) valerrors.ValidationError {
+	if err := ValidateCoordinates(p.Lat, p.Lon); err != nil {
+		return scopePointError(pointsField(i), err)
+	}
+	return valerrors.OutsideServiceAreaWithValue(pointsField(i), p.Lat, p.Lon)
+}
+
+// activeServiceAreasSnapshot returns a copy of the active service areas.
+func activeServiceAreasSnapshot() []ServiceArea {
+	serviceAreasMu.RLock()
+	defer serviceAreasMu.RUnlock()
+
+	areas := make([]ServiceArea, 0, len(serviceAreas))
+	for _, sa := range serviceAreas {
+		if sa.Active {
+			areas = append(areas, sa)
+		}
+	}
+	return areas
+}
+
+// containsAny reports whether any of the areas contains the coordinates.
+func containsAny(areas []ServiceArea, lat, lon float64) bool {
+	for _, sa := range areas {
+		if sa.Contains(lat, lon) {
+			return true
+		}
+	}
+	return false
+}
+
+// Option configures optional behavior of validation functions.
+type Option func(*options)
+
+// options holds the settings applied by Option values.
+type options struct {
+	nearestArea  bool
+	countryHint  bool
+	noOverlap    bool
+	minPrecision int
+}
+
+// WithNearestArea enriches OUTSIDE_SERVICE_AREA errors with the nearest service
+// area ("nearest_area") and the distance to it ("distance_km") in Params.
+// The error message also mentions them.
+func WithNearestArea() Option {
+	return func(o *options) {
+		o.nearestArea = true
+	}
+}
+
+// WithCountryHint enriches OUTSIDE_SERVICE_AREA errors from ValidateInMozambique
+// with the country the point appears to be in ("country" in Params), when it
+// can be identified. The error message also mentions it.
+func WithCountryHint() Option {
+	return func(o *options) {
+		o.countryHint = true
+	}
+}
+
+// WithoutOverlaps makes RegisterServiceArea and UpdateServiceArea reject an
+// area that overlaps any other registered area, not just one that is mostly
+// covered by it.
+func WithoutOverlaps() Option {
+	return func(o *options) {
+		o.noOverlap = true
+	}
+}
+
+// WithMinPrecision sets the minimum number of decimal places required by
+// ValidateCoordinatesStrict. Values of 0 or less disable the check.
+func WithMinPrecision(decimals int) Option {
+	return func(o *options) {
+		o.minPrecision = decimals
+	}
+}
+
+// applyOptions builds the options from the given Option values, starting from the defaults.
+func applyOptions(opts []Option) options {
+	o := options{minPrecision: DefaultCoordinatePrecision}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// ValidateWithinRadius checks that (lat, lon) lies within maxKM of the center.
+// The boundary is inclusive. Returns OUT_OF_RANGE on "distance" with the actual
+// distance when the point is too far, and INVALID_FORMAT on "radius" when maxKM
+// is not positive.
+func ValidateWithinRadius(centerLat, centerLon, lat, lon, maxKM float64) error {
+	if !(maxKM > 0) || math.IsInf(maxKM, 1) {
+		return valerrors.InvalidFormatWithValue("radius", "positive number of kilometers", maxKM)
+	}
+	if err := ValidateCoordinates(centerLat, centerLon); err != nil {
+		return err
+	}
+	if err := ValidateCoordinates(lat, lon); err != nil {
+		return err
+	}
+
+	d := haversineKM(centerLat, centerLon, lat, lon)
+	if d > maxKM {
+		return valerrors.OutOfRangeWithValue("distance", 0, maxKM, d)
+	}
+	return nil
+}
+
+// ValidateLocationWithinRadius is ValidateWithinRadius for Location values.
+func ValidateLocationWithinRadius(center, loc geo.Location, maxKM float64) error {
+	return ValidateWithinRadius(center.Latitude(), center.Longitude(), loc.Latitude(), loc.Longitude(), maxKM)
+}
+
+// IsWithinRadius returns true if (lat, lon) lies within maxKM of the center.
+func IsWithinRadius(centerLat, centerLon, lat, lon, maxKM float64) bool {
+	return ValidateWithinRadius(centerLat, centerLon, lat, lon, maxKM) == nil
+}
+
+// Point is a latitude/longitude pair.
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// Polygon is a closed ring of points; the last point connects back to the first.
+type Polygon []Point
+
+// Contains reports whether the coordinates fall inside the polygon.
+// Latitude and longitude are treated as planar coordinates, which is accurate
+// for polygons that do not cross the antimeridian or enclose a pole.
+// Points exactly on an edge may be classified either way.
+func (p Polygon) Contains(lat, lon float64) bool {
+	inside := false
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[i], p[j]
+		if (a.Lat > lat) != (b.Lat > lat) &&
+			lon < (b.Lon-a.Lon)*(lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// nearestEdgePoint returns the point on the polygon's boundary closest to
+// (lat, lon). Distances use an equirectangular projection centered on the
+// query point, which is accurate for the short distances involved in snapping.
+func (p Polygon) nearestEdgePoint(lat, lon float64) Point {
+	scale := math.Cos(lat * math.Pi / 180)
+	best := Point{Lat: lat, Lon: lon}
+	bestDist := math.Inf(1)
+	for i := range p {
+		a, b := p[i], p[(i+1)%len(p)]
+		ax, ay := (a.Lon-lon)*scale, a.Lat-lat
+		bx, by := (b.Lon-lon)*scale, b.Lat-lat
+		dx, dy := bx-ax, by-ay
+
+		t := 0.0
+		if lenSq := dx*dx + dy*dy; lenSq > 0 {
+			t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/lenSq))
+		}
+		x, y := ax+t*dx, ay+t*dy
+		if d := x*x + y*y; d < bestDist {
+			bestDist = d
+			best = Point{Lat: a.Lat + t*(b.Lat-a.Lat), Lon: a.Lon + t*(b.Lon-a.Lon)}
+		}
+	}
+	return best
+}
+
+// RouteContainment selects the region every point of a route must fall within.
+type RouteContainment int
+
+// Supported route containment levels.
+const (
+	// RouteInCountry requires every point to be inside Mozambique.
+	RouteInCountry RouteContainment = iota
+	// RouteInServiceArea requires every point to be inside an active service area.
+	RouteInServiceArea
+)
+
+// RouteOptions configures ValidateRoute.
+type RouteOptions struct {
+	// Containment selects country-level or service-area-level checks.
+	Containment RouteContainment
+	// SameArea requires all points to share at least one service area.
+	// It only applies with RouteInServiceArea.
+	SameArea bool
+}
+
+// ValidateRoute checks every point of a multi-stop route.
+// Errors use indexed fields such as "route[2]"; points outside the required
+// region are reported as OUTSIDE_SERVICE_AREA with their coordinates.
+// An empty route is reported as REQUIRED.
+func ValidateRoute(points []Point, opts RouteOptions) valerrors.ValidationErrors {
+	if len(points) == 0 {
+		return valerrors.ValidationErrors{valerrors.Required("route")}
+	}
+
+	var errs valerrors.ValidationErrors
+	var common []string
+	sameAreaChecked := false
+
+	for i, p := range points {
+		field := routeField(i)
+		if err := ValidateCoordinates(p.Lat, p.Lon); err != nil {
+			errs.Add(scopePointError(routeField(i), err))
+			continue
+		}
+
+		if opts.Containment == RouteInCountry {
+			if err := ValidateInMozambique(p.Lat, p.Lon); err != nil {
+				errs.Add(valerrors.OutsideServiceAreaWithValue(field, p.Lat, p.Lon))
+			}
+			continue
+		}
+
+		areas := FindServiceAreas(p.Lat, p.Lon)
+		if len(areas) == 0 {
+			errs.Add(valerrors.OutsideServiceAreaWithValue(field, p.Lat, p.Lon))
+			continue
+		}
+		if !opts.SameArea || sameAreaChecked {
+			continue
+		}
+		if common == nil {
+			common = areas
+			continue
+		}
+		if shared := intersectAreas(common, areas); len(shared) > 0 {
+			common = shared
+			continue
+		}
+		ve := valerrors.OutsideServiceAreaWithValue(field, p.Lat, p.Lon)
+		ve.Message = fmt.Sprintf("%s is not in the same service area as the preceding points", field)
+		errs.Add(ve.WithParam("areas", common))
+		sameAreaChecked = true
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// RouteLengthKM returns the total length of the route in kilometers, summing
+// the distance of each leg under the current DistanceMode. Every point is
+// validated first.
+// Routes with fewer than two points have zero length.
+func RouteLengthKM(points []Point) (float64, error) {
+	for i, p := range points {
+		if err := ValidateCoordinates(p.Lat, p.Lon); err != nil {
+			return 0, scopePointError(routeField(i), err)
+		}
+	}
+
+	var total float64
+	for i := 1; i < len(points); i++ {
+		total += modeDistanceKM(points[i-1].Lat, points[i-1].Lon, points[i].Lat, points[i].Lon)
+	}
+	return total, nil
+}
+
+// DistanceToPolylineKM returns the minimum distance in kilometers from the point
+// to any segment of the route, projecting onto each segment rather than only
+// measuring to its vertices. Each segment is projected in a local planar
+// approximation around the point and the distance to the nearest position is
+// then measured along the great circle, which is accurate for road-scale segments.
+// The cost is linear in the number of points; a 500-point route takes well
+// under a millisecond.
+// Returns an error for invalid coordinates or routes with fewer than 2 points.
+func DistanceToPolylineKM(point Point, route []Point) (float64, error) {
+	if len(route) < 2 {
+		return 0, valerrors.NewWithValue("route", valerrors.CodeTooShort, "route must have at least 2 points", len(route))
+	}
+	if err := ValidateCoordinates(point.Lat, point.Lon); err != nil {
+		return 0, err
+	}
+	for i, p := range route {
+		if err := ValidateCoordinates(p.Lat, p.Lon); err != nil {
+			return 0, scopePointError(routeField(i), err)
+		}
+	}
+
+	minDistance := math.Inf(1)
+	for i := 1; i < len(route); i++ {
+		if d := distanceToSegmentKM(point, route[i-1], route[i]); d < minDistance {
+			minDistance = d
+		}
+	}
+	return minDistance, nil
+}
+
+// ValidateWithinCorridor checks that the point lies within maxKM of the route.
+// Returns OUT_OF_RANGE on "deviation" with the computed distance when it doesn't,
+// and INVALID_FORMAT on "max_km" unless maxKM is non-negative and finite.
+func ValidateWithinCorridor(point Point, route []Point, maxKM float64) error {
+	if !(maxKM >= 0) || math.IsInf(maxKM, 1) {
+		return valerrors.InvalidFormatWithValue("max_km", "non-negative number of kilometers", maxKM)
+	}
+	d, err := DistanceToPolylineKM(point, route)
+	if err != nil {
+		return err
+	}
+	if d > maxKM {
+		return valerrors.OutOfRangeWithValue("deviation", 0, maxKM, d)
+	}
+	return nil
+}
+
+// distanceToSegmentKM returns the distance from p to the nearest position on segment ab.
+func distanceToSegmentKM(p, a, b Point) float64 {
+	// Local equirectangular projection centered on p.
+	kmPerDegreeLon := kmPerDegreeLat * math.Cos(p.Lat*math.Pi/180)
+	ax, ay := (a.Lon-p.Lon)*kmPerDegreeLon, (a.Lat-p.Lat)*kmPerDegreeLat
+	bx, by := (b.Lon-p.Lon)*kmPerDegreeLon, (b.Lat-p.Lat)*kmPerDegreeLat
+
+	dx, dy := bx-ax, by-ay
+	t := 0.0
+	if lengthSq := dx*dx + dy*dy; lengthSq > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/lengthSq))
+	}
+
+	nearestLat := a.Lat + t*(b.Lat-a.Lat)
+	nearestLon := a.Lon + t*(b.Lon-a.Lon)
+	return haversineKM(p.Lat, p.Lon, nearestLat, nearestLon)
+}
+
+// routeField returns the indexed field name for a route point.
+func routeField(i int) string {
+	return fmt.Sprintf("route[%d]", i)
+}
+
+// pointsField returns the indexed field name for a point in a batch.
+func pointsField(i int) string {
+	return fmt.Sprintf("points[%d]", i)
+}
+
+// scopePointError scopes a coordinate error to an indexed point field,
+// e.g. "latitude" becomes "route[2].latitude".
+func scopePointError(field string, err error) valerrors.ValidationError {
+	ve, ok := err.(valerrors.ValidationError)
+	if !ok {
+		return valerrors.New(field, valerrors.CodeInvalidFormat, err.Error())
+	}
+	ve.Field = field + "." + ve.Field
+	return ve
+}
+
+// intersectAreas returns the names present in both slices, in the order of a.
+func intersectAreas(a, b []string) []string {
+	var out []string
+	for _, name := range a {
+		for _, other := range b {
+			if name == other {
+				out = append(out, name)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// compassDirections are the 8 compass points, clockwise from north.
+var compassDirections = []string{
+	"north", "northeast", "east", "southeast",
+	"south", "southwest", "west", "northwest",
+}
+
+// Bearing returns the initial great-circle bearing from the first point to the
+// second, in degrees clockwise from north within [0, 360).
+// By convention the bearing between identical points is 0.
+func Bearing(lat1, lon1, lat2, lon2 float64) (degrees float64, err error) {
+	if err := ValidateCoordinates(lat1, lon1); err != nil {
+		return 0, err
+	}
+	if err := ValidateCoordinates(lat2, lon2); err != nil {
+		return 0, err
+	}
+	if lat1 == lat2 && lon1 == lon2 {
+		return 0, nil
+	}
+
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	dLambda := (lon2 - lon1) * math.Pi / 180
+
+	y := math.Sin(dLambda) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLambda)
+
+	degrees = math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+	return degrees, nil
+}
+
+// CompassDirection returns the compass point ("north", "northeast", ...) nearest
+// to the bearing. Any finite value is accepted and normalized to [0, 360).
+// Returns empty string for NaN or infinite bearings.
+func CompassDirection(degrees float64) string {
+	if math.IsNaN(degrees) || math.IsInf(degrees, 0) {
+		return ""
+	}
+	normalized := math.Mod(math.Mod(degrees, 360)+360, 360)
+	index := int(math.Floor((normalized+22.5)/45)) % len(compassDirections)
+	return compassDirections[index]
+}
+
+// DescribeOffset describes where to lies relative to from, e.g. "2.3 km northeast".
+// Identical points are described as "0.0 km" without a direction.
+// Returns empty string if either point has invalid coordinates.
+func DescribeOffset(from, to Point) string {
+	bearing, err := Bearing(from.Lat, from.Lon, to.Lat, to.Lon)
+	if err != nil {
+		return ""
+	}
+	distance := haversineKM(from.Lat, from.Lon, to.Lat, to.Lon)
+	if distance == 0 {
+		return "0.0 km"
+	}
+	return fmt.Sprintf("%.1f km %s", distance, CompassDirection(bearing))
+}
+
+// TimedPoint is a location ping with the time it was recorded.
+type TimedPoint struct {
+	Lat  float64
+	Lon  float64
+	Time time.Time
+}
+
+// SpeedKMH returns the average speed in km/h needed to travel from p1 to p2.
+// Returns an error for invalid coordinates, or if p2 is not strictly after p1.
+func SpeedKMH(p1, p2 TimedPoint) (float64, error) {
+	if err := ValidateCoordinates(p1.Lat, p1.Lon); err != nil {
+		return 0, err
+	}
+	if err := ValidateCoordinates(p2.Lat, p2.Lon); err != nil {
+		return 0, err
+	}
+
+	elapsed := p2.Time.Sub(p1.Time)
+	if elapsed <= 0 {
+		return 0, valerrors.NewWithValue("time", valerrors.CodeOutOfRange,
+			"time must increase between points", elapsed.String())
+	}
+
+	distance := haversineKM(p1.Lat, p1.Lon, p2.Lat, p2.Lon)
+	return distance / elapsed.Hours(), nil
+}
+
+// ValidateMovement rejects physically implausible movement between two pings.
+// Returns OUT_OF_RANGE on "speed" with the computed km/h as Value and the
+// limits in the "min" and "max" params when it exceeds maxSpeedKMH, or an
+// error on "time" when p2 is not after p1. Returns INVALID_FORMAT on
+// "max_speed_kmh" unless maxSpeedKMH is positive and finite.
+func ValidateMovement(p1, p2 TimedPoint, maxSpeedKMH float64) error {
+	if !(maxSpeedKMH > 0) || math.IsInf(maxSpeedKMH, 1) {
+		return valerrors.InvalidFormatWithValue("max_speed_kmh", "positive number of km/h", maxSpeedKMH)
+	}
+	speed, err := SpeedKMH(p1, p2)
+	if err != nil {
+		return err
+	}
+	if speed > maxSpeedKMH {
+		return valerrors.OutOfRangeWithValue("speed", 0, maxSpeedKMH, speed).
+			WithParam("min", 0).
+			WithParam("max", maxSpeedKMH)
+	}
+	return nil
+}
+
+// ValidateSpeedBetweenLocations is ValidateMovement with DefaultMaxSpeedKMH
+// for GPS breadcrumbs that carry the elapsed seconds instead of timestamps.
+// Returns INVALID_FORMAT on "elapsed_seconds" unless elapsedSeconds is
+// positive and finite.
+func ValidateSpeedBetweenLocations(lat1, lon1, lat2, lon2 float64, elapsedSeconds float64) error {
+	if !(elapsedSeconds > 0) || math.IsInf(elapsedSeconds, 1) {
+		return valerrors.InvalidFormatWithValue("elapsed_seconds", "positive number of seconds", elapsedSeconds)
+	}
+
+	// Sub-nanosecond intervals round up to one nanosecond and centuries-long
+	// ones saturate, so the conversion never reaches zero or overflows.
+	elapsed := time.Duration(math.MaxInt64)
+	if nanos := math.Ceil(elapsedSeconds * float64(time.Second)); nanos < math.MaxInt64 {
+		elapsed = time.Duration(nanos)
+	}
+	start := time.Unix(0, 0)
+	return ValidateMovement(
+		TimedPoint{Lat: lat1, Lon: lon1, Time: start},
+		TimedPoint{Lat: lat2, Lon: lon2, Time: start.Add(elapsed)},
+		DefaultMaxSpeedKMH,
+	)
+}
+
+// DistanceMatrixKM returns the haversine distance in kilometers from every
+// origin to every destination; result[i][j] is from origins[i] to
+// destinations[j]. Every point is validated once up front, so this is much
+// cheaper than calling CalculateDistance for each pair.
+// Coordinate errors are scoped to the point, e.g. "destinations[3].latitude".
+func DistanceMatrixKM(origins, destinations []Point) ([][]float64, error) {
+	from, err := toUnitVectors("origins", origins)
+	if err != nil {
+		return nil, err
+	}
+	to, err := toUnitVectors("destinations", destinations)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := make([][]float64, len(from))
+	cells := make([]float64, len(from)*len(to))
+	for i, o := range from {
+		matrix[i] = cells[i*len(to) : (i+1)*len(to) : (i+1)*len(to)]
+		o.distancesTo(to, matrix[i])
+	}
+	return matrix, nil
+}
+
+// DistancesFromKM returns the haversine distance in kilometers from origin to
+// each destination. It is the 1×N case of DistanceMatrixKM.
+func DistancesFromKM(origin Point, destinations []Point) ([]float64, error) {
+	if err := ValidateCoordinates(origin.Lat, origin.Lon); err != nil {
+		return nil, scopePointError("origin", err)
+	}
+	to, err := toUnitVectors("destinations", destinations)
+	if err != nil {
+		return nil, err
+	}
+
+	distances := make([]float64, len(to))
+	newUnitVector(origin).distancesTo(to, distances)
+	return distances, nil
+}
+
+// unitVector is a point on the unit sphere. The great-circle distance between
+// two points follows from the chord between their vectors, which needs no
+// per-pair trigonometry beyond one Asin and is equivalent to haversine.
+type unitVector struct {
+	x, y, z float64
+}
+
+func newUnitVector(p Point) unitVector {
+	sinPhi, cosPhi := math.Sincos(p.Lat * math.Pi / 180)
+	sinLambda, cosLambda := math.Sincos(p.Lon * math.Pi / 180)
+	return unitVector{x: cosPhi * cosLambda, y: cosPhi * sinLambda, z: sinPhi}
+}
+
+// toUnitVectors validates the points and converts them to unit vectors.
+func toUnitVectors(field string, points []Point) ([]unitVector, error) {
+	out := make([]unitVector, len(points))
+	for i, p := range points {
+		if err := ValidateCoordinates(p.Lat, p.Lon); err != nil {
+			return nil, scopePointError(fmt.Sprintf("%s[%d]", field, i), err)
+		}
+		out[i] = newUnitVector(p)
+	}
+	return out, nil
+}
+
+// distancesTo writes the great-circle distance to each destination into out.
+func (o unitVector) distancesTo(destinations []unitVector, out []float64) {
+	for j, d := range destinations {
+		dx, dy, dz := d.x-o.x, d.y-o.y, d.z-o.z
+		halfChord := math.Sqrt(dx*dx+dy*dy+dz*dz) / 2
+		out[j] = 2 * earthRadiusKM * math.Asin(math.Min(1, halfChord))
+	}
+}
+
+// Midpoint returns the great-circle midpoint between two points.
+// Unlike averaging the coordinates, this stays on the shortest path between
+// distant points.
+func Midpoint(lat1, lon1, lat2, lon2 float64) (lat, lon float64, err error) {
+	if err := ValidateCoordinates(lat1, lon1); err != nil {
+		return 0, 0, err
+	}
+	if err := ValidateCoordinates(lat2, lon2); err != nil {
+		return 0, 0, err
+	}
+
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	dLambda := (lon2 - lon1) * math.Pi / 180
+
+	bx := math.Cos(phi2) * math.Cos(dLambda)
+	by := math.Cos(phi2) * math.Sin(dLambda)
+	phiM := math.Atan2(math.Sin(phi1)+math.Sin(phi2), math.Hypot(math.Cos(phi1)+bx, by))
+	lambdaM := lon1*math.Pi/180 + math.Atan2(by, math.Cos(phi1)+bx)
+
+	lon = math.Mod(lambdaM*180/math.Pi+540, 360) - 180
+	return phiM * 180 / math.Pi, lon, nil
+}
+
+// BoundingBox returns the smallest box containing all points, expanded on
+// every side by paddingKM. The longitude padding is converted to degrees at
+// the box's latitude farthest from the equator, so the padding is at least
+// paddingKM everywhere. Results are clamped to valid coordinate ranges.
+// Coordinate errors are scoped to the point, e.g. "points[2].latitude".
+func BoundingBox(points []Point, paddingKM float64) (minLat, minLon, maxLat, maxLon float64, err error) {
+	if len(points) == 0 {
+		return 0, 0, 0, 0, valerrors.Required("points")
+	}
+	if !(paddingKM >= 0) || math.IsInf(paddingKM, 1) {
+		return 0, 0, 0, 0, valerrors.InvalidFormatWithValue("padding", "non-negative number of kilometers", paddingKM)
+	}
+
+	minLat, maxLat = math.Inf(1), math.Inf(-1)
+	minLon, maxLon = math.Inf(1), math.Inf(-1)
+	for i, p := range points {
+		if err := ValidateCoordinates(p.Lat, p.Lon); err != nil {
+			return 0, 0, 0, 0, scopePointError(pointsField(i), err)
+		}
+		minLat, maxLat = math.Min(minLat, p.Lat), math.Max(maxLat, p.Lat)
+		minLon, maxLon = math.Min(minLon, p.Lon), math.Max(maxLon, p.Lon)
+	}
+
+	dLat := paddingKM / kmPerDegreeLat
+	widestLat := math.Max(math.Abs(minLat), math.Abs(maxLat)) * math.Pi / 180
+	dLon := paddingKM / (kmPerDegreeLat * math.Cos(widestLat))
+	if math.IsInf(dLon, 0) || math.IsNaN(dLon) {
+		dLon = 360
+	}
+
+	minLat = math.Max(minLat-dLat, geo.MinLatitude)
+	maxLat = math.Min(maxLat+dLat, geo.MaxLatitude)
+	minLon = math.Max(minLon-dLon, geo.MinLongitude)
+	maxLon = math.Min(maxLon+dLon, geo.MaxLongitude)
+	return minLat, minLon, maxLat, maxLon, nil
+}
+
 // CalculateDistance returns the distance in kilometers between two points.
-// Uses the Haversine formula via the types library.
+// Uses the Haversine formula via the types library, or Vincenty's formula
+// when the DistanceMode is DistanceEllipsoidal.
 func CalculateDistance(lat1, lon1, lat2, lon2 float64) (float64, error) {
 	loc1, err := geo.NewLocation(lat1, lon1)
 	if err != nil {
@@ -167,5 +1699,8 @@ func CalculateDistance(lat1, lon1, lat2, lon2 float64) (float64, error) {
 	if err != nil {
 		return 0, err
 	}
+	if GetDistanceMode() == DistanceEllipsoidal {
+		return modeDistanceKM(lat1, lon1, lat2, lon2), nil
+	}
 	return geo.DistanceKM(loc1, loc2), nil
 }
@@ -1,8 +1,15 @@
 package geo
 
 import (
+	"fmt"
 	"math"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/Dorico-Dynamics/txova-go-types/geo"
 
 	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
 )
@@ -29,6 +36,12 @@ func TestValidateCoordinates(t *testing.T) {
 		{"lon too low", 0, -181, true, valerrors.CodeOutOfRange},
 		{"lon too high", 0, 181, true, valerrors.CodeOutOfRange},
 		{"both invalid", -100, 200, true, valerrors.CodeOutOfRange},
+
+		// Non-finite coordinates
+		{"lat NaN", math.NaN(), 32.573, true, valerrors.CodeInvalidFormat},
+		{"lon NaN", -25.969, math.NaN(), true, valerrors.CodeInvalidFormat},
+		{"lat +Inf", math.Inf(1), 32.573, true, valerrors.CodeInvalidFormat},
+		{"lon -Inf", -25.969, math.Inf(-1), true, valerrors.CodeInvalidFormat},
 	}
 
 	for _, tt := range tests {
@@ -49,6 +62,65 @@ func TestValidateCoordinates(t *testing.T) {
 	}
 }
 
+func TestValidateCoordinates_NonFiniteInherited(t *testing.T) {
+	if IsInMozambique(math.NaN(), 32.573) {
+		t.Error("IsInMozambique(NaN) = true, want false")
+	}
+	if IsInServiceArea(-25.969, math.NaN()) {
+		t.Error("IsInServiceArea(NaN) = true, want false")
+	}
+	if err := ValidateServiceArea(math.Inf(-1), 32.573, "maputo"); err == nil {
+		t.Error("ValidateServiceArea(-Inf) expected error")
+	}
+	if _, err := Bearing(math.NaN(), 0, 0, 0); err == nil {
+		t.Error("Bearing(NaN) expected error")
+	}
+}
+
+func TestValidateCoordinatesStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		lat     float64
+		lon     float64
+		opts    []Option
+		wantErr bool
+		field   string
+		errCode string
+	}{
+		{"valid Maputo", -25.969, 32.573, nil, false, "", ""},
+		{"high precision", -25.96912345, 32.57345678, nil, false, "", ""},
+		{"null island", 0, 0, nil, true, "location", valerrors.CodeInvalidFormat},
+		{"null island without precision check", 0, 0, []Option{WithMinPrecision(0)}, true, "location", valerrors.CodeInvalidFormat},
+		{"zero latitude only", 0, 32.573, []Option{WithMinPrecision(0)}, false, "", ""},
+		{"low precision latitude", -25.9, 32.573, nil, true, "latitude", valerrors.CodeInvalidFormat},
+		{"low precision longitude", -25.969, 32, nil, true, "longitude", valerrors.CodeInvalidFormat},
+		{"custom precision passes", -25.97, 32.57, []Option{WithMinPrecision(2)}, false, "", ""},
+		{"custom precision fails", -25.969, 32.573, []Option{WithMinPrecision(5)}, true, "latitude", valerrors.CodeInvalidFormat},
+		{"precision disabled", -26, 32, []Option{WithMinPrecision(0)}, false, "", ""},
+		{"NaN", math.NaN(), 32.573, nil, true, "latitude", valerrors.CodeInvalidFormat},
+		{"out of range", -91.123, 32.573, nil, true, "latitude", valerrors.CodeOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCoordinatesStrict(tt.lat, tt.lon, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateCoordinatesStrict(%v, %v) error = %v, wantErr %v", tt.lat, tt.lon, err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok {
+				t.Fatalf("expected ValidationError, got %T", err)
+			}
+			if ve.Field != tt.field || ve.Code != tt.errCode {
+				t.Errorf("error = %s/%s, want %s/%s", ve.Field, ve.Code, tt.field, tt.errCode)
+			}
+		})
+	}
+}
+
 func TestValidateInMozambique(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -61,7 +133,7 @@ func TestValidateInMozambique(t *testing.T) {
 		{"Maputo center", -25.969, 32.573, false, ""},
 		{"Beira", -19.84, 34.84, false, ""},
 		{"Nampula", -15.12, 39.27, false, ""},
-		{"Mozambique north", -11.0, 35.0, false, ""},
+		{"Mozambique north", -11.67, 39.56, false, ""},
 		{"Mozambique south", -26.0, 32.5, false, ""},
 
 		// Outside Mozambique
@@ -217,11 +289,80 @@ func TestGetServiceArea(t *testing.T) {
 				if sa.Name != tt.wantName {
 					t.Errorf("GetServiceArea(%q).Name = %v, want %v", tt.area, sa.Name, tt.wantName)
 				}
+				if sa.Timezone != DefaultTimezone || sa.Currency != DefaultCurrency || !sa.Active || sa.LaunchDate.IsZero() {
+					t.Errorf("GetServiceArea(%q) metadata = %q %q %v %v", tt.area, sa.Timezone, sa.Currency, sa.Active, sa.LaunchDate)
+				}
 			}
 		})
 	}
 }
 
+// pauseServiceArea marks a built-in area inactive for the duration of the test.
+func pauseServiceArea(t *testing.T, name string) {
+	t.Helper()
+	original := *GetServiceArea(name)
+	paused := original
+	paused.Active = false
+	if err := UpdateServiceArea(name, paused); err != nil {
+		t.Fatalf("UpdateServiceArea(%q) error = %v", name, err)
+	}
+	t.Cleanup(func() { _ = UpdateServiceArea(name, original) })
+}
+
+func TestServiceArea_Inactive(t *testing.T) {
+	pauseServiceArea(t, "beira")
+
+	if err := ValidateAnyServiceArea(-19.8, 34.85); err == nil {
+		t.Error("ValidateAnyServiceArea() in paused area expected error")
+	} else if ve, ok := err.(valerrors.ValidationError); !ok || ve.Code != valerrors.CodeOutsideServiceArea {
+		t.Errorf("ValidateAnyServiceArea() error = %v, want %v", err, valerrors.CodeOutsideServiceArea)
+	}
+	if err := ValidateAnyServiceAreaIncludingInactive(-19.8, 34.85); err != nil {
+		t.Errorf("ValidateAnyServiceAreaIncludingInactive() error = %v", err)
+	}
+	if err := ValidateAnyServiceArea(-25.95, 32.5); err != nil {
+		t.Errorf("ValidateAnyServiceArea() in active area error = %v", err)
+	}
+
+	got := ActiveServiceAreas()
+	want := []string{"maputo", "matola"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("ActiveServiceAreas() = %v, want %v", got, want)
+	}
+	if n := len(GetServiceAreas()); n != 3 {
+		t.Errorf("GetServiceAreas() returned %d areas, want 3", n)
+	}
+
+	t.Run("nearest area skips paused", func(t *testing.T) {
+		err := ValidateAnyServiceArea(-19.6, 34.85, WithNearestArea())
+		ve, ok := err.(valerrors.ValidationError)
+		if !ok {
+			t.Fatalf("ValidateAnyServiceArea() error = %v, want ValidationError", err)
+		}
+		if ve.Param("nearest_area") != "maputo" {
+			t.Errorf("nearest_area = %v, want maputo", ve.Param("nearest_area"))
+		}
+
+		err = ValidateAnyServiceAreaIncludingInactive(-19.6, 34.85, WithNearestArea())
+		if ve, ok := err.(valerrors.ValidationError); !ok || ve.Param("nearest_area") != "beira" {
+			t.Errorf("ValidateAnyServiceAreaIncludingInactive() error = %v, want nearest beira", err)
+		}
+	})
+}
+
+func TestActiveServiceAreas(t *testing.T) {
+	got := ActiveServiceAreas()
+	want := []string{"beira", "maputo", "matola"}
+	if len(got) != len(want) {
+		t.Fatalf("ActiveServiceAreas() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ActiveServiceAreas()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
 func TestFindServiceArea(t *testing.T) {
 	t.Run("in Maputo only", func(t *testing.T) {
 		// Point in Maputo but outside Matola (lon > 32.5)
@@ -232,10 +373,18 @@ func TestFindServiceArea(t *testing.T) {
 	})
 
 	t.Run("in overlapping Maputo/Matola area", func(t *testing.T) {
-		// Point in both Maputo and Matola - either is acceptable
+		// Point in both Maputo and Matola - the higher-priority Matola wins
 		got := FindServiceArea(-25.95, 32.4)
-		if got != "maputo" && got != "matola" {
-			t.Errorf("FindServiceArea(-25.95, 32.4) = %v, want maputo or matola", got)
+		if got != "matola" {
+			t.Errorf("FindServiceArea(-25.95, 32.4) = %v, want matola", got)
+		}
+	})
+
+	t.Run("stable across calls", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			if got := FindServiceArea(-25.95, 32.4); got != "matola" {
+				t.Fatalf("call %d: FindServiceArea(-25.95, 32.4) = %v, want matola", i, got)
+			}
 		}
 	})
 
@@ -261,6 +410,340 @@ func TestFindServiceArea(t *testing.T) {
 	})
 }
 
+func TestFindServiceAreas_Priority(t *testing.T) {
+	// Two registered areas sharing a narrow strip of Nampula.
+	west := ServiceArea{Name: "Nampula West", MinLat: -15.2, MaxLat: -15.0, MinLon: 39.2, MaxLon: 39.35, Active: true}
+	east := ServiceArea{Name: "Nampula East", MinLat: -15.2, MaxLat: -15.0, MinLon: 39.3, MaxLon: 39.45, Active: true}
+	for name, sa := range map[string]ServiceArea{"nampula-west": west, "nampula-east": east} {
+		if err := RegisterServiceArea(name, sa); err != nil {
+			t.Fatalf("RegisterServiceArea(%q) error = %v", name, err)
+		}
+	}
+	t.Cleanup(func() {
+		UnregisterServiceArea("nampula-west")
+		UnregisterServiceArea("nampula-east")
+	})
+
+	assertAreas := func(t *testing.T, want []string) {
+		t.Helper()
+		for i := 0; i < 100; i++ {
+			got := FindServiceAreas(-15.1, 39.32)
+			if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+				t.Fatalf("call %d: FindServiceAreas() = %v, want %v", i, got, want)
+			}
+		}
+	}
+
+	t.Run("equal priority sorts by name", func(t *testing.T) {
+		if GetServiceArea("nampula-west").Priority != 0 {
+			t.Errorf("default Priority = %d, want 0", GetServiceArea("nampula-west").Priority)
+		}
+		assertAreas(t, []string{"nampula-east", "nampula-west"})
+	})
+
+	t.Run("higher priority first", func(t *testing.T) {
+		west.Priority = 5
+		if err := UpdateServiceArea("nampula-west", west); err != nil {
+			t.Fatalf("UpdateServiceArea() error = %v", err)
+		}
+		assertAreas(t, []string{"nampula-west", "nampula-east"})
+		if got := FindServiceArea(-15.1, 39.32); got != "nampula-west" {
+			t.Errorf("FindServiceArea() = %v, want nampula-west", got)
+		}
+	})
+}
+
+func TestFindServiceAreas(t *testing.T) {
+	tests := []struct {
+		name string
+		lat  float64
+		lon  float64
+		want []string
+	}{
+		{"Maputo/Matola overlap", -25.95, 32.4, []string{"matola", "maputo"}},
+		{"Maputo only", -25.85, 32.6, []string{"maputo"}},
+		{"Beira", -19.8, 34.85, []string{"beira"}},
+		{"outside all", -15.0, 39.0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindServiceAreas(tt.lat, tt.lon)
+			if len(got) != len(tt.want) {
+				t.Fatalf("FindServiceAreas(%v, %v) = %v, want %v", tt.lat, tt.lon, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("FindServiceAreas(%v, %v) = %v, want %v", tt.lat, tt.lon, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFindServiceAreas_SkipsInactive(t *testing.T) {
+	pauseServiceArea(t, "maputo")
+
+	if got := FindServiceAreas(-25.95, 32.4); len(got) != 1 || got[0] != "matola" {
+		t.Errorf("FindServiceAreas() = %v, want [matola]", got)
+	}
+	if got := FindServiceArea(-25.85, 32.6); got != "" {
+		t.Errorf("FindServiceArea() in paused maputo = %q, want empty", got)
+	}
+
+	pauseServiceArea(t, "beira")
+	if got := FindServiceAreas(-19.8, 34.85); got != nil {
+		t.Errorf("FindServiceAreas() in paused area = %v, want nil", got)
+	}
+	if got := FindServiceArea(-19.8, 34.85); got != "" {
+		t.Errorf("FindServiceArea() in paused area = %q, want empty", got)
+	}
+}
+
+func TestServiceArea_Contains(t *testing.T) {
+	maputo := GetServiceArea("maputo")
+	if !maputo.Contains(-25.95, 32.5) {
+		t.Error("Maputo should contain its center")
+	}
+	if !maputo.Contains(-26.1, 32.3) {
+		t.Error("Maputo should contain its corner")
+	}
+	if maputo.Contains(-19.8, 34.85) {
+		t.Error("Maputo should not contain Beira")
+	}
+}
+
+func TestValidateInMozambique_Border(t *testing.T) {
+	// Mozambican cities, including those near the land borders and the coast.
+	cities := []struct {
+		name     string
+		lat, lon float64
+	}{
+		{"Maputo", -25.969, 32.573},
+		{"Matola", -25.962, 32.459},
+		{"Boane", -26.04, 32.33},
+		{"Xai-Xai", -25.05, 33.64},
+		{"Inhambane", -23.86, 35.38},
+		{"Vilankulo", -21.99, 35.32},
+		{"Beira", -19.84, 34.84},
+		{"Chimoio", -19.12, 33.48},
+		{"Manica", -18.94, 32.88},
+		{"Tete", -16.16, 33.59},
+		{"Songo", -15.61, 32.77},
+		{"Quelimane", -17.88, 36.89},
+		{"Milange", -16.10, 35.77},
+		{"Mocuba", -16.84, 36.98},
+		{"Nampula", -15.12, 39.27},
+		{"Ilha de Moçambique", -15.03, 40.73},
+		{"Nacala", -14.56, 40.68},
+		{"Cuamba", -14.80, 36.54},
+		{"Lichinga", -13.31, 35.24},
+		{"Metangula", -12.70, 34.80},
+		{"Pemba", -12.97, 40.52},
+		{"Montepuez", -13.13, 39.00},
+		{"Mueda", -11.67, 39.56},
+		{"Mocímboa da Praia", -11.35, 40.35},
+		{"Palma", -10.77, 40.47},
+	}
+	for _, c := range cities {
+		t.Run(c.name, func(t *testing.T) {
+			if err := ValidateInMozambique(c.lat, c.lon); err != nil {
+				t.Errorf("ValidateInMozambique(%v, %v) error = %v, want nil", c.lat, c.lon, err)
+			}
+		})
+	}
+
+	// Points inside the bounding box but in neighboring countries.
+	foreign := []struct {
+		name     string
+		lat, lon float64
+	}{
+		{"Zimbabwe interior", -20.0, 31.0},
+		{"Harare", -17.83, 31.05},
+		{"Masvingo", -20.07, 30.83},
+		{"Mutare", -18.97, 32.65},
+		{"Chipinge", -20.19, 32.62},
+		{"Lilongwe", -13.98, 33.78},
+		{"Blantyre", -15.79, 35.01},
+		{"Zomba", -15.39, 35.32},
+		{"Mulanje", -16.03, 35.50},
+		{"Mchinji", -13.80, 32.88},
+		{"Chipata", -13.64, 32.65},
+		{"Petauke", -14.25, 31.33},
+		{"Newala", -10.95, 39.28},
+		{"Tunduru", -11.10, 37.35},
+		{"Mbamba Bay", -11.29, 34.77},
+		{"Malelane", -25.48, 31.52},
+		{"Phalaborwa", -23.94, 31.14},
+		{"Thohoyandou", -22.95, 30.48},
+		{"Siteki", -26.45, 31.95},
+		{"Manzini", -26.49, 31.38},
+		{"Mozambique Channel", -20.0, 38.0},
+	}
+	for _, f := range foreign {
+		t.Run(f.name, func(t *testing.T) {
+			err := ValidateInMozambique(f.lat, f.lon)
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Code != valerrors.CodeOutsideServiceArea {
+				t.Errorf("ValidateInMozambique(%v, %v) error = %v, want OUTSIDE_SERVICE_AREA", f.lat, f.lon, err)
+			}
+		})
+	}
+}
+
+func TestMozambiqueBoundary(t *testing.T) {
+	boundary := MozambiqueBoundary()
+	if len(boundary) < 100 {
+		t.Fatalf("MozambiqueBoundary() has %d vertices, want a detailed outline", len(boundary))
+	}
+
+	for i, p := range boundary {
+		if !BoundingBoxContains(MozambiqueMinLat, MozambiqueMaxLat, MozambiqueMinLon, MozambiqueMaxLon, p.Lat, p.Lon) {
+			t.Errorf("vertex %d (%v, %v) is outside the bounding box", i, p.Lat, p.Lon)
+		}
+	}
+
+	boundary[0] = Point{}
+	if MozambiqueBoundary()[0] == (Point{}) {
+		t.Error("MozambiqueBoundary() should return a copy")
+	}
+}
+
+// coastPoint returns a point distKM east of where latitude lat leaves
+// Mozambique's boundary polygon, found by bisection from an inland point.
+func coastPoint(t *testing.T, lat, inlandLon, distKM float64) Point {
+	t.Helper()
+	in, out := inlandLon, MozambiqueMaxLon+1
+	if !inMozambique(lat, in) {
+		t.Fatalf("(%v, %v) is not inside Mozambique", lat, in)
+	}
+	for i := 0; i < 60; i++ {
+		mid := (in + out) / 2
+		if inMozambique(lat, mid) {
+			in = mid
+		} else {
+			out = mid
+		}
+	}
+	return Point{Lat: lat, Lon: out + distKM/(kmPerDegreeLat*math.Cos(lat*math.Pi/180))}
+}
+
+func TestClampToMozambique(t *testing.T) {
+	offshore := coastPoint(t, -25.0, 33.0, 0.05)
+
+	tests := []struct {
+		name        string
+		lat, lon    float64
+		wantClamped bool
+	}{
+		{"inside", -25.9692, 32.5732, false},
+		{"50 m offshore", offshore.Lat, offshore.Lon, true},
+		{"Zimbabwe", -18.97, 32.38, true},
+		{"far away", 40.7128, -74.0060, true},
+		{"invalid coordinates", 91, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lat, lon, clamped := ClampToMozambique(tt.lat, tt.lon)
+			if clamped != tt.wantClamped {
+				t.Fatalf("ClampToMozambique() wasClamped = %v, want %v", clamped, tt.wantClamped)
+			}
+			if !clamped {
+				if lat != tt.lat || lon != tt.lon {
+					t.Errorf("ClampToMozambique() = (%v, %v), want input unchanged", lat, lon)
+				}
+				return
+			}
+			if err := ValidateInMozambique(lat, lon); err != nil {
+				t.Errorf("ClampToMozambique() = (%v, %v), which is rejected: %v", lat, lon, err)
+			}
+		})
+	}
+}
+
+func TestSnapIfWithin(t *testing.T) {
+	offshore := coastPoint(t, -25.0, 33.0, 0.05)
+
+	t.Run("50 m offshore is snapped", func(t *testing.T) {
+		lat, lon, err := SnapIfWithin(offshore.Lat, offshore.Lon, 0.1)
+		if err != nil {
+			t.Fatalf("SnapIfWithin() error = %v", err)
+		}
+		if ValidateInMozambique(lat, lon) != nil {
+			t.Errorf("SnapIfWithin() = (%v, %v), which is still outside", lat, lon)
+		}
+		if d := haversineKM(offshore.Lat, offshore.Lon, lat, lon); d > 0.05 {
+			t.Errorf("SnapIfWithin() moved the point %.3f km, want at most 0.05", d)
+		}
+	})
+
+	t.Run("inside is unchanged", func(t *testing.T) {
+		lat, lon, err := SnapIfWithin(-25.9692, 32.5732, 0.1)
+		if err != nil || lat != -25.9692 || lon != 32.5732 {
+			t.Errorf("SnapIfWithin() = (%v, %v, %v), want input unchanged", lat, lon, err)
+		}
+	})
+
+	tests := []struct {
+		name      string
+		lat, lon  float64
+		tolerance float64
+		wantField string
+		wantCode  string
+	}{
+		{"30 km inland in Zimbabwe", -18.97, 32.38, 1, "location", valerrors.CodeOutsideServiceArea},
+		{"offshore beyond zero tolerance", offshore.Lat, offshore.Lon, 0, "location", valerrors.CodeOutsideServiceArea},
+		{"invalid coordinates", 91, 0, 1, "latitude", valerrors.CodeOutOfRange},
+		{"negative tolerance", offshore.Lat, offshore.Lon, -1, "tolerance", valerrors.CodeInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lat, lon, err := SnapIfWithin(tt.lat, tt.lon, tt.tolerance)
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok {
+				t.Fatalf("SnapIfWithin() error = %v, want ValidationError", err)
+			}
+			if ve.Field != tt.wantField || ve.Code != tt.wantCode {
+				t.Errorf("SnapIfWithin() error = %s/%s, want %s/%s", ve.Field, ve.Code, tt.wantField, tt.wantCode)
+			}
+			if lat != tt.lat || lon != tt.lon {
+				t.Errorf("SnapIfWithin() = (%v, %v), want input unchanged on error", lat, lon)
+			}
+		})
+	}
+}
+
+func TestPolygon_Contains(t *testing.T) {
+	// A concave "L" shape.
+	l := Polygon{{0, 0}, {0, 2}, {1, 2}, {1, 1}, {2, 1}, {2, 0}}
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     bool
+	}{
+		{"inside lower arm", 0.5, 1.5, true},
+		{"inside upper arm", 1.5, 0.5, true},
+		{"in the notch", 1.5, 1.5, false},
+		{"outside", 3, 3, false},
+		{"outside negative", -0.5, 0.5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := l.Contains(tt.lat, tt.lon); got != tt.want {
+				t.Errorf("Contains(%v, %v) = %v, want %v", tt.lat, tt.lon, got, tt.want)
+			}
+		})
+	}
+
+	if (Polygon{}).Contains(0, 0) {
+		t.Error("empty polygon should contain nothing")
+	}
+}
+
 func TestIsInMozambique(t *testing.T) {
 	tests := []struct {
 		name string
@@ -409,3 +892,1950 @@ func TestCalculateDistance_Precision(t *testing.T) {
 		t.Errorf("CalculateDistance(0,0,1,0) = %v km, want ~%v km (tolerance %v)", dist, expected, tolerance)
 	}
 }
+
+// nampula is a service area used to exercise runtime registration.
+var nampula = ServiceArea{
+	Name:   "Nampula",
+	MinLat: -15.2,
+	MaxLat: -15.0,
+	MinLon: 39.2,
+	MaxLon: 39.35,
+	Active: true,
+}
+
+func TestRegisterServiceArea(t *testing.T) {
+	if err := RegisterServiceArea("nampula", nampula); err != nil {
+		t.Fatalf("RegisterServiceArea() error = %v", err)
+	}
+	t.Cleanup(func() { UnregisterServiceArea("nampula") })
+
+	if err := ValidateServiceArea(-15.12, 39.27, "nampula"); err != nil {
+		t.Errorf("ValidateServiceArea() in registered area error = %v", err)
+	}
+	if err := ValidateAnyServiceArea(-15.12, 39.27); err != nil {
+		t.Errorf("ValidateAnyServiceArea() in registered area error = %v", err)
+	}
+	if got := FindServiceArea(-15.12, 39.27); got != "nampula" {
+		t.Errorf("FindServiceArea() = %v, want nampula", got)
+	}
+	if got := len(GetServiceAreas()); got != 4 {
+		t.Errorf("GetServiceAreas() returned %d areas, want 4", got)
+	}
+
+	tests := []struct {
+		name    string
+		area    string
+		sa      ServiceArea
+		errCode string
+	}{
+		{"duplicate name", "nampula", nampula, valerrors.CodeInvalidOption},
+		{"duplicate built-in", "maputo", nampula, valerrors.CodeInvalidOption},
+		{"empty name", "", nampula, valerrors.CodeRequired},
+		{"outside Mozambique", "johannesburg", ServiceArea{Name: "Johannesburg", MinLat: -26.3, MaxLat: -26.0, MinLon: 27.9, MaxLon: 28.2}, valerrors.CodeOutsideServiceArea},
+		{"partly outside Mozambique", "border", ServiceArea{Name: "Border", MinLat: -27.5, MaxLat: -26.0, MinLon: 32.0, MaxLon: 32.5}, valerrors.CodeOutsideServiceArea},
+		{"unknown timezone", "pemba", ServiceArea{Name: "Pemba", MinLat: -13.0, MaxLat: -12.9, MinLon: 40.4, MaxLon: 40.6, Timezone: "Africa/Pemba"}, valerrors.CodeInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterServiceArea(tt.area, tt.sa)
+			if err == nil {
+				t.Fatalf("RegisterServiceArea(%q) expected error", tt.area)
+			}
+			if ve, ok := err.(valerrors.ValidationError); ok {
+				if ve.Code != tt.errCode {
+					t.Errorf("error code = %v, want %v", ve.Code, tt.errCode)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateServiceArea(t *testing.T) {
+	if err := RegisterServiceArea("nampula", nampula); err != nil {
+		t.Fatalf("RegisterServiceArea() error = %v", err)
+	}
+	t.Cleanup(func() { UnregisterServiceArea("nampula") })
+
+	grown := nampula
+	grown.MaxLon = 39.5
+	if err := UpdateServiceArea("nampula", grown); err != nil {
+		t.Fatalf("UpdateServiceArea() error = %v", err)
+	}
+	if err := ValidateServiceArea(-15.1, 39.45, "nampula"); err != nil {
+		t.Errorf("ValidateServiceArea() in grown area error = %v", err)
+	}
+
+	err := UpdateServiceArea("pemba", nampula)
+	if ve, ok := err.(valerrors.ValidationError); !ok || ve.Code != valerrors.CodeInvalidOption {
+		t.Errorf("UpdateServiceArea(unknown) error = %v, want %v", err, valerrors.CodeInvalidOption)
+	}
+
+	outside := nampula
+	outside.MaxLon = 42.0
+	err = UpdateServiceArea("nampula", outside)
+	if ve, ok := err.(valerrors.ValidationError); !ok || ve.Code != valerrors.CodeOutsideServiceArea {
+		t.Errorf("UpdateServiceArea(outside) error = %v, want %v", err, valerrors.CodeOutsideServiceArea)
+	}
+}
+
+func TestUnregisterServiceArea(t *testing.T) {
+	if err := RegisterServiceArea("nampula", nampula); err != nil {
+		t.Fatalf("RegisterServiceArea() error = %v", err)
+	}
+
+	UnregisterServiceArea("nampula")
+
+	if GetServiceArea("nampula") != nil {
+		t.Error("GetServiceArea() should return nil after unregistering")
+	}
+	if err := ValidateAnyServiceArea(-15.12, 39.27); err == nil {
+		t.Error("ValidateAnyServiceArea() should fail after unregistering")
+	}
+
+	// Unregistering an unknown area is a no-op.
+	UnregisterServiceArea("nampula")
+	if got := len(GetServiceAreas()); got != 3 {
+		t.Errorf("GetServiceAreas() returned %d areas, want 3", got)
+	}
+}
+
+func TestGetServiceArea_ReturnsCopy(t *testing.T) {
+	sa := GetServiceArea("maputo")
+	if sa == nil {
+		t.Fatal("GetServiceArea(maputo) = nil")
+	}
+
+	sa.Name = "Mutated"
+	sa.MinLat = -10.5
+
+	again := GetServiceArea("maputo")
+	if again.Name != "Maputo" || again.MinLat != -26.1 {
+		t.Errorf("GetServiceArea() exposed internal state: %+v", *again)
+	}
+}
+
+func TestServiceAreaRegistry_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("temp-%d", i)
+		sa := ServiceArea{
+			Name:   name,
+			MinLat: -20.0 + float64(i)*0.1,
+			MaxLat: -19.95 + float64(i)*0.1,
+			MinLon: 35.0,
+			MaxLon: 35.05,
+		}
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := RegisterServiceArea(name, sa); err != nil {
+				t.Errorf("RegisterServiceArea(%q) error = %v", name, err)
+				return
+			}
+			_ = UpdateServiceArea(name, sa)
+			UnregisterServiceArea(name)
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = ValidateAnyServiceArea(-25.95, 32.5)
+				_ = ValidateServiceArea(-19.8, 34.85, "beira")
+				_ = FindServiceArea(-19.97, 35.02)
+				_ = GetServiceArea("maputo")
+				_ = GetServiceAreas()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if got := len(GetServiceAreas()); got != 3 {
+		t.Errorf("GetServiceAreas() returned %d areas after concurrent registration, want 3", got)
+	}
+}
+
+// nampulaCenter is the center of Nampula city used for circular area tests.
+var nampulaCenter = struct{ lat, lon float64 }{-15.1165, 39.2666}
+
+func TestNewCircularServiceArea(t *testing.T) {
+	sa := NewCircularServiceArea("Nampula Pilot", nampulaCenter.lat, nampulaCenter.lon, 15)
+
+	if sa.Shape != ShapeCircle {
+		t.Errorf("Shape = %v, want ShapeCircle", sa.Shape)
+	}
+	if sa.Name != "Nampula Pilot" || sa.RadiusKM != 15 {
+		t.Errorf("NewCircularServiceArea() = %+v", sa)
+	}
+
+	// Bounding box should enclose the circle.
+	if sa.MinLat >= nampulaCenter.lat || sa.MaxLat <= nampulaCenter.lat ||
+		sa.MinLon >= nampulaCenter.lon || sa.MaxLon <= nampulaCenter.lon {
+		t.Errorf("bounding box does not enclose center: %+v", sa)
+	}
+	northEdge := nampulaCenter.lat + 15/kmPerDegreeLat
+	if math.Abs(sa.MaxLat-northEdge) > 1e-9 {
+		t.Errorf("MaxLat = %v, want %v", sa.MaxLat, northEdge)
+	}
+}
+
+func TestCircularServiceArea(t *testing.T) {
+	sa := NewCircularServiceArea("Nampula", nampulaCenter.lat, nampulaCenter.lon, 15)
+	if err := RegisterServiceArea("nampula", sa); err != nil {
+		t.Fatalf("RegisterServiceArea() error = %v", err)
+	}
+	t.Cleanup(func() { UnregisterServiceArea("nampula") })
+
+	// Points due north of the center at the given distance.
+	north := func(km float64) float64 { return nampulaCenter.lat + km/kmPerDegreeLat }
+
+	tests := []struct {
+		name    string
+		lat     float64
+		lon     float64
+		wantErr bool
+	}{
+		{"center", nampulaCenter.lat, nampulaCenter.lon, false},
+		{"just inside 14.9 km", north(14.9), nampulaCenter.lon, false},
+		{"just outside 15.1 km", north(15.1), nampulaCenter.lon, true},
+		// The bounding box corner is inside the box but outside the circle.
+		{"bounding box corner", sa.MaxLat - 0.001, sa.MaxLon - 0.001, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateServiceArea(tt.lat, tt.lon, "nampula")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateServiceArea(%v, %v) error = %v, wantErr %v", tt.lat, tt.lon, err, tt.wantErr)
+			}
+			if err := ValidateAnyServiceArea(tt.lat, tt.lon); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateAnyServiceArea(%v, %v) error = %v, wantErr %v", tt.lat, tt.lon, err, tt.wantErr)
+			}
+			want := "nampula"
+			if tt.wantErr {
+				want = ""
+			}
+			if got := FindServiceArea(tt.lat, tt.lon); got != want {
+				t.Errorf("FindServiceArea(%v, %v) = %q, want %q", tt.lat, tt.lon, got, want)
+			}
+		})
+	}
+
+	t.Run("exactly at radius is inclusive", func(t *testing.T) {
+		lat := north(15)
+		edge := sa
+		edge.RadiusKM = haversineKM(sa.CenterLat, sa.CenterLon, lat, sa.CenterLon)
+		if !edge.Contains(lat, sa.CenterLon) {
+			t.Error("point exactly at the radius should be inside")
+		}
+	})
+}
+
+func TestCircularServiceArea_OverlapsBox(t *testing.T) {
+	// A circle straddling the northern edge of the Maputo box, mostly outside it.
+	sa := NewCircularServiceArea("Maputo North", -25.78, 32.6, 10)
+	if err := RegisterServiceArea("maputo-north", sa); err != nil {
+		t.Fatalf("RegisterServiceArea() error = %v", err)
+	}
+	t.Cleanup(func() { UnregisterServiceArea("maputo-north") })
+
+	t.Run("in circle only", func(t *testing.T) {
+		if got := FindServiceArea(-25.75, 32.6); got != "maputo-north" {
+			t.Errorf("FindServiceArea() = %q, want maputo-north", got)
+		}
+		if err := ValidateAnyServiceArea(-25.75, 32.6); err != nil {
+			t.Errorf("ValidateAnyServiceArea() error = %v", err)
+		}
+	})
+
+	t.Run("in box only", func(t *testing.T) {
+		if got := FindServiceArea(-26.05, 32.65); got != "maputo" {
+			t.Errorf("FindServiceArea() = %q, want maputo", got)
+		}
+	})
+
+	t.Run("in both", func(t *testing.T) {
+		if got := FindServiceArea(-25.82, 32.6); got != "maputo" {
+			t.Errorf("FindServiceArea() = %q, want maputo", got)
+		}
+		if err := ValidateServiceArea(-25.82, 32.6, "maputo"); err != nil {
+			t.Errorf("ValidateServiceArea(maputo) error = %v", err)
+		}
+		if err := ValidateServiceArea(-25.82, 32.6, "maputo-north"); err != nil {
+			t.Errorf("ValidateServiceArea(maputo-north) error = %v", err)
+		}
+	})
+
+	t.Run("in neither", func(t *testing.T) {
+		if err := ValidateAnyServiceArea(-25.6, 32.6); err == nil {
+			t.Error("ValidateAnyServiceArea() expected error")
+		}
+	})
+}
+
+func TestRegisterServiceArea_Circle(t *testing.T) {
+	tests := []struct {
+		name    string
+		sa      ServiceArea
+		errCode string
+	}{
+		{"zero radius", NewCircularServiceArea("Zero", nampulaCenter.lat, nampulaCenter.lon, 0), valerrors.CodeOutOfRange},
+		{"negative radius", NewCircularServiceArea("Negative", nampulaCenter.lat, nampulaCenter.lon, -5), valerrors.CodeOutOfRange},
+		{"crosses border", NewCircularServiceArea("Ponta do Ouro", -26.84, 32.89, 20), valerrors.CodeOutsideServiceArea},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterServiceArea("circle", tt.sa)
+			if err == nil {
+				UnregisterServiceArea("circle")
+				t.Fatal("RegisterServiceArea() expected error")
+			}
+			if ve, ok := err.(valerrors.ValidationError); ok {
+				if ve.Code != tt.errCode {
+					t.Errorf("error code = %v, want %v", ve.Code, tt.errCode)
+				}
+			}
+		})
+	}
+}
+
+func TestNearestServiceArea(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat      float64
+		lon      float64
+		wantArea string
+		wantMin  float64
+		wantMax  float64
+		wantErr  bool
+	}{
+		{"inside Maputo", -25.85, 32.6, "maputo", 0, 0, false},
+		{"inside Beira", -19.8, 34.85, "beira", 0, 0, false},
+		// West of the Maputo box, level with its southern part (south of Matola).
+		{"west of Maputo", -26.05, 32.2, "maputo", 9.5, 10.5, false},
+		// Pemba is far from every active area; Beira is closest.
+		{"Pemba", -12.97, 40.52, "beira", 900, 1000, false},
+		{"invalid coordinates", -100, 32.5, "", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, distance, err := NearestServiceArea(tt.lat, tt.lon)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NearestServiceArea() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if name != tt.wantArea {
+				t.Errorf("NearestServiceArea() name = %q, want %q", name, tt.wantArea)
+			}
+			if distance < tt.wantMin || distance > tt.wantMax {
+				t.Errorf("NearestServiceArea() distance = %v, want between %v and %v", distance, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestNearestServiceArea_Circle(t *testing.T) {
+	sa := NewCircularServiceArea("Nampula", nampulaCenter.lat, nampulaCenter.lon, 15)
+	if err := RegisterServiceArea("nampula", sa); err != nil {
+		t.Fatalf("RegisterServiceArea() error = %v", err)
+	}
+	t.Cleanup(func() { UnregisterServiceArea("nampula") })
+
+	// 20 km north of the center is 5 km outside the ring.
+	lat := nampulaCenter.lat + 20/kmPerDegreeLat
+	name, distance, err := NearestServiceArea(lat, nampulaCenter.lon)
+	if err != nil {
+		t.Fatalf("NearestServiceArea() error = %v", err)
+	}
+	if name != "nampula" {
+		t.Errorf("NearestServiceArea() name = %q, want nampula", name)
+	}
+	if math.Abs(distance-5) > 0.01 {
+		t.Errorf("NearestServiceArea() distance = %v, want 5", distance)
+	}
+}
+
+func TestValidateAnyServiceArea_WithNearestArea(t *testing.T) {
+	t.Run("without option keeps message", func(t *testing.T) {
+		err := ValidateAnyServiceArea(-26.05, 32.2)
+		ve, ok := err.(valerrors.ValidationError)
+		if !ok {
+			t.Fatalf("expected ValidationError, got %T", err)
+		}
+		if ve.Message != "location is outside the service area" {
+			t.Errorf("Message = %q", ve.Message)
+		}
+		if ve.Params != nil {
+			t.Errorf("Params = %v, want nil", ve.Params)
+		}
+	})
+
+	t.Run("with option", func(t *testing.T) {
+		err := ValidateAnyServiceArea(-26.05, 32.2, WithNearestArea())
+		ve, ok := err.(valerrors.ValidationError)
+		if !ok {
+			t.Fatalf("expected ValidationError, got %T", err)
+		}
+		if ve.Code != valerrors.CodeOutsideServiceArea {
+			t.Errorf("Code = %v, want %v", ve.Code, valerrors.CodeOutsideServiceArea)
+		}
+		if ve.Param("nearest_area") != "maputo" {
+			t.Errorf("Params[nearest_area] = %v, want maputo", ve.Param("nearest_area"))
+		}
+		distance, ok := ve.Param("distance_km").(float64)
+		if !ok || distance < 9.5 || distance > 10.5 {
+			t.Errorf("Params[distance_km] = %v, want ~10", ve.Param("distance_km"))
+		}
+		if ve.Message != "location is outside the service area (10.0 km from maputo)" {
+			t.Errorf("Message = %q", ve.Message)
+		}
+	})
+
+	t.Run("inside area with option", func(t *testing.T) {
+		if err := ValidateAnyServiceArea(-25.95, 32.5, WithNearestArea()); err != nil {
+			t.Errorf("ValidateAnyServiceArea() error = %v", err)
+		}
+	})
+}
+
+func TestBoundingBoxContains(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     bool
+	}{
+		{"inside", -25.95, 32.5, true},
+		{"min corner", -26.1, 32.3, true},
+		{"max corner", -25.8, 32.7, true},
+		{"north of box", -25.79, 32.5, false},
+		{"south of box", -26.11, 32.5, false},
+		{"west of box", -25.95, 32.29, false},
+		{"east of box", -25.95, 32.71, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BoundingBoxContains(-26.1, -25.8, 32.3, 32.7, tt.lat, tt.lon); got != tt.want {
+				t.Errorf("BoundingBoxContains(%v, %v) = %v, want %v", tt.lat, tt.lon, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoundingBoxIntersects(t *testing.T) {
+	maputo := *GetServiceArea("maputo")
+	matola := *GetServiceArea("matola")
+	beira := *GetServiceArea("beira")
+
+	tests := []struct {
+		name string
+		a, b ServiceArea
+		want bool
+	}{
+		{"Maputo contains Matola", maputo, matola, true},
+		{"Matola inside Maputo", matola, maputo, true},
+		{"Maputo and Beira", maputo, beira, false},
+		{"same area", beira, beira, true},
+		{"touching edges", ServiceArea{MinLat: -20, MaxLat: -19, MinLon: 34, MaxLon: 35}, ServiceArea{MinLat: -19, MaxLat: -18, MinLon: 34, MaxLon: 35}, true},
+		{"side by side", ServiceArea{MinLat: -20, MaxLat: -19, MinLon: 34, MaxLon: 35}, ServiceArea{MinLat: -20, MaxLat: -19, MinLon: 35.1, MaxLon: 36}, false},
+		{"circle overlapping box", NewCircularServiceArea("North", -25.8, 32.6, 10), maputo, true},
+		{"circle far from box", NewCircularServiceArea("Nampula", nampulaCenter.lat, nampulaCenter.lon, 15), maputo, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BoundingBoxIntersects(tt.a, tt.b); got != tt.want {
+				t.Errorf("BoundingBoxIntersects() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var (
+	maputoPoint = Point{Lat: -25.85, Lon: 32.6}
+	matolaPoint = Point{Lat: -25.95, Lon: 32.4}
+	beiraPoint  = Point{Lat: -19.8, Lon: 34.85}
+)
+
+func TestValidateRoute(t *testing.T) {
+	johannesburg := Point{Lat: -26.2, Lon: 28.04}
+
+	tests := []struct {
+		name       string
+		points     []Point
+		opts       RouteOptions
+		wantFields []string
+		wantCode   string
+	}{
+		{"empty route", nil, RouteOptions{}, []string{"route"}, valerrors.CodeRequired},
+		{"country route valid", []Point{maputoPoint, matolaPoint, beiraPoint}, RouteOptions{}, nil, ""},
+		{
+			"country route with bad point",
+			[]Point{maputoPoint, matolaPoint, johannesburg, beiraPoint},
+			RouteOptions{Containment: RouteInCountry},
+			[]string{"route[2]"}, valerrors.CodeOutsideServiceArea,
+		},
+		{
+			"service area route with bad point",
+			[]Point{maputoPoint, matolaPoint, {Lat: -15.1, Lon: 39.27}, beiraPoint},
+			RouteOptions{Containment: RouteInServiceArea},
+			[]string{"route[2]"}, valerrors.CodeOutsideServiceArea,
+		},
+		{
+			"invalid coordinates",
+			[]Point{maputoPoint, {Lat: -95, Lon: 32.6}},
+			RouteOptions{},
+			[]string{"route[1].latitude"}, valerrors.CodeOutOfRange,
+		},
+		// Matola lies within Maputo's bounds, so both points share "maputo".
+		{
+			"maputo to matola any area",
+			[]Point{maputoPoint, matolaPoint},
+			RouteOptions{Containment: RouteInServiceArea},
+			nil, "",
+		},
+		{
+			"maputo to matola same area",
+			[]Point{maputoPoint, matolaPoint},
+			RouteOptions{Containment: RouteInServiceArea, SameArea: true},
+			nil, "",
+		},
+		{
+			"maputo to beira any area",
+			[]Point{maputoPoint, beiraPoint},
+			RouteOptions{Containment: RouteInServiceArea},
+			nil, "",
+		},
+		{
+			"maputo to beira same area",
+			[]Point{maputoPoint, matolaPoint, beiraPoint},
+			RouteOptions{Containment: RouteInServiceArea, SameArea: true},
+			[]string{"route[2]"}, valerrors.CodeOutsideServiceArea,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateRoute(tt.points, tt.opts)
+			if len(errs) != len(tt.wantFields) {
+				t.Fatalf("ValidateRoute() = %v, want errors for %v", errs, tt.wantFields)
+			}
+			for i, field := range tt.wantFields {
+				if errs[i].Field != field {
+					t.Errorf("errs[%d].Field = %v, want %v", i, errs[i].Field, field)
+				}
+				if errs[i].Code != tt.wantCode {
+					t.Errorf("errs[%d].Code = %v, want %v", i, errs[i].Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateRoute_SkipsInactive(t *testing.T) {
+	pauseServiceArea(t, "beira")
+
+	errs := ValidateRoute([]Point{maputoPoint, beiraPoint}, RouteOptions{Containment: RouteInServiceArea})
+	if len(errs) != 1 || errs[0].Field != "route[1]" || errs[0].Code != valerrors.CodeOutsideServiceArea {
+		t.Errorf("ValidateRoute() = %v, want OUTSIDE_SERVICE_AREA on route[1]", errs)
+	}
+
+	// Country-level checks do not depend on service areas.
+	if errs := ValidateRoute([]Point{maputoPoint, beiraPoint}, RouteOptions{}); errs != nil {
+		t.Errorf("ValidateRoute() country level = %v, want nil", errs)
+	}
+}
+
+func TestValidateRoute_IncludesCoordinates(t *testing.T) {
+	errs := ValidateRoute([]Point{maputoPoint, {Lat: -26.2, Lon: 28.04}}, RouteOptions{})
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+	if errs[0].Value != "-26.200000, 28.040000" {
+		t.Errorf("Value = %v, want offending coordinates", errs[0].Value)
+	}
+}
+
+func TestValidateRoute_SameAreaParams(t *testing.T) {
+	errs := ValidateRoute([]Point{maputoPoint, beiraPoint}, RouteOptions{Containment: RouteInServiceArea, SameArea: true})
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+	areas, ok := errs[0].Param("areas").([]string)
+	if !ok || len(areas) != 1 || areas[0] != "maputo" {
+		t.Errorf("Params[areas] = %v, want [maputo]", errs[0].Param("areas"))
+	}
+}
+
+func TestRouteLengthKM(t *testing.T) {
+	tests := []struct {
+		name    string
+		points  []Point
+		wantMin float64
+		wantMax float64
+		wantErr bool
+	}{
+		{"empty route", nil, 0, 0, false},
+		{"single point", []Point{maputoPoint}, 0, 0, false},
+		{"maputo to beira", []Point{maputoPoint, beiraPoint}, 700, 750, false},
+		{"round trip doubles", []Point{maputoPoint, beiraPoint, maputoPoint}, 1400, 1500, false},
+		{"invalid point", []Point{maputoPoint, {Lat: 0, Lon: 200}}, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RouteLengthKM(tt.points)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RouteLengthKM() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				ve, ok := err.(valerrors.ValidationError)
+				if !ok || ve.Field != "route[1].longitude" {
+					t.Errorf("error = %v, want route[1].longitude", err)
+				}
+				return
+			}
+			if got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("RouteLengthKM() = %v, want between %v and %v", got, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestValidatePickupAllowed(t *testing.T) {
+	airport := NewCircularServiceArea("Maputo Airport", -25.9208, 32.5726, 1.5)
+	if err := RegisterExclusionZone("maputo_airport", airport); err != nil {
+		t.Fatalf("RegisterExclusionZone() error = %v", err)
+	}
+	t.Cleanup(func() { UnregisterExclusionZone("maputo_airport") })
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		wantErr  bool
+		errCode  string
+	}{
+		{"maputo outside airport", -25.969, 32.573, false, ""},
+		{"airport apron", -25.9208, 32.5726, true, valerrors.CodeNotAllowed},
+		{"outside service areas", -15.1, 39.27, true, valerrors.CodeOutsideServiceArea},
+		{"invalid coordinates", -95, 32.5, true, valerrors.CodeOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePickupAllowed(tt.lat, tt.lon)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidatePickupAllowed(%v, %v) error = %v, wantErr %v", tt.lat, tt.lon, err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok {
+				t.Fatalf("expected ValidationError, got %T", err)
+			}
+			if ve.Code != tt.errCode {
+				t.Errorf("error code = %v, want %v", ve.Code, tt.errCode)
+			}
+			if tt.errCode == valerrors.CodeNotAllowed && ve.Param("exclusion_zone") != "maputo_airport" {
+				t.Errorf("Params[exclusion_zone] = %v, want maputo_airport", ve.Param("exclusion_zone"))
+			}
+		})
+	}
+
+	// Service area validation itself is unaffected by exclusion zones.
+	if err := ValidateAnyServiceArea(-25.9208, 32.5726); err != nil {
+		t.Errorf("ValidateAnyServiceArea() error = %v, want nil inside exclusion zone", err)
+	}
+}
+
+func TestIsInExclusionZone(t *testing.T) {
+	if _, ok := IsInExclusionZone(-25.9208, 32.5726); ok {
+		t.Fatal("expected no exclusion zones registered")
+	}
+
+	port := ServiceArea{Name: "Port", MinLat: -25.98, MaxLat: -25.96, MinLon: 32.54, MaxLon: 32.57}
+	if err := RegisterExclusionZone("port", port); err != nil {
+		t.Fatalf("RegisterExclusionZone() error = %v", err)
+	}
+	t.Cleanup(func() { UnregisterExclusionZone("port") })
+
+	if name, ok := IsInExclusionZone(-25.97, 32.55); !ok || name != "port" {
+		t.Errorf("IsInExclusionZone() = %q, %v, want port, true", name, ok)
+	}
+	if _, ok := IsInExclusionZone(-25.85, 32.6); ok {
+		t.Error("IsInExclusionZone() = true, want false outside zone")
+	}
+}
+
+func TestRegisterExclusionZone_Invalid(t *testing.T) {
+	zone := NewCircularServiceArea("Compound", -25.95, 32.58, 0.5)
+	if err := RegisterExclusionZone("compound", zone); err != nil {
+		t.Fatalf("RegisterExclusionZone() error = %v", err)
+	}
+	t.Cleanup(func() { UnregisterExclusionZone("compound") })
+
+	tests := []struct {
+		name    string
+		zone    string
+		area    ServiceArea
+		errCode string
+	}{
+		{"empty name", "", zone, valerrors.CodeRequired},
+		{"duplicate", "compound", zone, valerrors.CodeInvalidOption},
+		{"zero radius", "bad", NewCircularServiceArea("Bad", -25.95, 32.58, 0), valerrors.CodeOutOfRange},
+		{"outside mozambique", "jnb", NewCircularServiceArea("JNB", -26.13, 28.24, 2), valerrors.CodeOutsideServiceArea},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterExclusionZone(tt.zone, tt.area)
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if ve.Code != tt.errCode {
+				t.Errorf("error code = %v, want %v", ve.Code, tt.errCode)
+			}
+		})
+	}
+}
+
+func TestBearing(t *testing.T) {
+	tests := []struct {
+		name    string
+		lat1    float64
+		lon1    float64
+		lat2    float64
+		lon2    float64
+		want    float64
+		wantErr bool
+	}{
+		{"due east on equator", 0, 0, 0, 1, 90, false},
+		{"due west on equator", 0, 1, 0, 0, 270, false},
+		{"due north", -26, 32.5, -25, 32.5, 0, false},
+		{"due south", -25, 32.5, -26, 32.5, 180, false},
+		{"identical points", -25.969, 32.573, -25.969, 32.573, 0, false},
+		{"from north pole", 90, 0, 0, 0, 180, false},
+		{"invalid start", -91, 0, 0, 0, 0, true},
+		{"invalid end", 0, 0, 0, 181, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Bearing(tt.lat1, tt.lon1, tt.lat2, tt.lon2)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bearing() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if math.IsNaN(got) {
+				t.Fatal("Bearing() = NaN")
+			}
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("Bearing() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBearing_Range(t *testing.T) {
+	got, err := Bearing(maputoPoint.Lat, maputoPoint.Lon, beiraPoint.Lat, beiraPoint.Lon)
+	if err != nil {
+		t.Fatalf("Bearing() error = %v", err)
+	}
+	// Beira is north-northeast of Maputo.
+	if got < 0 || got >= 45 {
+		t.Errorf("Bearing(Maputo, Beira) = %v, want within [0, 45)", got)
+	}
+}
+
+func TestCompassDirection(t *testing.T) {
+	tests := []struct {
+		degrees float64
+		want    string
+	}{
+		{0, "north"},
+		{22.4, "north"},
+		{22.5, "northeast"},
+		{45, "northeast"},
+		{90, "east"},
+		{135, "southeast"},
+		{180, "south"},
+		{225, "southwest"},
+		{270, "west"},
+		{315, "northwest"},
+		{337.5, "north"},
+		{359.9, "north"},
+		{360, "north"},
+		{-90, "west"},
+		{450, "east"},
+		{math.NaN(), ""},
+		{math.Inf(1), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%v", tt.degrees), func(t *testing.T) {
+			if got := CompassDirection(tt.degrees); got != tt.want {
+				t.Errorf("CompassDirection(%v) = %q, want %q", tt.degrees, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeOffset(t *testing.T) {
+	tests := []struct {
+		name string
+		from Point
+		to   Point
+		want string
+	}{
+		{"east along equator", Point{0, 0}, Point{0, 0.1}, "11.1 km east"},
+		{"northeast", Point{-25.97, 32.57}, Point{-25.955, 32.586}, "2.3 km northeast"},
+		{"identical points", maputoPoint, maputoPoint, "0.0 km"},
+		{"invalid point", Point{-95, 0}, maputoPoint, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DescribeOffset(tt.from, tt.to); got != tt.want {
+				t.Errorf("DescribeOffset() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpeedKMH(t *testing.T) {
+	start := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	// One degree of latitude (~111 km) in one hour.
+	got, err := SpeedKMH(
+		TimedPoint{Lat: -26, Lon: 32.5, Time: start},
+		TimedPoint{Lat: -25, Lon: 32.5, Time: start.Add(time.Hour)},
+	)
+	if err != nil {
+		t.Fatalf("SpeedKMH() error = %v", err)
+	}
+	if math.Abs(got-111.19) > 0.1 {
+		t.Errorf("SpeedKMH() = %v, want ~111.19", got)
+	}
+
+	if _, err := SpeedKMH(TimedPoint{Lat: -95, Time: start}, TimedPoint{Time: start.Add(time.Minute)}); err == nil {
+		t.Error("SpeedKMH() expected error for invalid coordinates")
+	}
+}
+
+func TestValidateMovement(t *testing.T) {
+	start := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	origin := TimedPoint{Lat: -25.969, Lon: 32.573, Time: start}
+
+	tests := []struct {
+		name    string
+		next    TimedPoint
+		wantErr bool
+		field   string
+		errCode string
+	}{
+		{
+			"plausible urban hop",
+			TimedPoint{Lat: -25.965, Lon: 32.578, Time: start.Add(30 * time.Second)},
+			false, "", "",
+		},
+		{
+			"stationary",
+			TimedPoint{Lat: -25.969, Lon: 32.573, Time: start.Add(10 * time.Second)},
+			false, "", "",
+		},
+		{
+			"impossible jump",
+			TimedPoint{Lat: -25.52, Lon: 32.573, Time: start.Add(10 * time.Second)},
+			true, "speed", valerrors.CodeOutOfRange,
+		},
+		{
+			"identical timestamps",
+			TimedPoint{Lat: -25.965, Lon: 32.578, Time: start},
+			true, "time", valerrors.CodeOutOfRange,
+		},
+		{
+			"time goes backwards",
+			TimedPoint{Lat: -25.965, Lon: 32.578, Time: start.Add(-time.Second)},
+			true, "time", valerrors.CodeOutOfRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMovement(origin, tt.next, DefaultMaxSpeedKMH)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateMovement() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok {
+				t.Fatalf("expected ValidationError, got %T", err)
+			}
+			if ve.Field != tt.field || ve.Code != tt.errCode {
+				t.Errorf("error = %s/%s, want %s/%s", ve.Field, ve.Code, tt.field, tt.errCode)
+			}
+			if tt.field == "speed" {
+				if speed, ok := ve.Value.(float64); !ok || speed <= DefaultMaxSpeedKMH {
+					t.Errorf("Value = %v, want computed speed above cap", ve.Value)
+				}
+				if ve.Param("max") != DefaultMaxSpeedKMH {
+					t.Errorf("Param(max) = %v, want %v", ve.Param("max"), DefaultMaxSpeedKMH)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateMovement_InvalidMaxSpeed(t *testing.T) {
+	start := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	p1 := TimedPoint{Lat: -25.969, Lon: 32.573, Time: start}
+	p2 := TimedPoint{Lat: -25.965, Lon: 32.578, Time: start.Add(30 * time.Second)}
+
+	for _, maxSpeed := range []float64{0, -150, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		err := ValidateMovement(p1, p2, maxSpeed)
+		ve, ok := err.(valerrors.ValidationError)
+		if !ok || ve.Field != "max_speed_kmh" || ve.Code != valerrors.CodeInvalidFormat {
+			t.Errorf("ValidateMovement(maxSpeed=%v) error = %v, want max_speed_kmh/%s", maxSpeed, err, valerrors.CodeInvalidFormat)
+		}
+	}
+}
+
+func TestValidateSpeedBetweenLocations(t *testing.T) {
+	tests := []struct {
+		name    string
+		lat2    float64
+		elapsed float64
+		wantErr bool
+		field   string
+		errCode string
+	}{
+		// 0.5 degrees of latitude is about 55.6 km.
+		{"highway speed", -25.5, 1200, false, "", ""},
+		{"stationary", -26.0, 1, false, "", ""},
+		{"spoofed jump", -25.5, 900, true, "speed", valerrors.CodeOutOfRange},
+		{"zero elapsed", -25.5, 0, true, "elapsed_seconds", valerrors.CodeInvalidFormat},
+		{"negative elapsed", -25.5, -60, true, "elapsed_seconds", valerrors.CodeInvalidFormat},
+		{"NaN elapsed", -25.5, math.NaN(), true, "elapsed_seconds", valerrors.CodeInvalidFormat},
+		{"infinite elapsed", -25.5, math.Inf(1), true, "elapsed_seconds", valerrors.CodeInvalidFormat},
+		{"centuries elapsed", -25.5, 1e20, false, "", ""},
+		{"sub-nanosecond jump", -25.5, 1e-12, true, "speed", valerrors.CodeOutOfRange},
+		{"sub-nanosecond stationary", -26.0, 1e-12, false, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSpeedBetweenLocations(-26.0, 32.5, tt.lat2, 32.5, tt.elapsed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateSpeedBetweenLocations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok {
+				t.Fatalf("expected ValidationError, got %T", err)
+			}
+			if ve.Field != tt.field || ve.Code != tt.errCode {
+				t.Errorf("error = %s/%s, want %s/%s", ve.Field, ve.Code, tt.field, tt.errCode)
+			}
+			if tt.field == "speed" {
+				if speed, ok := ve.Value.(float64); !ok || speed <= DefaultMaxSpeedKMH {
+					t.Errorf("Value = %v, want computed speed above cap", ve.Value)
+				}
+			}
+		})
+	}
+
+	if err := ValidateSpeedBetweenLocations(91, 32.5, -25.5, 32.5, 60); err == nil {
+		t.Error("ValidateSpeedBetweenLocations() with invalid coordinates returned nil")
+	}
+}
+
+func TestServiceArea_Overlaps(t *testing.T) {
+	box := ServiceArea{MinLat: -26.0, MaxLat: -25.9, MinLon: 32.3, MaxLon: 32.5}
+
+	tests := []struct {
+		name string
+		a, b ServiceArea
+		want bool
+	}{
+		{"box inside box", ServiceArea{MinLat: -25.98, MaxLat: -25.92, MinLon: 32.35, MaxLon: 32.45}, box, true},
+		{"partial boxes", ServiceArea{MinLat: -25.95, MaxLat: -25.8, MinLon: 32.4, MaxLon: 32.6}, box, true},
+		{"boxes touching edge", ServiceArea{MinLat: -25.9, MaxLat: -25.8, MinLon: 32.3, MaxLon: 32.5}, box, false},
+		{"disjoint boxes", ServiceArea{MinLat: -19.9, MaxLat: -19.7, MinLon: 34.8, MaxLon: 34.9}, box, false},
+		{"circle inside box", NewCircularServiceArea("c", -25.95, 32.4, 2), box, true},
+		{"circle near box", NewCircularServiceArea("c", -25.95, 32.55, 2), box, false},
+		{"circle crossing box edge", NewCircularServiceArea("c", -25.95, 32.51, 2), box, true},
+		{"circles overlapping", NewCircularServiceArea("c", -25.95, 32.4, 5), NewCircularServiceArea("d", -25.95, 32.48, 5), true},
+		{"circles apart", NewCircularServiceArea("c", -25.95, 32.4, 2), NewCircularServiceArea("d", -25.95, 32.48, 2), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Overlaps(tt.b); got != tt.want {
+				t.Errorf("a.Overlaps(b) = %v, want %v", got, tt.want)
+			}
+			if got := tt.b.Overlaps(tt.a); got != tt.want {
+				t.Errorf("b.Overlaps(a) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverlapFraction(t *testing.T) {
+	box := ServiceArea{MinLat: -26.0, MaxLat: -25.9, MinLon: 32.3, MaxLon: 32.5}
+
+	tests := []struct {
+		name      string
+		a, b      ServiceArea
+		want      float64
+		tolerance float64
+	}{
+		{"identical boxes", box, box, 1, 0},
+		{"box inside box", ServiceArea{MinLat: -25.98, MaxLat: -25.92, MinLon: 32.35, MaxLon: 32.45}, box, 1, 1e-9},
+		{"box containing box", box, ServiceArea{MinLat: -26.0, MaxLat: -25.95, MinLon: 32.3, MaxLon: 32.4}, 0.25, 1e-9},
+		{"quarter overlap", ServiceArea{MinLat: -25.95, MaxLat: -25.85, MinLon: 32.4, MaxLon: 32.6}, box, 0.25, 1e-9},
+		{"disjoint", ServiceArea{MinLat: -19.9, MaxLat: -19.7, MinLon: 34.8, MaxLon: 34.9}, box, 0, 0},
+		{"circle inside box", NewCircularServiceArea("c", -25.95, 32.4, 2), box, 1, 0},
+		{"circle half in box", NewCircularServiceArea("c", -25.95, 32.5, 2), box, 0.5, 0.05},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OverlapFraction(tt.a, tt.b)
+			if math.Abs(got-tt.want) > tt.tolerance {
+				t.Errorf("OverlapFraction() = %v, want %v ± %v", got, tt.want, tt.tolerance)
+			}
+		})
+	}
+}
+
+func TestRegisterServiceArea_Overlap(t *testing.T) {
+	// Entirely inside the built-in Matola area.
+	inner := ServiceArea{Name: "Matola Rio", MinLat: -25.98, MaxLat: -25.92, MinLon: 32.35, MaxLon: 32.45}
+	err := RegisterServiceArea("matola-rio", inner)
+	if err == nil {
+		UnregisterServiceArea("matola-rio")
+		t.Fatal("RegisterServiceArea() expected overlap error")
+	}
+	ve, ok := err.(valerrors.ValidationError)
+	if !ok {
+		t.Fatalf("expected ValidationError, got %T", err)
+	}
+	if ve.Code != valerrors.CodeDuplicate {
+		t.Errorf("error code = %v, want %v", ve.Code, valerrors.CodeDuplicate)
+	}
+	// maputo and matola both cover it fully; the first by name is reported.
+	if ve.Param("overlaps") != "maputo" {
+		t.Errorf("Params[overlaps] = %v, want maputo", ve.Param("overlaps"))
+	}
+
+	// A nested area that wins lookups over both is allowed.
+	inner.Priority = 30
+	if err := RegisterServiceArea("matola-rio", inner); err != nil {
+		t.Errorf("RegisterServiceArea(higher priority) error = %v", err)
+	}
+	UnregisterServiceArea("matola-rio")
+
+	// Mostly outside existing areas is allowed.
+	edge := ServiceArea{Name: "Marracuene", MinLat: -25.85, MaxLat: -25.6, MinLon: 32.5, MaxLon: 32.7}
+	if err := RegisterServiceArea("marracuene", edge); err != nil {
+		t.Fatalf("RegisterServiceArea() error = %v", err)
+	}
+	t.Cleanup(func() { UnregisterServiceArea("marracuene") })
+
+	// Updating an area is not checked against itself, only against others.
+	if err := UpdateServiceArea("marracuene", edge); err != nil {
+		t.Errorf("UpdateServiceArea(unchanged) error = %v", err)
+	}
+	moved := ServiceArea{Name: "Marracuene", MinLat: -25.95, MaxLat: -25.85, MinLon: 32.5, MaxLon: 32.7}
+	if err := UpdateServiceArea("marracuene", moved); err == nil {
+		t.Error("UpdateServiceArea() expected overlap error")
+	}
+}
+
+func TestUpdateServiceArea_NestedArea(t *testing.T) {
+	original := *GetServiceArea("matola")
+	t.Cleanup(func() { _ = UpdateServiceArea("matola", original) })
+
+	// Matola lies entirely inside Maputo; updates that keep its box succeed.
+	paused := original
+	paused.Active = false
+	if err := UpdateServiceArea("matola", paused); err != nil {
+		t.Fatalf("UpdateServiceArea(paused matola) error = %v", err)
+	}
+	if got := FindServiceArea(-25.95, 32.4); got != "maputo" {
+		t.Errorf("FindServiceArea() with matola paused = %q, want maputo", got)
+	}
+
+	reprioritized := original
+	reprioritized.Priority = 5
+	if err := UpdateServiceArea("matola", reprioritized); err != nil {
+		t.Fatalf("UpdateServiceArea(reprioritized matola) error = %v", err)
+	}
+
+	// A higher priority than Maputo settles the overlap even for a new box.
+	shrunk := original
+	shrunk.MaxLon = 32.45
+	if err := UpdateServiceArea("matola", shrunk); err != nil {
+		t.Errorf("UpdateServiceArea(shrunk matola) error = %v", err)
+	}
+
+	// With a lower priority, a moved box is still allowed because its
+	// overlap with Maputo was already total before the update.
+	moved := reprioritized
+	moved.MinLat, moved.MaxLat = -25.95, -25.85
+	if err := UpdateServiceArea("matola", moved); err != nil {
+		t.Errorf("UpdateServiceArea(moved matola) error = %v", err)
+	}
+
+	if err := UpdateServiceArea("matola", original, WithoutOverlaps()); err == nil {
+		t.Error("UpdateServiceArea(WithoutOverlaps) expected overlap error")
+	}
+}
+
+func TestServiceArea_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		sa        ServiceArea
+		wantField string
+		wantCode  string
+	}{
+		{"proposed Nampula box", nampula, "", ""},
+		{"Nampula circle", NewCircularServiceArea("Nampula", nampulaCenter.lat, nampulaCenter.lon, 15), "", ""},
+		{"built-in Maputo", *GetServiceArea("maputo"), "", ""},
+
+		{"zero-height box", ServiceArea{MinLat: -15.1, MaxLat: -15.1, MinLon: 39.2, MaxLon: 39.35}, "bounds", valerrors.CodeInvalidFormat},
+		{"zero-width box", ServiceArea{MinLat: -15.2, MaxLat: -15.0, MinLon: 39.3, MaxLon: 39.3}, "bounds", valerrors.CodeInvalidFormat},
+		{"inverted box", ServiceArea{MinLat: -15.0, MaxLat: -15.2, MinLon: 39.2, MaxLon: 39.35}, "bounds", valerrors.CodeInvalidFormat},
+		{"zero box", ServiceArea{}, "bounds", valerrors.CodeInvalidFormat},
+		{"invalid corner", ServiceArea{MinLat: -95, MaxLat: -15.0, MinLon: 39.2, MaxLon: 39.35}, "min.latitude", valerrors.CodeOutOfRange},
+		{"NaN corner", ServiceArea{MinLat: -15.2, MaxLat: -15.0, MinLon: 39.2, MaxLon: math.NaN()}, "max.longitude", valerrors.CodeInvalidFormat},
+		{"outside Mozambique", ServiceArea{MinLat: -26.3, MaxLat: -26.1, MinLon: 27.9, MaxLon: 28.2}, "area", valerrors.CodeOutsideServiceArea},
+		{"zero-radius circle", NewCircularServiceArea("c", nampulaCenter.lat, nampulaCenter.lon, 0), "radius_km", valerrors.CodeOutOfRange},
+		{"circle past the border", NewCircularServiceArea("c", -26.8, 32.5, 20), "area", valerrors.CodeOutsideServiceArea},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sa.Validate()
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok {
+				t.Fatalf("Validate() error = %v, want ValidationError", err)
+			}
+			if ve.Field != tt.wantField || ve.Code != tt.wantCode {
+				t.Errorf("Validate() error = %s/%s, want %s/%s", ve.Field, ve.Code, tt.wantField, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestRegisterServiceArea_Degenerate(t *testing.T) {
+	flat := ServiceArea{Name: "Flat", MinLat: -15.1, MaxLat: -15.1, MinLon: 39.2, MaxLon: 39.35, Timezone: DefaultTimezone}
+	if err := RegisterServiceArea("flat", flat); err == nil {
+		UnregisterServiceArea("flat")
+		t.Fatal("RegisterServiceArea() accepted a zero-height box")
+	}
+}
+
+func TestOverlappingAreas(t *testing.T) {
+	tests := []struct {
+		name string
+		sa   ServiceArea
+		want []string
+	}{
+		{"proposed Nampula area", nampula, nil},
+		{"overlapping Maputo only", ServiceArea{MinLat: -25.85, MaxLat: -25.6, MinLon: 32.5, MaxLon: 32.7}, []string{"maputo"}},
+		{"overlapping Maputo and Matola", ServiceArea{MinLat: -25.98, MaxLat: -25.92, MinLon: 32.35, MaxLon: 32.45}, []string{"maputo", "matola"}},
+		{"circle in Beira", NewCircularServiceArea("c", -19.8, 34.85, 2), []string{"beira"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := OverlappingAreas(tt.sa)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OverlappingAreas() = %v, want %v", got, tt.want)
+			}
+			for _, name := range got {
+				if !Overlaps(tt.sa, *GetServiceArea(name)) {
+					t.Errorf("Overlaps(sa, %s) = false, want true", name)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterServiceArea_WithoutOverlaps(t *testing.T) {
+	// About 20% covered by Maputo: allowed by default, rejected when strict.
+	edge := ServiceArea{Name: "Marracuene", MinLat: -25.85, MaxLat: -25.6, MinLon: 32.5, MaxLon: 32.7, Timezone: DefaultTimezone}
+
+	err := RegisterServiceArea("marracuene", edge, WithoutOverlaps())
+	if err == nil {
+		UnregisterServiceArea("marracuene")
+		t.Fatal("RegisterServiceArea(WithoutOverlaps) expected overlap error")
+	}
+	if ve, ok := err.(valerrors.ValidationError); !ok || ve.Code != valerrors.CodeDuplicate || ve.Param("overlaps") != "maputo" {
+		t.Errorf("RegisterServiceArea(WithoutOverlaps) error = %v, want DUPLICATE overlapping maputo", err)
+	}
+
+	if err := RegisterServiceArea("nampula", nampula, WithoutOverlaps()); err != nil {
+		t.Fatalf("RegisterServiceArea(WithoutOverlaps) error = %v", err)
+	}
+	t.Cleanup(func() { UnregisterServiceArea("nampula") })
+
+	if err := RegisterServiceArea("marracuene", edge); err != nil {
+		t.Fatalf("RegisterServiceArea() error = %v", err)
+	}
+	t.Cleanup(func() { UnregisterServiceArea("marracuene") })
+	if err := UpdateServiceArea("marracuene", edge, WithoutOverlaps()); err == nil {
+		t.Error("UpdateServiceArea(WithoutOverlaps) expected overlap error")
+	}
+}
+
+func TestServiceArea_AreaKM2(t *testing.T) {
+	// 1 km on each side at the equator.
+	side := 1 / kmPerDegreeLat
+	unit := ServiceArea{MinLat: 0, MaxLat: side, MinLon: 0, MaxLon: side}
+
+	// Maputo by hand: 0.3° of latitude is 33.36 km; 0.4° of longitude at
+	// 25.95°S is 0.4 * 111.195 * cos(25.95°) = 39.99 km; 33.36 * 39.99 = 1334 km².
+	tests := []struct {
+		name       string
+		sa         ServiceArea
+		wantArea   float64
+		wantWidth  float64
+		wantHeight float64
+	}{
+		{"unit square at the equator", unit, 1, 1, 1},
+		{"one degree at the equator", ServiceArea{MinLat: 0, MaxLat: 1, MinLon: 0, MaxLon: 1}, 12364, 111.19, 111.19},
+		{"Maputo", *GetServiceArea("maputo"), 1334, 39.99, 33.36},
+		{"circle", NewCircularServiceArea("c", -15.1, 39.3, 10), 314.16, 20, 20},
+		{"degenerate box", ServiceArea{MinLat: -15.1, MaxLat: -15.1, MinLon: 39.2, MaxLon: 39.35}, 0, 0, 0},
+	}
+
+	within := func(got, want float64) bool {
+		return math.Abs(got-want) <= 0.01*want
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sa.AreaKM2(); !within(got, tt.wantArea) {
+				t.Errorf("AreaKM2() = %v, want %v ± 1%%", got, tt.wantArea)
+			}
+			width, height := tt.sa.Dimensions()
+			if !within(width, tt.wantWidth) || !within(height, tt.wantHeight) {
+				t.Errorf("Dimensions() = (%v, %v), want (%v, %v) ± 1%%", width, height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestGetServiceAreaStats(t *testing.T) {
+	stats := GetServiceAreaStats()
+	if len(stats) != len(GetServiceAreas()) {
+		t.Fatalf("GetServiceAreaStats() has %d entries, want %d", len(stats), len(GetServiceAreas()))
+	}
+
+	maputo := GetServiceArea("maputo")
+	width, height := maputo.Dimensions()
+	want := AreaStats{AreaKM2: maputo.AreaKM2(), WidthKM: width, HeightKM: height}
+	if stats["maputo"] != want {
+		t.Errorf("GetServiceAreaStats()[maputo] = %+v, want %+v", stats["maputo"], want)
+	}
+	if stats["matola"].AreaKM2 >= stats["maputo"].AreaKM2 {
+		t.Errorf("matola (%v km²) should be smaller than maputo (%v km²)", stats["matola"].AreaKM2, stats["maputo"].AreaKM2)
+	}
+}
+
+func TestDistanceToPolylineKM(t *testing.T) {
+	// A long east-west segment along -25.9 from 32.3 to 32.9 (~60 km).
+	route := []Point{{Lat: -25.9, Lon: 32.3}, {Lat: -25.9, Lon: 32.9}}
+
+	t.Run("near middle of long segment", func(t *testing.T) {
+		// 1 km north of the segment's midpoint; ~30 km from either vertex.
+		p := Point{Lat: -25.9 + 1/kmPerDegreeLat, Lon: 32.6}
+		got, err := DistanceToPolylineKM(p, route)
+		if err != nil {
+			t.Fatalf("DistanceToPolylineKM() error = %v", err)
+		}
+		if math.Abs(got-1) > 0.01 {
+			t.Errorf("DistanceToPolylineKM() = %v, want ~1", got)
+		}
+	})
+
+	t.Run("beyond segment end", func(t *testing.T) {
+		p := Point{Lat: -25.9, Lon: 33.0}
+		got, err := DistanceToPolylineKM(p, route)
+		if err != nil {
+			t.Fatalf("DistanceToPolylineKM() error = %v", err)
+		}
+		want := haversineKM(-25.9, 32.9, -25.9, 33.0)
+		if math.Abs(got-want) > 0.01 {
+			t.Errorf("DistanceToPolylineKM() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("on the route", func(t *testing.T) {
+		got, err := DistanceToPolylineKM(Point{Lat: -25.9, Lon: 32.45}, route)
+		if err != nil {
+			t.Fatalf("DistanceToPolylineKM() error = %v", err)
+		}
+		if got > 0.001 {
+			t.Errorf("DistanceToPolylineKM() = %v, want 0", got)
+		}
+	})
+
+	t.Run("nearest of several segments", func(t *testing.T) {
+		multi := []Point{{Lat: -25.9, Lon: 32.3}, {Lat: -25.9, Lon: 32.5}, {Lat: -25.7, Lon: 32.5}}
+		// 2 km east of the northbound second segment.
+		p := Point{Lat: -25.8, Lon: 32.5 + 2/(kmPerDegreeLat*math.Cos(25.8*math.Pi/180))}
+		got, err := DistanceToPolylineKM(p, multi)
+		if err != nil {
+			t.Fatalf("DistanceToPolylineKM() error = %v", err)
+		}
+		if math.Abs(got-2) > 0.02 {
+			t.Errorf("DistanceToPolylineKM() = %v, want ~2", got)
+		}
+	})
+
+	t.Run("repeated points", func(t *testing.T) {
+		got, err := DistanceToPolylineKM(Point{Lat: -25.9, Lon: 32.4}, []Point{{Lat: -25.9, Lon: 32.3}, {Lat: -25.9, Lon: 32.3}})
+		if err != nil {
+			t.Fatalf("DistanceToPolylineKM() error = %v", err)
+		}
+		want := haversineKM(-25.9, 32.3, -25.9, 32.4)
+		if math.Abs(got-want) > 0.001 {
+			t.Errorf("DistanceToPolylineKM() = %v, want %v", got, want)
+		}
+	})
+
+	errTests := []struct {
+		name  string
+		point Point
+		route []Point
+		field string
+	}{
+		{"empty route", maputoPoint, nil, "route"},
+		{"single point route", maputoPoint, []Point{maputoPoint}, "route"},
+		{"invalid point", Point{Lat: -95, Lon: 32.5}, route, "latitude"},
+		{"invalid route point", maputoPoint, []Point{maputoPoint, {Lat: 0, Lon: 190}}, "route[1].longitude"},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DistanceToPolylineKM(tt.point, tt.route)
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if ve.Field != tt.field {
+				t.Errorf("error field = %v, want %v", ve.Field, tt.field)
+			}
+		})
+	}
+}
+
+func TestValidateWithinCorridor(t *testing.T) {
+	route := []Point{{Lat: -25.9, Lon: 32.3}, {Lat: -25.9, Lon: 32.9}}
+
+	if err := ValidateWithinCorridor(Point{Lat: -25.905, Lon: 32.6}, route, 1); err != nil {
+		t.Errorf("ValidateWithinCorridor() error = %v, want nil", err)
+	}
+
+	err := ValidateWithinCorridor(Point{Lat: -25.95, Lon: 32.6}, route, 1)
+	ve, ok := err.(valerrors.ValidationError)
+	if !ok {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if ve.Field != "deviation" || ve.Code != valerrors.CodeOutOfRange {
+		t.Errorf("error = %s/%s, want deviation/%s", ve.Field, ve.Code, valerrors.CodeOutOfRange)
+	}
+	if d, ok := ve.Value.(float64); !ok || math.Abs(d-5.56) > 0.05 {
+		t.Errorf("Value = %v, want ~5.56", ve.Value)
+	}
+
+	if err := ValidateWithinCorridor(maputoPoint, nil, 1); err == nil {
+		t.Error("ValidateWithinCorridor() expected error for empty route")
+	}
+
+	if err := ValidateWithinCorridor(Point{Lat: -25.9, Lon: 32.6}, route, 0); err != nil {
+		t.Errorf("ValidateWithinCorridor() on the route with zero width error = %v, want nil", err)
+	}
+	for _, maxKM := range []float64{-1, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		err := ValidateWithinCorridor(Point{Lat: -25.905, Lon: 32.6}, route, maxKM)
+		ve, ok := err.(valerrors.ValidationError)
+		if !ok || ve.Field != "max_km" || ve.Code != valerrors.CodeInvalidFormat {
+			t.Errorf("ValidateWithinCorridor(maxKM=%v) error = %v, want max_km/%s", maxKM, err, valerrors.CodeInvalidFormat)
+		}
+	}
+}
+
+func TestValidateWithinRadius(t *testing.T) {
+	centerLat, centerLon := -25.969, 32.573
+	// Exactly 1 km due north of the center.
+	edgeLat := centerLat + 1/kmPerDegreeLat
+	exact := haversineKM(centerLat, centerLon, edgeLat, centerLon)
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		maxKM    float64
+		wantErr  bool
+		field    string
+		errCode  string
+	}{
+		{"same point", centerLat, centerLon, 1, false, "", ""},
+		{"well inside", centerLat + 0.001, centerLon, 1, false, "", ""},
+		{"exactly at radius", edgeLat, centerLon, exact, false, "", ""},
+		{"just beyond radius", edgeLat + 0.0001, centerLon, exact, true, "distance", valerrors.CodeOutOfRange},
+		{"far away", -19.84, 34.84, 5, true, "distance", valerrors.CodeOutOfRange},
+		{"zero radius", centerLat, centerLon, 0, true, "radius", valerrors.CodeInvalidFormat},
+		{"negative radius", centerLat, centerLon, -1, true, "radius", valerrors.CodeInvalidFormat},
+		{"NaN radius", centerLat, centerLon, math.NaN(), true, "radius", valerrors.CodeInvalidFormat},
+		{"invalid point", -95, centerLon, 1, true, "latitude", valerrors.CodeOutOfRange},
+		{"NaN point", centerLat, math.NaN(), 1, true, "longitude", valerrors.CodeInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWithinRadius(centerLat, centerLon, tt.lat, tt.lon, tt.maxKM)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateWithinRadius() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := IsWithinRadius(centerLat, centerLon, tt.lat, tt.lon, tt.maxKM); got == tt.wantErr {
+				t.Errorf("IsWithinRadius() = %v, want %v", got, !tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok {
+				t.Fatalf("expected ValidationError, got %T", err)
+			}
+			if ve.Field != tt.field || ve.Code != tt.errCode {
+				t.Errorf("error = %s/%s, want %s/%s", ve.Field, ve.Code, tt.field, tt.errCode)
+			}
+			if tt.field == "distance" {
+				if d, ok := ve.Value.(float64); !ok || d <= tt.maxKM {
+					t.Errorf("Value = %v, want actual distance above %v", ve.Value, tt.maxKM)
+				}
+			}
+		})
+	}
+
+	if err := ValidateWithinRadius(-95, centerLon, centerLat, centerLon, 1); err == nil {
+		t.Error("ValidateWithinRadius() expected error for invalid center")
+	}
+}
+
+func TestValidateLocationWithinRadius(t *testing.T) {
+	center := geo.MustNewLocation(-25.969, 32.573)
+
+	if err := ValidateLocationWithinRadius(center, geo.MustNewLocation(-25.965, 32.575), 1); err != nil {
+		t.Errorf("ValidateLocationWithinRadius() error = %v", err)
+	}
+	if err := ValidateLocationWithinRadius(center, geo.MustNewLocation(-19.84, 34.84), 1); err == nil {
+		t.Error("ValidateLocationWithinRadius() expected error")
+	}
+}
+
+func TestValidateManyInMozambique(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		if errs := ValidateManyInMozambique(nil); errs != nil {
+			t.Errorf("ValidateManyInMozambique(nil) = %v, want nil", errs)
+		}
+	})
+
+	t.Run("all valid", func(t *testing.T) {
+		if errs := ValidateManyInMozambique([]Point{maputoPoint, beiraPoint}); errs != nil {
+			t.Errorf("ValidateManyInMozambique() = %v, want nil", errs)
+		}
+	})
+
+	t.Run("mixed", func(t *testing.T) {
+		points := []Point{
+			maputoPoint,
+			{Lat: -26.2, Lon: 28.0}, // Johannesburg
+			beiraPoint,
+			{Lat: 95, Lon: 32.6},
+			{Lat: -25.9, Lon: math.NaN()},
+		}
+		errs := ValidateManyInMozambique(points)
+		want := []struct{ field, code string }{
+			{"points[1]", valerrors.CodeOutsideServiceArea},
+			{"points[3].latitude", valerrors.CodeOutOfRange},
+			{"points[4].longitude", valerrors.CodeInvalidFormat},
+		}
+		if len(errs) != len(want) {
+			t.Fatalf("ValidateManyInMozambique() = %v, want %d errors", errs, len(want))
+		}
+		for i, w := range want {
+			if errs[i].Field != w.field || errs[i].Code != w.code {
+				t.Errorf("error[%d] = %s/%s, want %s/%s", i, errs[i].Field, errs[i].Code, w.field, w.code)
+			}
+		}
+	})
+}
+
+func TestValidateManyInServiceAreas(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		if errs := ValidateManyInServiceAreas([]Point{}); errs != nil {
+			t.Errorf("ValidateManyInServiceAreas() = %v, want nil", errs)
+		}
+	})
+
+	t.Run("mixed", func(t *testing.T) {
+		points := []Point{
+			maputoPoint,
+			{Lat: -15.12, Lon: 39.27}, // Nampula, in Mozambique but not served
+			matolaPoint,
+			{Lat: -91, Lon: 32.6},
+		}
+		errs := ValidateManyInServiceAreas(points)
+		if len(errs) != 2 {
+			t.Fatalf("ValidateManyInServiceAreas() = %v, want 2 errors", errs)
+		}
+		if errs[0].Field != "points[1]" || errs[0].Code != valerrors.CodeOutsideServiceArea {
+			t.Errorf("error[0] = %s/%s", errs[0].Field, errs[0].Code)
+		}
+		if errs[1].Field != "points[3].latitude" || errs[1].Code != valerrors.CodeOutOfRange {
+			t.Errorf("error[1] = %s/%s", errs[1].Field, errs[1].Code)
+		}
+	})
+
+	t.Run("skips paused areas", func(t *testing.T) {
+		pauseServiceArea(t, "beira")
+		errs := ValidateManyInServiceAreas([]Point{beiraPoint})
+		if len(errs) != 1 || errs[0].Field != "points[0]" {
+			t.Errorf("ValidateManyInServiceAreas() = %v, want points[0] error", errs)
+		}
+	})
+}
+
+func TestCountValid(t *testing.T) {
+	tests := []struct {
+		name        string
+		points      []Point
+		wantValid   int
+		wantInvalid int
+	}{
+		{"empty", nil, 0, 0},
+		{"all valid", []Point{maputoPoint, matolaPoint, beiraPoint}, 3, 0},
+		{"mixed", []Point{maputoPoint, {Lat: -26.2, Lon: 28.0}, {Lat: math.NaN(), Lon: 32.6}, {Lat: 95, Lon: 32.6}}, 1, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, invalid := CountValid(tt.points)
+			if valid != tt.wantValid || invalid != tt.wantInvalid {
+				t.Errorf("CountValid() = (%d, %d), want (%d, %d)", valid, invalid, tt.wantValid, tt.wantInvalid)
+			}
+		})
+	}
+}
+
+// benchmarkPoints returns n points in Maputo with every tenth in Johannesburg.
+func benchmarkPoints(n int) []Point {
+	points := make([]Point, n)
+	for i := range points {
+		if i%10 == 0 {
+			points[i] = Point{Lat: -26.2, Lon: 28.0}
+		} else {
+			points[i] = maputoPoint
+		}
+	}
+	return points
+}
+
+func BenchmarkValidateManyInMozambique(b *testing.B) {
+	points := benchmarkPoints(100000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = ValidateManyInMozambique(points)
+	}
+}
+
+func BenchmarkValidateManyInServiceAreas(b *testing.B) {
+	points := benchmarkPoints(100000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = ValidateManyInServiceAreas(points)
+	}
+}
+
+func BenchmarkCountValid(b *testing.B) {
+	points := benchmarkPoints(100000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = CountValid(points)
+	}
+}
+
+func TestValidateServiceAreaRadius(t *testing.T) {
+	// Maputo's box center is (-25.95, 32.5).
+	tests := []struct {
+		name     string
+		area     string
+		lat, lon float64
+		radiusKM float64
+		wantCode string
+	}{
+		{"at center", "maputo", -25.95, 32.5, 1, ""},
+		{"within radius", "maputo", -25.92, 32.55, 10, ""},
+		{"outside radius", "maputo", -25.85, 32.6, 10, valerrors.CodeOutsideServiceArea},
+		{"Beira from Maputo", "maputo", -19.8, 34.85, 50, valerrors.CodeOutsideServiceArea},
+		{"Beira center", "beira", -19.8, 34.85, 1, ""},
+		{"unknown area", "nampula", -15.12, 39.27, 10, valerrors.CodeInvalidOption},
+		{"empty area", "", -25.95, 32.5, 10, valerrors.CodeInvalidOption},
+		{"invalid latitude", "maputo", -95, 32.5, 10, valerrors.CodeOutOfRange},
+		{"zero radius", "maputo", -25.95, 32.5, 0, valerrors.CodeInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateServiceAreaRadius(tt.area, tt.lat, tt.lon, tt.radiusKM)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("ValidateServiceAreaRadius() error = %v", err)
+				}
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Code != tt.wantCode {
+				t.Errorf("ValidateServiceAreaRadius() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestValidateServiceAreaRadius_DistanceParam(t *testing.T) {
+	err := ValidateServiceAreaRadius("maputo", -25.85, 32.6, 10)
+	ve, ok := err.(valerrors.ValidationError)
+	if !ok {
+		t.Fatalf("ValidateServiceAreaRadius() error = %v, want ValidationError", err)
+	}
+	d, ok := ve.Param("distance_km").(float64)
+	if !ok || d < 14 || d > 15 {
+		t.Errorf("Params[distance_km] = %v, want ~14.8", ve.Param("distance_km"))
+	}
+}
+
+func TestCityCenter(t *testing.T) {
+	t.Run("built-in", func(t *testing.T) {
+		center, ok := CityCenter("maputo")
+		if !ok || center.Lat != -25.9692 || center.Lon != 32.5732 {
+			t.Errorf("CityCenter(maputo) = %v, %v", center, ok)
+		}
+		if !IsInServiceArea(center.Lat, center.Lon) {
+			t.Error("Maputo center should be inside a service area")
+		}
+	})
+
+	t.Run("registered circle uses its center", func(t *testing.T) {
+		sa := NewCircularServiceArea("Nampula", nampulaCenter.lat, nampulaCenter.lon, 15)
+		if err := RegisterServiceArea("nampula", sa); err != nil {
+			t.Fatalf("RegisterServiceArea() error = %v", err)
+		}
+		t.Cleanup(func() { UnregisterServiceArea("nampula") })
+
+		center, ok := CityCenter("nampula")
+		if !ok || center.Lat != nampulaCenter.lat || center.Lon != nampulaCenter.lon {
+			t.Errorf("CityCenter(nampula) = %v, %v", center, ok)
+		}
+	})
+
+	t.Run("registered box uses its midpoint", func(t *testing.T) {
+		if err := RegisterServiceArea("nampula", nampula); err != nil {
+			t.Fatalf("RegisterServiceArea() error = %v", err)
+		}
+		t.Cleanup(func() { UnregisterServiceArea("nampula") })
+
+		center, _ := CityCenter("nampula")
+		if math.Abs(center.Lat+15.1) > 1e-9 || math.Abs(center.Lon-39.275) > 1e-9 {
+			t.Errorf("CityCenter(nampula) = %v, want (-15.1, 39.275)", center)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		if _, ok := CityCenter("pemba"); ok {
+			t.Error("CityCenter(pemba) ok = true, want false")
+		}
+	})
+}
+
+func TestDistanceFromCenterKM(t *testing.T) {
+	t.Run("Matola from Maputo center", func(t *testing.T) {
+		d, err := DistanceFromCenterKM("maputo", -25.9622, 32.4589)
+		if err != nil {
+			t.Fatalf("DistanceFromCenterKM() error = %v", err)
+		}
+		if math.Abs(d-11.5) > 0.5 {
+			t.Errorf("DistanceFromCenterKM() = %.2f, want ~11.5", d)
+		}
+	})
+
+	t.Run("at center", func(t *testing.T) {
+		d, err := DistanceFromCenterKM("beira", -19.8436, 34.8389)
+		if err != nil || d != 0 {
+			t.Errorf("DistanceFromCenterKM() = %v, %v, want 0", d, err)
+		}
+	})
+
+	t.Run("invalid area", func(t *testing.T) {
+		_, err := DistanceFromCenterKM("pemba", -25.9622, 32.4589)
+		ve, ok := err.(valerrors.ValidationError)
+		if !ok || ve.Code != valerrors.CodeInvalidOption {
+			t.Fatalf("DistanceFromCenterKM() error = %v, want %v", err, valerrors.CodeInvalidOption)
+		}
+		if !strings.Contains(ve.Message, "maputo") {
+			t.Errorf("Message = %q, want valid names listed", ve.Message)
+		}
+	})
+
+	t.Run("invalid coordinates", func(t *testing.T) {
+		_, err := DistanceFromCenterKM("maputo", 91, 32.5)
+		if ve, ok := err.(valerrors.ValidationError); !ok || ve.Code != valerrors.CodeOutOfRange {
+			t.Errorf("DistanceFromCenterKM() error = %v, want %v", err, valerrors.CodeOutOfRange)
+		}
+	})
+}
+
+func TestMidpoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		lat1, lon1 float64
+		lat2, lon2 float64
+		wantLat    float64
+		wantLon    float64
+		tolerance  float64
+	}{
+		// Expected value computed independently by averaging unit vectors.
+		{"Maputo to Beira", -25.9692, 32.5732, -19.8436, 34.8389, -22.910414, 33.731666, 1e-4},
+		{"same point", -25.9692, 32.5732, -25.9692, 32.5732, -25.9692, 32.5732, 1e-9},
+		{"along equator", 0, 30, 0, 40, 0, 35, 1e-9},
+		{"across antimeridian", 0, 179, 0, -179, 0, 180, 1e-9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lat, lon, err := Midpoint(tt.lat1, tt.lon1, tt.lat2, tt.lon2)
+			if err != nil {
+				t.Fatalf("Midpoint() error = %v", err)
+			}
+			if math.Abs(lat-tt.wantLat) > tt.tolerance || math.Abs(math.Abs(lon)-math.Abs(tt.wantLon)) > tt.tolerance {
+				t.Errorf("Midpoint() = (%v, %v), want (%v, %v)", lat, lon, tt.wantLat, tt.wantLon)
+			}
+		})
+	}
+
+	t.Run("differs from naive average", func(t *testing.T) {
+		_, lon, _ := Midpoint(-25.9692, 32.5732, -19.8436, 34.8389)
+		naive := (32.5732 + 34.8389) / 2
+		if math.Abs(lon-naive) < 0.02 {
+			t.Errorf("Midpoint() lon = %v, too close to naive average %v", lon, naive)
+		}
+	})
+
+	t.Run("invalid coordinates", func(t *testing.T) {
+		if _, _, err := Midpoint(-25.9, 32.5, 95, 32.5); err == nil {
+			t.Error("Midpoint() expected error")
+		}
+	})
+}
+
+func TestBoundingBox(t *testing.T) {
+	t.Run("three points with 1 km padding", func(t *testing.T) {
+		points := []Point{maputoPoint, matolaPoint, {Lat: -25.97, Lon: 32.57}}
+		minLat, minLon, maxLat, maxLon, err := BoundingBox(points, 1)
+		if err != nil {
+			t.Fatalf("BoundingBox() error = %v", err)
+		}
+
+		// The padded edges should be 1 km from the tight bounds.
+		if d := haversineKM(-25.97, 32.5, minLat, 32.5); math.Abs(d-1) > 0.01 {
+			t.Errorf("south padding = %.3f km, want 1", d)
+		}
+		if d := haversineKM(-25.85, 32.5, maxLat, 32.5); math.Abs(d-1) > 0.01 {
+			t.Errorf("north padding = %.3f km, want 1", d)
+		}
+		if d := haversineKM(-25.97, 32.4, -25.97, minLon); d < 0.99 || d > 1.02 {
+			t.Errorf("west padding = %.3f km, want ~1", d)
+		}
+		if d := haversineKM(-25.97, 32.6, -25.97, maxLon); d < 0.99 || d > 1.02 {
+			t.Errorf("east padding = %.3f km, want ~1", d)
+		}
+		for _, p := range points {
+			if !BoundingBoxContains(minLat, maxLat, minLon, maxLon, p.Lat, p.Lon) {
+				t.Errorf("box does not contain %v", p)
+			}
+		}
+	})
+
+	t.Run("no padding", func(t *testing.T) {
+		minLat, minLon, maxLat, maxLon, err := BoundingBox([]Point{maputoPoint, beiraPoint}, 0)
+		if err != nil {
+			t.Fatalf("BoundingBox() error = %v", err)
+		}
+		if minLat != -25.85 || maxLat != -19.8 || minLon != 32.6 || maxLon != 34.85 {
+			t.Errorf("BoundingBox() = (%v, %v, %v, %v)", minLat, minLon, maxLat, maxLon)
+		}
+	})
+
+	t.Run("clamped at the pole", func(t *testing.T) {
+		minLat, _, _, _, err := BoundingBox([]Point{{Lat: -89.999, Lon: 0}}, 10)
+		if err != nil {
+			t.Fatalf("BoundingBox() error = %v", err)
+		}
+		if minLat != -90 {
+			t.Errorf("minLat = %v, want -90", minLat)
+		}
+	})
+
+	tests := []struct {
+		name      string
+		points    []Point
+		padding   float64
+		wantField string
+		wantCode  string
+	}{
+		{"empty", nil, 1, "points", valerrors.CodeRequired},
+		{"negative padding", []Point{maputoPoint}, -1, "padding", valerrors.CodeInvalidFormat},
+		{"NaN padding", []Point{maputoPoint}, math.NaN(), "padding", valerrors.CodeInvalidFormat},
+		{"invalid point", []Point{maputoPoint, {Lat: -95, Lon: 32.5}}, 1, "points[1].latitude", valerrors.CodeOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, _, err := BoundingBox(tt.points, tt.padding)
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok {
+				t.Fatalf("BoundingBox() error = %v, want ValidationError", err)
+			}
+			if ve.Field != tt.wantField || ve.Code != tt.wantCode {
+				t.Errorf("BoundingBox() error = %s/%s, want %s/%s", ve.Field, ve.Code, tt.wantField, tt.wantCode)
+			}
+		})
+	}
+}
+
+// candidateDrivers returns n points scattered around Maputo.
+func candidateDrivers(n int) []Point {
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = Point{Lat: -25.99 + float64(i%20)*0.01, Lon: 32.45 + float64(i/20)*0.015}
+	}
+	return points
+}
+
+func TestDistanceMatrixKM(t *testing.T) {
+	origins := []Point{maputoPoint, beiraPoint}
+	destinations := []Point{matolaPoint, maputoPoint, beiraPoint}
+
+	matrix, err := DistanceMatrixKM(origins, destinations)
+	if err != nil {
+		t.Fatalf("DistanceMatrixKM() error = %v", err)
+	}
+	if len(matrix) != 2 || len(matrix[0]) != 3 || len(matrix[1]) != 3 {
+		t.Fatalf("DistanceMatrixKM() shape = %dx%d, want 2x3", len(matrix), len(matrix[0]))
+	}
+	for i, o := range origins {
+		for j, d := range destinations {
+			want, _ := CalculateDistance(o.Lat, o.Lon, d.Lat, d.Lon)
+			if math.Abs(matrix[i][j]-want) > 1e-6 {
+				t.Errorf("matrix[%d][%d] = %v, want %v", i, j, matrix[i][j], want)
+			}
+		}
+	}
+	if matrix[0][1] != 0 || matrix[1][2] != 0 {
+		t.Error("distance from a point to itself should be 0")
+	}
+
+	t.Run("rows do not share capacity", func(t *testing.T) {
+		matrix[0] = append(matrix[0], 1)
+		if matrix[1][0] == 1 {
+			t.Error("appending to one row modified the next")
+		}
+	})
+
+	t.Run("empty inputs", func(t *testing.T) {
+		m, err := DistanceMatrixKM(nil, destinations)
+		if err != nil || len(m) != 0 {
+			t.Errorf("DistanceMatrixKM(nil, ...) = %v, %v", m, err)
+		}
+		m, err = DistanceMatrixKM(origins, nil)
+		if err != nil || len(m) != 2 || len(m[0]) != 0 {
+			t.Errorf("DistanceMatrixKM(..., nil) = %v, %v", m, err)
+		}
+	})
+
+	t.Run("invalid destination", func(t *testing.T) {
+		_, err := DistanceMatrixKM(origins, []Point{matolaPoint, {Lat: -25.9, Lon: 181}})
+		ve, ok := err.(valerrors.ValidationError)
+		if !ok || ve.Field != "destinations[1].longitude" {
+			t.Errorf("DistanceMatrixKM() error = %v, want destinations[1].longitude", err)
+		}
+	})
+
+	t.Run("invalid origin", func(t *testing.T) {
+		_, err := DistanceMatrixKM([]Point{{Lat: math.NaN(), Lon: 32}}, destinations)
+		ve, ok := err.(valerrors.ValidationError)
+		if !ok || ve.Field != "origins[0].latitude" {
+			t.Errorf("DistanceMatrixKM() error = %v, want origins[0].latitude", err)
+		}
+	})
+}
+
+func TestDistancesFromKM(t *testing.T) {
+	drivers := candidateDrivers(50)
+	distances, err := DistancesFromKM(maputoPoint, drivers)
+	if err != nil {
+		t.Fatalf("DistancesFromKM() error = %v", err)
+	}
+	if len(distances) != len(drivers) {
+		t.Fatalf("DistancesFromKM() returned %d distances, want %d", len(distances), len(drivers))
+	}
+	for _, j := range []int{0, 17, 49} {
+		want, _ := CalculateDistance(maputoPoint.Lat, maputoPoint.Lon, drivers[j].Lat, drivers[j].Lon)
+		if math.Abs(distances[j]-want) > 1e-6 {
+			t.Errorf("distances[%d] = %v, want %v", j, distances[j], want)
+		}
+	}
+
+	_, err = DistancesFromKM(Point{Lat: 91, Lon: 32}, drivers)
+	if ve, ok := err.(valerrors.ValidationError); !ok || ve.Field != "origin.latitude" {
+		t.Errorf("DistancesFromKM() error = %v, want origin.latitude", err)
+	}
+}
+
+func BenchmarkDistancesFromKM(b *testing.B) {
+	drivers := candidateDrivers(200)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = DistancesFromKM(maputoPoint, drivers)
+	}
+}
+
+// BenchmarkDistancesFromKM_Naive is the CalculateDistance loop DistancesFromKM replaces.
+func BenchmarkDistancesFromKM_Naive(b *testing.B) {
+	drivers := candidateDrivers(200)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		distances := make([]float64, len(drivers))
+		for j, d := range drivers {
+			distances[j], _ = CalculateDistance(maputoPoint.Lat, maputoPoint.Lon, d.Lat, d.Lon)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package geo
+
+import (
+	"strings"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// Geohash precision limits, in characters.
+const (
+	MinGeohashPrecision = 1
+	MaxGeohashPrecision = 12
+)
+
+// geohashAlphabet is the standard geohash base32 alphabet (no a, i, l, o).
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// EncodeGeohash returns the standard base32 geohash of the coordinates with
+// the given number of characters (1-12).
+func EncodeGeohash(lat, lon float64, precision int) (string, error) {
+	if err := ValidateCoordinates(lat, lon); err != nil {
+		return "", err
+	}
+	if precision < MinGeohashPrecision || precision > MaxGeohashPrecision {
+		return "", valerrors.OutOfRangeWithValue("precision", MinGeohashPrecision, MaxGeohashPrecision, precision)
+	}
+
+	minLat, maxLat := -90.0, 90.0
+	minLon, maxLon := -180.0, 180.0
+	hash := make([]byte, precision)
+	evenBit := true
+	for i := range hash {
+		idx := 0
+		for bit := 0; bit < 5; bit++ {
+			idx <<= 1
+			if evenBit {
+				mid := (minLon + maxLon) / 2
+				if lon >= mid {
+					idx |= 1
+					minLon = mid
+				} else {
+					maxLon = mid
+				}
+			} else {
+				mid := (minLat + maxLat) / 2
+				if lat >= mid {
+					idx |= 1
+					minLat = mid
+				} else {
+					maxLat = mid
+				}
+			}
+			evenBit = !evenBit
+		}
+		hash[i] = geohashAlphabet[idx]
+	}
+	return string(hash), nil
+}
+
+// DecodeGeohash returns the center of the geohash cell.
+// Decoding is case-insensitive. Returns INVALID_FORMAT for characters outside
+// the geohash alphabet or hashes longer than MaxGeohashPrecision.
+func DecodeGeohash(hash string) (lat, lon float64, err error) {
+	if hash == "" {
+		return 0, 0, valerrors.Required("geohash")
+	}
+	if len(hash) > MaxGeohashPrecision {
+		return 0, 0, valerrors.InvalidFormatWithValue("geohash", "1-12 character geohash", hash)
+	}
+
+	minLat, maxLat := -90.0, 90.0
+	minLon, maxLon := -180.0, 180.0
+	evenBit := true
+	for _, c := range strings.ToLower(hash) {
+		idx := strings.IndexRune(geohashAlphabet, c)
+		if idx < 0 {
+			return 0, 0, valerrors.InvalidFormatWithValue("geohash", "base32 geohash characters", hash)
+		}
+		for bit := 4; bit >= 0; bit-- {
+			set := idx>>uint(bit)&1 == 1
+			if evenBit {
+				mid := (minLon + maxLon) / 2
+				if set {
+					minLon = mid
+				} else {
+					maxLon = mid
+				}
+			} else {
+				mid := (minLat + maxLat) / 2
+				if set {
+					minLat = mid
+				} else {
+					maxLat = mid
+				}
+			}
+			evenBit = !evenBit
+		}
+	}
+	return (minLat + maxLat) / 2, (minLon + maxLon) / 2, nil
+}
+
+// GeohashesMatch reports whether two geohashes share their first prefixLen
+// characters, a coarse check that both points fall in the same cell.
+// Returns false if prefixLen is not positive or either hash is shorter.
+func GeohashesMatch(a, b string, prefixLen int) bool {
+	if prefixLen < 1 || len(a) < prefixLen || len(b) < prefixLen {
+		return false
+	}
+	return strings.EqualFold(a[:prefixLen], b[:prefixLen])
+}
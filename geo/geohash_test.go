@@ -0,0 +1,185 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestEncodeGeohash(t *testing.T) {
+	tests := []struct {
+		name      string
+		lat, lon  float64
+		precision int
+		want      string
+	}{
+		{"Maputo center", -25.9692, 32.5732, 9, "kerhm0rn6"},
+		{"Maputo center coarse", -25.9692, 32.5732, 4, "kerh"},
+		{"Beira center", -19.8436, 34.8389, 7, "ku2z0g8"},
+		{"reference vector", 57.64911, 10.40744, 11, "u4pruydqqvj"},
+		{"reference vector short", 42.6, -5.6, 5, "ezs42"},
+		{"single character", -25.9692, 32.5732, 1, "k"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeGeohash(tt.lat, tt.lon, tt.precision)
+			if err != nil {
+				t.Fatalf("EncodeGeohash() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("EncodeGeohash(%v, %v, %d) = %q, want %q", tt.lat, tt.lon, tt.precision, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeGeohash_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		lat, lon  float64
+		precision int
+		wantField string
+		wantCode  string
+	}{
+		{"precision zero", -25.9, 32.5, 0, "precision", valerrors.CodeOutOfRange},
+		{"precision too high", -25.9, 32.5, 13, "precision", valerrors.CodeOutOfRange},
+		{"invalid latitude", -91, 32.5, 6, "latitude", valerrors.CodeOutOfRange},
+		{"NaN longitude", -25.9, math.NaN(), 6, "longitude", valerrors.CodeInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := EncodeGeohash(tt.lat, tt.lon, tt.precision)
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok {
+				t.Fatalf("EncodeGeohash() error = %v, want ValidationError", err)
+			}
+			if ve.Field != tt.wantField || ve.Code != tt.wantCode {
+				t.Errorf("EncodeGeohash() error = %s/%s, want %s/%s", ve.Field, ve.Code, tt.wantField, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestDecodeGeohash(t *testing.T) {
+	tests := []struct {
+		name     string
+		hash     string
+		lat, lon float64
+		tol      float64
+	}{
+		{"reference vector", "ezs42", 42.605, -5.603, 0.001},
+		{"Maputo center", "kerhm0rn6", -25.9692, 32.5732, 0.0001},
+		{"uppercase", "KERHM0RN6", -25.9692, 32.5732, 0.0001},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lat, lon, err := DecodeGeohash(tt.hash)
+			if err != nil {
+				t.Fatalf("DecodeGeohash(%q) error = %v", tt.hash, err)
+			}
+			if math.Abs(lat-tt.lat) > tt.tol || math.Abs(lon-tt.lon) > tt.tol {
+				t.Errorf("DecodeGeohash(%q) = (%v, %v), want ~(%v, %v)", tt.hash, lat, lon, tt.lat, tt.lon)
+			}
+		})
+	}
+}
+
+func TestDecodeGeohash_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		hash     string
+		wantCode string
+	}{
+		{"empty", "", valerrors.CodeRequired},
+		{"letter a", "kerha", valerrors.CodeInvalidFormat},
+		{"letter i", "kei", valerrors.CodeInvalidFormat},
+		{"letter l", "kel", valerrors.CodeInvalidFormat},
+		{"letter o", "keo", valerrors.CodeInvalidFormat},
+		{"symbol", "ke-h", valerrors.CodeInvalidFormat},
+		{"non-ASCII", "keçh", valerrors.CodeInvalidFormat},
+		{"too long", "kerhm0rn6kerh", valerrors.CodeInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := DecodeGeohash(tt.hash)
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Code != tt.wantCode {
+				t.Errorf("DecodeGeohash(%q) error = %v, want %v", tt.hash, err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestGeohash_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for _, precision := range []int{1, 3, 5, 7, 9, 12} {
+		// Half-widths of a cell at this precision.
+		lonBits := (5*precision + 1) / 2
+		latBits := 5*precision - lonBits
+		latErr := 90 / math.Pow(2, float64(latBits))
+		lonErr := 180 / math.Pow(2, float64(lonBits))
+
+		for i := 0; i < 200; i++ {
+			lat := MozambiqueMinLat + rng.Float64()*(MozambiqueMaxLat-MozambiqueMinLat)
+			lon := MozambiqueMinLon + rng.Float64()*(MozambiqueMaxLon-MozambiqueMinLon)
+
+			hash, err := EncodeGeohash(lat, lon, precision)
+			if err != nil {
+				t.Fatalf("EncodeGeohash(%v, %v, %d) error = %v", lat, lon, precision, err)
+			}
+			if len(hash) != precision {
+				t.Fatalf("EncodeGeohash() = %q, want %d characters", hash, precision)
+			}
+
+			gotLat, gotLon, err := DecodeGeohash(hash)
+			if err != nil {
+				t.Fatalf("DecodeGeohash(%q) error = %v", hash, err)
+			}
+			if math.Abs(gotLat-lat) > latErr || math.Abs(gotLon-lon) > lonErr {
+				t.Fatalf("DecodeGeohash(%q) = (%v, %v), want within cell of (%v, %v)", hash, gotLat, gotLon, lat, lon)
+			}
+
+			again, err := EncodeGeohash(gotLat, gotLon, precision)
+			if err != nil || again != hash {
+				t.Fatalf("EncodeGeohash(center of %q) = %q, %v", hash, again, err)
+			}
+		}
+	}
+}
+
+func TestGeohashesMatch(t *testing.T) {
+	maputo, _ := EncodeGeohash(-25.9692, 32.5732, 9)
+	nearby, _ := EncodeGeohash(-25.9700, 32.5740, 9)
+	beira, _ := EncodeGeohash(-19.8436, 34.8389, 9)
+
+	tests := []struct {
+		name      string
+		a, b      string
+		prefixLen int
+		want      bool
+	}{
+		{"nearby points coarse", maputo, nearby, 5, true},
+		{"different cities", maputo, beira, 2, false},
+		{"same country cell", maputo, beira, 1, true},
+		{"identical", maputo, maputo, 9, true},
+		{"case-insensitive", "KERHM", "kerhm", 5, true},
+		{"prefix longer than hash", "kerh", "kerhm", 5, false},
+		{"zero prefix", maputo, beira, 0, false},
+		{"empty hashes", "", "", 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GeohashesMatch(tt.a, tt.b, tt.prefixLen); got != tt.want {
+				t.Errorf("GeohashesMatch(%q, %q, %d) = %v, want %v", tt.a, tt.b, tt.prefixLen, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// GeoJSONCircleSegments is the number of segments used to approximate a
+// circular service area as a polygon ring.
+const GeoJSONCircleSegments = 64
+
+// geoJSONCoordinateScale rounds exported coordinates to 6 decimal places
+// (about 0.1 m) so output is stable across platforms.
+const geoJSONCoordinateScale = 1e6
+
+// Shape names used in GeoJSON feature properties.
+const (
+	geoJSONShapeBox    = "box"
+	geoJSONShapeCircle = "circle"
+)
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string            `json:"type"`
+	ID         string            `json:"id,omitempty"`
+	Geometry   geoJSONGeometry   `json:"geometry"`
+	Properties geoJSONProperties `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+type geoJSONProperties struct {
+	Name       string  `json:"name"`
+	Active     bool    `json:"active"`
+	Priority   int     `json:"priority"`
+	Shape      string  `json:"shape"`
+	CenterLat  float64 `json:"center_lat,omitempty"`
+	CenterLon  float64 `json:"center_lon,omitempty"`
+	RadiusKM   float64 `json:"radius_km,omitempty"`
+	Timezone   string  `json:"timezone,omitempty"`
+	Currency   string  `json:"currency,omitempty"`
+	LaunchDate string  `json:"launch_date,omitempty"`
+}
+
+// ToGeoJSON returns the area as a GeoJSON Feature with a Polygon geometry.
+// Boxes are rendered as a closed 5-point ring and circles are approximated
+// with GeoJSONCircleSegments segments. Rings wind counterclockwise, as
+// RFC 7946 requires for exterior rings. Properties include name, active,
+// priority, and shape, plus the center and radius of circles.
+func (sa ServiceArea) ToGeoJSON() ([]byte, error) {
+	return json.Marshal(sa.geoJSONFeature(""))
+}
+
+// ServiceAreasGeoJSON returns every registered service area, including
+// inactive ones, as a GeoJSON FeatureCollection. Each feature's id is the
+// registry name and features are ordered by name, so output is deterministic.
+func ServiceAreasGeoJSON() ([]byte, error) {
+	serviceAreasMu.RLock()
+	names := serviceAreaNamesLocked()
+	sort.Strings(names)
+	features := make([]geoJSONFeature, 0, len(names))
+	for _, name := range names {
+		features = append(features, serviceAreas[name].geoJSONFeature(name))
+	}
+	serviceAreasMu.RUnlock()
+
+	return json.Marshal(geoJSONFeatureCollection{Type: "FeatureCollection", Features: features})
+}
+
+// LoadServiceAreasGeoJSON parses a FeatureCollection produced by
+// ServiceAreasGeoJSON into service areas keyed by feature id.
+// Circles are rebuilt from their center and radius properties; other features
+// must be axis-aligned rectangles. The areas are not registered.
+func LoadServiceAreasGeoJSON(data []byte) (map[string]ServiceArea, error) {
+	var fc geoJSONFeatureCollection
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return nil, valerrors.InvalidFormat("geojson", "GeoJSON FeatureCollection")
+	}
+	if fc.Type != "FeatureCollection" {
+		return nil, valerrors.InvalidFormatWithValue("type", "FeatureCollection", fc.Type)
+	}
+
+	areas := make(map[string]ServiceArea, len(fc.Features))
+	for i, f := range fc.Features {
+		field := fmt.Sprintf("features[%d]", i)
+		if f.ID == "" {
+			return nil, valerrors.Required(field + ".id")
+		}
+		if _, exists := areas[f.ID]; exists {
+			return nil, valerrors.NewWithValue(field+".id", valerrors.CodeDuplicate, "feature id is repeated", f.ID)
+		}
+		sa, err := f.serviceArea(field)
+		if err != nil {
+			return nil, err
+		}
+		areas[f.ID] = sa
+	}
+	return areas, nil
+}
+
+// geoJSONFeature converts the area to a GeoJSON feature with the given id.
+func (sa ServiceArea) geoJSONFeature(id string) geoJSONFeature {
+	props := geoJSONProperties{
+		Name:     sa.Name,
+		Active:   sa.Active,
+		Priority: sa.Priority,
+		Shape:    geoJSONShapeBox,
+		Timezone: sa.Timezone,
+		Currency: sa.Currency,
+	}
+	if !sa.LaunchDate.IsZero() {
+		props.LaunchDate = sa.LaunchDate.Format(time.RFC3339)
+	}
+
+	var ring [][2]float64
+	if sa.Shape == ShapeCircle {
+		props.Shape = geoJSONShapeCircle
+		props.CenterLat = sa.CenterLat
+		props.CenterLon = sa.CenterLon
+		props.RadiusKM = sa.RadiusKM
+		ring = sa.circleRing()
+	} else {
+		ring = [][2]float64{
+			{sa.MinLon, sa.MinLat},
+			{sa.MaxLon, sa.MinLat},
+			{sa.MaxLon, sa.MaxLat},
+			{sa.MinLon, sa.MaxLat},
+			{sa.MinLon, sa.MinLat},
+		}
+	}
+
+	return geoJSONFeature{
+		Type:       "Feature",
+		ID:         id,
+		Geometry:   geoJSONGeometry{Type: "Polygon", Coordinates: [][][2]float64{ring}},
+		Properties: props,
+	}
+}
+
+// circleRing approximates the circle as a closed counterclockwise ring of
+// [lon, lat] positions that touches the area's bounding box.
+func (sa ServiceArea) circleRing() [][2]float64 {
+	minLat, maxLat, minLon, maxLon := sa.bounds()
+	dLat := (maxLat - minLat) / 2
+	dLon := (maxLon - minLon) / 2
+
+	ring := make([][2]float64, GeoJSONCircleSegments+1)
+	for i := 0; i < GeoJSONCircleSegments; i++ {
+		theta := 2 * math.Pi * float64(i) / GeoJSONCircleSegments
+		ring[i] = [2]float64{
+			roundCoordinate(sa.CenterLon + dLon*math.Cos(theta)),
+			roundCoordinate(sa.CenterLat + dLat*math.Sin(theta)),
+		}
+	}
+	ring[GeoJSONCircleSegments] = ring[0]
+	return ring
+}
+
+// roundCoordinate rounds a coordinate for GeoJSON output.
+func roundCoordinate(v float64) float64 {
+	return math.Round(v*geoJSONCoordinateScale) / geoJSONCoordinateScale
+}
+
+// serviceArea converts a parsed feature back into a service area.
+func (f geoJSONFeature) serviceArea(field string) (ServiceArea, error) {
+	props := f.Properties
+	sa := ServiceArea{
+		Name:     props.Name,
+		Active:   props.Active,
+		Priority: props.Priority,
+		Timezone: props.Timezone,
+		Currency: props.Currency,
+	}
+	if props.LaunchDate != "" {
+		launch, err := time.Parse(time.RFC3339, props.LaunchDate)
+		if err != nil {
+			return ServiceArea{}, valerrors.InvalidFormatWithValue(field+".properties.launch_date", "RFC 3339 timestamp", props.LaunchDate)
+		}
+		sa.LaunchDate = launch
+	}
+
+	switch props.Shape {
+	case geoJSONShapeCircle:
+		sa.Shape = ShapeCircle
+		sa.CenterLat = props.CenterLat
+		sa.CenterLon = props.CenterLon
+		sa.RadiusKM = props.RadiusKM
+		sa.MinLat, sa.MaxLat, sa.MinLon, sa.MaxLon = sa.bounds()
+		return sa, nil
+	case geoJSONShapeBox, "":
+	default:
+		return ServiceArea{}, valerrors.InvalidOptionWithValue(field+".properties.shape",
+			[]string{geoJSONShapeBox, geoJSONShapeCircle}, props.Shape)
+	}
+
+	if f.Geometry.Type != "Polygon" || len(f.Geometry.Coordinates) != 1 || len(f.Geometry.Coordinates[0]) != 5 {
+		return ServiceArea{}, valerrors.InvalidFormat(field+".geometry", "rectangular Polygon with a closed 5-point ring")
+	}
+	ring := f.Geometry.Coordinates[0]
+	sa.MinLon, sa.MinLat = ring[0][0], ring[0][1]
+	sa.MaxLon, sa.MaxLat = ring[0][0], ring[0][1]
+	for _, pos := range ring[1:] {
+		sa.MinLon = math.Min(sa.MinLon, pos[0])
+		sa.MaxLon = math.Max(sa.MaxLon, pos[0])
+		sa.MinLat = math.Min(sa.MinLat, pos[1])
+		sa.MaxLat = math.Max(sa.MaxLat, pos[1])
+	}
+	for _, pos := range ring {
+		onLon := pos[0] == sa.MinLon || pos[0] == sa.MaxLon
+		onLat := pos[1] == sa.MinLat || pos[1] == sa.MaxLat
+		if !onLon || !onLat {
+			return ServiceArea{}, valerrors.InvalidFormat(field+".geometry", "rectangular Polygon with a closed 5-point ring")
+		}
+	}
+	if ring[0] != ring[4] {
+		return ServiceArea{}, valerrors.InvalidFormat(field+".geometry", "rectangular Polygon with a closed 5-point ring")
+	}
+	return sa, nil
+}
@@ -0,0 +1,176 @@
+package geo
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+// signedRingArea returns twice the signed area of a ring; positive means counterclockwise.
+func signedRingArea(ring [][2]float64) float64 {
+	var sum float64
+	for i := 0; i < len(ring)-1; i++ {
+		sum += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	return sum
+}
+
+func TestServiceAreasGeoJSON_Golden(t *testing.T) {
+	got, err := ServiceAreasGeoJSON()
+	if err != nil {
+		t.Fatalf("ServiceAreasGeoJSON() error = %v", err)
+	}
+
+	golden := filepath.Join("testdata", "service_areas.geojson")
+	if *updateGolden {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatalf("writing golden file: %v", err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("ServiceAreasGeoJSON() = %s\nwant %s", got, want)
+	}
+
+	again, _ := ServiceAreasGeoJSON()
+	if !bytes.Equal(got, again) {
+		t.Error("ServiceAreasGeoJSON() is not deterministic")
+	}
+}
+
+func TestServiceArea_ToGeoJSON(t *testing.T) {
+	tests := []struct {
+		name       string
+		sa         ServiceArea
+		wantShape  string
+		wantPoints int
+	}{
+		{"box", *GetServiceArea("maputo"), "box", 5},
+		{"circle", NewCircularServiceArea("Nampula", nampulaCenter.lat, nampulaCenter.lon, 15), "circle", GeoJSONCircleSegments + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.sa.ToGeoJSON()
+			if err != nil {
+				t.Fatalf("ToGeoJSON() error = %v", err)
+			}
+
+			var f geoJSONFeature
+			if err := json.Unmarshal(data, &f); err != nil {
+				t.Fatalf("ToGeoJSON() produced invalid JSON: %v", err)
+			}
+			if f.Type != "Feature" || f.Geometry.Type != "Polygon" {
+				t.Errorf("ToGeoJSON() types = %q/%q, want Feature/Polygon", f.Type, f.Geometry.Type)
+			}
+			if f.Properties.Name != tt.sa.Name || f.Properties.Active != tt.sa.Active ||
+				f.Properties.Priority != tt.sa.Priority || f.Properties.Shape != tt.wantShape {
+				t.Errorf("ToGeoJSON() properties = %+v", f.Properties)
+			}
+
+			ring := f.Geometry.Coordinates[0]
+			if len(ring) != tt.wantPoints {
+				t.Fatalf("ring has %d points, want %d", len(ring), tt.wantPoints)
+			}
+			if ring[0] != ring[len(ring)-1] {
+				t.Error("ring is not closed")
+			}
+			if signedRingArea(ring) <= 0 {
+				t.Error("ring is not counterclockwise")
+			}
+			for _, pos := range ring {
+				if tt.wantShape == "box" && !tt.sa.Contains(pos[1], pos[0]) {
+					t.Errorf("vertex %v is outside the area", pos)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadServiceAreasGeoJSON_RoundTrip(t *testing.T) {
+	pilot := NewCircularServiceArea("Nampula", nampulaCenter.lat, nampulaCenter.lon, 15)
+	pilot.Priority = 5
+	if err := RegisterServiceArea("nampula", pilot); err != nil {
+		t.Fatalf("RegisterServiceArea() error = %v", err)
+	}
+	t.Cleanup(func() { UnregisterServiceArea("nampula") })
+
+	data, err := ServiceAreasGeoJSON()
+	if err != nil {
+		t.Fatalf("ServiceAreasGeoJSON() error = %v", err)
+	}
+	loaded, err := LoadServiceAreasGeoJSON(data)
+	if err != nil {
+		t.Fatalf("LoadServiceAreasGeoJSON() error = %v", err)
+	}
+	if len(loaded) != len(GetServiceAreas()) {
+		t.Fatalf("LoadServiceAreasGeoJSON() returned %d areas, want %d", len(loaded), len(GetServiceAreas()))
+	}
+
+	for _, name := range GetServiceAreas() {
+		want := *GetServiceArea(name)
+		got, ok := loaded[name]
+		if !ok {
+			t.Errorf("area %q missing after round trip", name)
+			continue
+		}
+		if !got.LaunchDate.Equal(want.LaunchDate) {
+			t.Errorf("%s LaunchDate = %v, want %v", name, got.LaunchDate, want.LaunchDate)
+		}
+		got.LaunchDate = want.LaunchDate
+		if got != want {
+			t.Errorf("%s round trip = %+v, want %+v", name, got, want)
+		}
+	}
+}
+
+func TestLoadServiceAreasGeoJSON_Invalid(t *testing.T) {
+	triangle := `{"type":"FeatureCollection","features":[{"type":"Feature","id":"t","geometry":{"type":"Polygon",` +
+		`"coordinates":[[[32.3,-26.1],[32.7,-26.1],[32.5,-25.8],[32.3,-26.1],[32.3,-26.1]]]},"properties":{"name":"T","shape":"box"}}]}`
+	box := `{"type":"Feature","id":"%s","geometry":{"type":"Polygon","coordinates":[[[32.3,-26.1],[32.7,-26.1],[32.7,-25.8],[32.3,-25.8],[32.3,-26.1]]]},"properties":{"name":"B","shape":"%s"}}`
+
+	tests := []struct {
+		name      string
+		data      string
+		wantField string
+		wantCode  string
+	}{
+		{"not JSON", `{`, "geojson", valerrors.CodeInvalidFormat},
+		{"wrong type", `{"type":"Feature","features":[]}`, "type", valerrors.CodeInvalidFormat},
+		{"non-rectangular polygon", triangle, "features[0].geometry", valerrors.CodeInvalidFormat},
+		{"missing id", `{"type":"FeatureCollection","features":[` + fmt.Sprintf(box, "", "box") + `]}`, "features[0].id", valerrors.CodeRequired},
+		{"duplicate id", `{"type":"FeatureCollection","features":[` + fmt.Sprintf(box, "a", "box") + `,` + fmt.Sprintf(box, "a", "box") + `]}`, "features[1].id", valerrors.CodeDuplicate},
+		{"unknown shape", `{"type":"FeatureCollection","features":[` + fmt.Sprintf(box, "a", "hexagon") + `]}`, "features[0].properties.shape", valerrors.CodeInvalidOption},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadServiceAreasGeoJSON([]byte(tt.data))
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok {
+				t.Fatalf("LoadServiceAreasGeoJSON() error = %v, want ValidationError", err)
+			}
+			if ve.Field != tt.wantField || ve.Code != tt.wantCode {
+				t.Errorf("LoadServiceAreasGeoJSON() error = %s/%s, want %s/%s", ve.Field, ve.Code, tt.wantField, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestLoadServiceAreasGeoJSON_Empty(t *testing.T) {
+	areas, err := LoadServiceAreasGeoJSON([]byte(`{"type":"FeatureCollection","features":[]}`))
+	if err != nil || len(areas) != 0 {
+		t.Errorf("LoadServiceAreasGeoJSON() = %v, %v, want empty", areas, err)
+	}
+}
@@ -0,0 +1,86 @@
+package geo
+
+import (
+	"fmt"
+
+	"github.com/Dorico-Dynamics/txova-go-types/geo"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// locationField is the field name used for errors about a Location value.
+const locationField = "location"
+
+// IsZeroLocation reports whether loc is the zero Location, which the
+// Location validators treat as missing rather than as the point (0, 0).
+func IsZeroLocation(loc geo.Location) bool {
+	return loc.Latitude() == 0 && loc.Longitude() == 0
+}
+
+// ValidateLocationInMozambique is ValidateInMozambique for Location values.
+// The zero Location is reported as REQUIRED.
+func ValidateLocationInMozambique(loc geo.Location, opts ...Option) error {
+	if IsZeroLocation(loc) {
+		return valerrors.Required(locationField)
+	}
+	return ValidateInMozambique(loc.Latitude(), loc.Longitude(), opts...)
+}
+
+// ValidateLocationServiceArea is ValidateServiceArea for Location values.
+// The zero Location is reported as REQUIRED.
+func ValidateLocationServiceArea(loc geo.Location, area string) error {
+	if IsZeroLocation(loc) {
+		return valerrors.Required(locationField)
+	}
+	return ValidateServiceArea(loc.Latitude(), loc.Longitude(), area)
+}
+
+// FindServiceAreaForLocation is FindServiceArea for Location values.
+// Returns an empty string for the zero Location.
+func FindServiceAreaForLocation(loc geo.Location) string {
+	if IsZeroLocation(loc) {
+		return ""
+	}
+	return FindServiceArea(loc.Latitude(), loc.Longitude())
+}
+
+// DistanceBetween returns the distance in kilometers between two locations,
+// using the current DistanceMode like CalculateDistance.
+// Returns 0 if either location is the zero Location.
+func DistanceBetween(loc1, loc2 geo.Location) float64 {
+	if IsZeroLocation(loc1) || IsZeroLocation(loc2) {
+		return 0
+	}
+	return modeDistanceKM(loc1.Latitude(), loc1.Longitude(), loc2.Latitude(), loc2.Longitude())
+}
+
+// ValidateDisjointPickupDropoff checks that a pickup and dropoff are both set
+// and at least minSeparationKM apart. Unlike a single error it reports every
+// problem: REQUIRED on "pickup" and "dropoff" for zero locations, and
+// OUT_OF_RANGE on "pickup_dropoff", with the actual distance in kilometers as
+// the value, when both are set but too close. A negative minSeparationKM is
+// reported as INVALID_FORMAT on "min_separation".
+// Returns nil if the pair is valid.
+func ValidateDisjointPickupDropoff(pickup, dropoff geo.Location, minSeparationKM float64) valerrors.ValidationErrors {
+	var errs valerrors.ValidationErrors
+	if !(minSeparationKM >= 0) {
+		errs.Add(valerrors.InvalidFormatWithValue("min_separation", "non-negative number of kilometers", minSeparationKM))
+		return errs
+	}
+	if IsZeroLocation(pickup) {
+		errs.Add(valerrors.Required("pickup"))
+	}
+	if IsZeroLocation(dropoff) {
+		errs.Add(valerrors.Required("dropoff"))
+	}
+	if errs.HasErrors() {
+		return errs
+	}
+
+	if distance := DistanceBetween(pickup, dropoff); distance < minSeparationKM {
+		errs.Add(valerrors.NewWithValue("pickup_dropoff", valerrors.CodeOutOfRange,
+			fmt.Sprintf("pickup and dropoff must be at least %g km apart", minSeparationKM), distance).
+			WithParam("min_km", minSeparationKM))
+	}
+	return errs
+}
@@ -0,0 +1,201 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Dorico-Dynamics/txova-go-types/geo"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestIsZeroLocation(t *testing.T) {
+	tests := []struct {
+		name string
+		loc  geo.Location
+		want bool
+	}{
+		{"zero value", geo.Location{}, true},
+		{"explicit origin", geo.MustNewLocation(0, 0), true},
+		{"equator only", geo.MustNewLocation(0, 32.573), false},
+		{"prime meridian only", geo.MustNewLocation(-25.969, 0), false},
+		{"Maputo", geo.MustNewLocation(-25.969, 32.573), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsZeroLocation(tt.loc); got != tt.want {
+				t.Errorf("IsZeroLocation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateLocationInMozambique(t *testing.T) {
+	tests := []struct {
+		name    string
+		loc     geo.Location
+		wantErr bool
+		errCode string
+	}{
+		// Valid Mozambique locations
+		{"Maputo center", geo.MustNewLocation(-25.969, 32.573), false, ""},
+		{"Beira", geo.MustNewLocation(-19.84, 34.84), false, ""},
+		{"Nampula", geo.MustNewLocation(-15.12, 39.27), false, ""},
+		{"Mozambique north", geo.MustNewLocation(-11.67, 39.56), false, ""},
+
+		// Outside Mozambique
+		{"South Africa", geo.MustNewLocation(-29.0, 24.0), true, valerrors.CodeOutsideServiceArea},
+		{"Tanzania", geo.MustNewLocation(-6.0, 35.0), true, valerrors.CodeOutsideServiceArea},
+		{"Madagascar", geo.MustNewLocation(-19.0, 47.0), true, valerrors.CodeOutsideServiceArea},
+
+		// Missing location
+		{"zero location", geo.Location{}, true, valerrors.CodeRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLocationInMozambique(tt.loc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateLocationInMozambique(%v) error = %v, wantErr %v", tt.loc, err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Field != "location" || ve.Code != tt.errCode {
+				t.Errorf("error = %v, want location/%s", err, tt.errCode)
+			}
+			if tt.errCode != valerrors.CodeRequired {
+				if want := ValidateInMozambique(tt.loc.Latitude(), tt.loc.Longitude()); err.Error() != want.Error() {
+					t.Errorf("error = %v, want same as float version %v", err, want)
+				}
+			}
+		})
+	}
+
+	err := ValidateLocationInMozambique(geo.MustNewLocation(-26.2041, 28.0473), WithCountryHint())
+	if ve, ok := err.(valerrors.ValidationError); !ok || ve.Param("country") != CountrySouthAfrica {
+		t.Errorf("ValidateLocationInMozambique() with WithCountryHint = %v, want country hint", err)
+	}
+}
+
+func TestValidateLocationServiceArea(t *testing.T) {
+	tests := []struct {
+		name    string
+		loc     geo.Location
+		area    string
+		wantErr bool
+		errCode string
+	}{
+		{"Maputo center", geo.MustNewLocation(-25.95, 32.5), "maputo", false, ""},
+		{"Matola center", geo.MustNewLocation(-25.95, 32.4), "matola", false, ""},
+		{"Beira center", geo.MustNewLocation(-19.8, 34.85), "beira", false, ""},
+
+		{"Maputo location in Beira area", geo.MustNewLocation(-25.95, 32.5), "beira", true, valerrors.CodeOutsideServiceArea},
+		{"invalid area", geo.MustNewLocation(-25.95, 32.5), "invalid", true, valerrors.CodeInvalidOption},
+		{"zero location", geo.Location{}, "maputo", true, valerrors.CodeRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLocationServiceArea(tt.loc, tt.area)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateLocationServiceArea(%v, %q) error = %v, wantErr %v", tt.loc, tt.area, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if ve, ok := err.(valerrors.ValidationError); !ok || ve.Code != tt.errCode {
+					t.Errorf("error = %v, want code %v", err, tt.errCode)
+				}
+			}
+		})
+	}
+}
+
+func TestFindServiceAreaForLocation(t *testing.T) {
+	tests := []struct {
+		name string
+		loc  geo.Location
+		want string
+	}{
+		{"in Maputo only", geo.MustNewLocation(-25.85, 32.6), "maputo"},
+		{"in overlapping Maputo/Matola area", geo.MustNewLocation(-25.95, 32.4), "matola"},
+		{"in Beira", geo.MustNewLocation(-19.8, 34.85), "beira"},
+		{"outside all", geo.MustNewLocation(-15.0, 39.0), ""},
+		{"zero location", geo.Location{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindServiceAreaForLocation(tt.loc); got != tt.want {
+				t.Errorf("FindServiceAreaForLocation(%v) = %q, want %q", tt.loc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistanceBetween(t *testing.T) {
+	maputo := geo.MustNewLocation(-25.969, 32.573)
+	beira := geo.MustNewLocation(-19.84, 34.84)
+
+	want, _ := CalculateDistance(-25.969, 32.573, -19.84, 34.84)
+	if got := DistanceBetween(maputo, beira); math.Abs(got-want) > 1e-9 {
+		t.Errorf("DistanceBetween() = %v, want %v", got, want)
+	}
+	if got := DistanceBetween(maputo, maputo); got != 0 {
+		t.Errorf("DistanceBetween() same location = %v, want 0", got)
+	}
+	if got := DistanceBetween(geo.Location{}, beira); got != 0 {
+		t.Errorf("DistanceBetween() with zero location = %v, want 0", got)
+	}
+
+	t.Cleanup(func() { SetDistanceMode(DistanceHaversine) })
+	SetDistanceMode(DistanceEllipsoidal)
+	want, _ = CalculateDistanceEllipsoidal(-25.969, 32.573, -19.84, 34.84)
+	if got := DistanceBetween(maputo, beira); got != want {
+		t.Errorf("DistanceBetween() in ellipsoidal mode = %v, want %v", got, want)
+	}
+}
+
+func TestValidateDisjointPickupDropoff(t *testing.T) {
+	maputo := geo.MustNewLocation(-25.969, 32.573)
+	nearby := geo.MustNewLocation(-25.9695, 32.5735)
+	farAway := geo.MustNewLocation(-25.980, 32.590)
+
+	tests := []struct {
+		name       string
+		pickup     geo.Location
+		dropoff    geo.Location
+		minKM      float64
+		wantFields []string
+		wantCodes  []string
+	}{
+		{"far apart", maputo, farAway, 0.1, nil, nil},
+		{"too close", maputo, nearby, 0.1, []string{"pickup_dropoff"}, []string{valerrors.CodeOutOfRange}},
+		{"same location", maputo, maputo, 0.1, []string{"pickup_dropoff"}, []string{valerrors.CodeOutOfRange}},
+		{"zero separation allowed", maputo, maputo, 0, nil, nil},
+		{"zero pickup", geo.Location{}, farAway, 0.1, []string{"pickup"}, []string{valerrors.CodeRequired}},
+		{"both zero", geo.Location{}, geo.Location{}, 0.1,
+			[]string{"pickup", "dropoff"}, []string{valerrors.CodeRequired, valerrors.CodeRequired}},
+		{"negative separation", maputo, farAway, -1, []string{"min_separation"}, []string{valerrors.CodeInvalidFormat}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateDisjointPickupDropoff(tt.pickup, tt.dropoff, tt.minKM)
+			if len(errs) != len(tt.wantFields) {
+				t.Fatalf("ValidateDisjointPickupDropoff() = %v, want fields %v", errs, tt.wantFields)
+			}
+			for i, e := range errs {
+				if e.Field != tt.wantFields[i] || e.Code != tt.wantCodes[i] {
+					t.Errorf("errs[%d] = %s/%s, want %s/%s", i, e.Field, e.Code, tt.wantFields[i], tt.wantCodes[i])
+				}
+			}
+		})
+	}
+
+	errs := ValidateDisjointPickupDropoff(maputo, nearby, 0.1)
+	if want := DistanceBetween(maputo, nearby); errs[0].Value != want {
+		t.Errorf("Value = %v, want actual distance %v", errs[0].Value, want)
+	}
+}
@@ -0,0 +1,119 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// tileReferenceLat is the latitude whose scale sets the longitude width of
+// grid tiles: the middle of Mozambique's bounding box.
+const tileReferenceLat = (MozambiqueMinLat + MozambiqueMaxLat) / 2
+
+// tileIDFormat formats a tile's row and column, counted from the south-west
+// corner of Mozambique's bounding box.
+const tileIDFormat = "r%04d_c%04d"
+
+// tileGrid is a fixed grid of square tiles laid over Mozambique's bounding box.
+type tileGrid struct {
+	latStep, lonStep float64
+	rows, cols       int
+}
+
+// newTileGrid returns the grid for tiles of the given size in kilometers.
+// Tiles span tileSizeKM of latitude and tileSizeKM of longitude at
+// tileReferenceLat, so every tile covers the same range of degrees.
+func newTileGrid(tileSizeKM float64) (tileGrid, error) {
+	if !(tileSizeKM > 0) || math.IsInf(tileSizeKM, 1) {
+		return tileGrid{}, valerrors.InvalidFormatWithValue("tile_size", "positive number of kilometers", tileSizeKM)
+	}
+	g := tileGrid{
+		latStep: tileSizeKM / kmPerDegreeLat,
+		lonStep: tileSizeKM / (kmPerDegreeLat * math.Cos(tileReferenceLat*math.Pi/180)),
+	}
+	g.rows = int(math.Ceil((MozambiqueMaxLat - MozambiqueMinLat) / g.latStep))
+	g.cols = int(math.Ceil((MozambiqueMaxLon - MozambiqueMinLon) / g.lonStep))
+	return g, nil
+}
+
+// parse returns the row and column of a tile ID on this grid.
+func (g tileGrid) parse(id string) (row, col int, err error) {
+	var rest string
+	n, _ := fmt.Sscanf(id, "r%d_c%d%s", &row, &col, &rest)
+	if n != 2 || fmt.Sprintf(tileIDFormat, row, col) != id {
+		return 0, 0, valerrors.InvalidFormatWithValue("tile_id", "tile ID such as r0012_c0034", id)
+	}
+	if row < 0 || row >= g.rows || col < 0 || col >= g.cols {
+		return 0, 0, valerrors.NewWithValue("tile_id", valerrors.CodeOutOfRange, "tile is outside Mozambique's grid", id)
+	}
+	return row, col, nil
+}
+
+// TileID returns the key of the fixed grid tile containing the coordinates,
+// e.g. "r0123_c0045". Rows count north and columns east from the south-west
+// corner of Mozambique's bounding box. Tiles are tileSizeKM tall; their width
+// is tileSizeKM at the middle of the country and narrows slightly toward the
+// south. The same size must be used to compare or invert IDs.
+// Returns OUTSIDE_SERVICE_AREA for coordinates outside Mozambique.
+func TileID(lat, lon float64, tileSizeKM float64) (string, error) {
+	g, err := newTileGrid(tileSizeKM)
+	if err != nil {
+		return "", err
+	}
+	if err := ValidateInMozambique(lat, lon); err != nil {
+		return "", err
+	}
+
+	row := min(int((lat-MozambiqueMinLat)/g.latStep), g.rows-1)
+	col := min(int((lon-MozambiqueMinLon)/g.lonStep), g.cols-1)
+	return fmt.Sprintf(tileIDFormat, row, col), nil
+}
+
+// TileBounds returns the bounds of a tile produced by TileID with the same
+// tileSizeKM, as a box ServiceArea named after the tile. Tiles on the northern
+// and eastern edge of the grid may extend past Mozambique's bounding box.
+func TileBounds(id string, tileSizeKM float64) (ServiceArea, error) {
+	g, err := newTileGrid(tileSizeKM)
+	if err != nil {
+		return ServiceArea{}, err
+	}
+	row, col, err := g.parse(id)
+	if err != nil {
+		return ServiceArea{}, err
+	}
+
+	return ServiceArea{
+		Name:   id,
+		MinLat: MozambiqueMinLat + float64(row)*g.latStep,
+		MaxLat: MozambiqueMinLat + float64(row+1)*g.latStep,
+		MinLon: MozambiqueMinLon + float64(col)*g.lonStep,
+		MaxLon: MozambiqueMinLon + float64(col+1)*g.lonStep,
+		Shape:  ShapeBox,
+	}, nil
+}
+
+// NeighborTiles returns the IDs of the up to 8 tiles surrounding id, ordered
+// from south-west to north-east. Tiles beyond the grid over Mozambique's
+// bounding box are omitted. Returns nil for an invalid ID or tile size.
+func NeighborTiles(id string, tileSizeKM float64) []string {
+	g, err := newTileGrid(tileSizeKM)
+	if err != nil {
+		return nil
+	}
+	row, col, err := g.parse(id)
+	if err != nil {
+		return nil
+	}
+
+	neighbors := make([]string, 0, 8)
+	for r := row - 1; r <= row+1; r++ {
+		for c := col - 1; c <= col+1; c++ {
+			if (r == row && c == col) || r < 0 || r >= g.rows || c < 0 || c >= g.cols {
+				continue
+			}
+			neighbors = append(neighbors, fmt.Sprintf(tileIDFormat, r, c))
+		}
+	}
+	return neighbors
+}
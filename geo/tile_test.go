@@ -0,0 +1,165 @@
+package geo
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestTileID(t *testing.T) {
+	id, err := TileID(-25.9692, 32.5732, 5)
+	if err != nil {
+		t.Fatalf("TileID() error = %v", err)
+	}
+	again, _ := TileID(-25.9692, 32.5732, 5)
+	if id != again {
+		t.Errorf("TileID() is not stable: %q then %q", id, again)
+	}
+
+	bounds, err := TileBounds(id, 5)
+	if err != nil {
+		t.Fatalf("TileBounds(%q) error = %v", id, err)
+	}
+	if !bounds.Contains(-25.9692, 32.5732) {
+		t.Errorf("TileBounds(%q) = %+v, does not contain the point", id, bounds)
+	}
+	if bounds.Name != id || bounds.Shape != ShapeBox {
+		t.Errorf("TileBounds(%q) = %+v, want a box named after the tile", id, bounds)
+	}
+
+	coarse, _ := TileID(-25.9692, 32.5732, 50)
+	if coarse == id {
+		t.Errorf("TileID() = %q for both 5 km and 50 km tiles", id)
+	}
+}
+
+func TestTileID_AcrossEdge(t *testing.T) {
+	id, _ := TileID(-25.9692, 32.5732, 5)
+	bounds, _ := TileBounds(id, 5)
+
+	// About 1 m either side of the tile's northern and eastern edges.
+	const eps = 1e-5
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		wantRow, wantCol       int
+	}{
+		{"northern edge", bounds.MaxLat - eps, 32.5732, bounds.MaxLat + eps, 32.5732, 1, 0},
+		{"eastern edge", -25.9692, bounds.MaxLon - eps, -25.9692, bounds.MaxLon + eps, 0, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := TileID(tt.lat1, tt.lon1, 5)
+			if err != nil {
+				t.Fatalf("TileID() error = %v", err)
+			}
+			b, err := TileID(tt.lat2, tt.lon2, 5)
+			if err != nil {
+				t.Fatalf("TileID() error = %v", err)
+			}
+			if a != id {
+				t.Errorf("TileID() inside the edge = %q, want %q", a, id)
+			}
+
+			var r1, c1, r2, c2 int
+			fmt.Sscanf(a, tileIDFormat, &r1, &c1)
+			fmt.Sscanf(b, tileIDFormat, &r2, &c2)
+			if r2-r1 != tt.wantRow || c2-c1 != tt.wantCol {
+				t.Errorf("TileID() across the edge = %q, %q; want offset (%d, %d)", a, b, tt.wantRow, tt.wantCol)
+			}
+		})
+	}
+}
+
+func TestTileID_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		lat, lon  float64
+		size      float64
+		wantField string
+		wantCode  string
+	}{
+		{"outside Mozambique", -26.2041, 28.0473, 5, "location", valerrors.CodeOutsideServiceArea},
+		{"invalid coordinates", 91, 32.5, 5, "latitude", valerrors.CodeOutOfRange},
+		{"zero size", -25.9692, 32.5732, 0, "tile_size", valerrors.CodeInvalidFormat},
+		{"negative size", -25.9692, 32.5732, -5, "tile_size", valerrors.CodeInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := TileID(tt.lat, tt.lon, tt.size)
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok {
+				t.Fatalf("TileID() error = %v, want ValidationError", err)
+			}
+			if ve.Field != tt.wantField || ve.Code != tt.wantCode {
+				t.Errorf("TileID() error = %s/%s, want %s/%s", ve.Field, ve.Code, tt.wantField, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestTileBounds_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		wantCode string
+	}{
+		{"empty", "", valerrors.CodeInvalidFormat},
+		{"garbage", "tile-7", valerrors.CodeInvalidFormat},
+		{"unpadded", "r1_c2", valerrors.CodeInvalidFormat},
+		{"trailing text", "r0001_c0002x", valerrors.CodeInvalidFormat},
+		{"row beyond grid", "r9999_c0000", valerrors.CodeOutOfRange},
+		{"negative column", "r0000_c-001", valerrors.CodeOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := TileBounds(tt.id, 5)
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Field != "tile_id" || ve.Code != tt.wantCode {
+				t.Errorf("TileBounds(%q) error = %v, want tile_id/%s", tt.id, err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestNeighborTiles(t *testing.T) {
+	g, _ := newTileGrid(50)
+	lastRow, lastCol := g.rows-1, g.cols-1
+	id := func(r, c int) string { return fmt.Sprintf(tileIDFormat, r, c) }
+
+	tests := []struct {
+		name string
+		id   string
+		want []string
+	}{
+		{"interior", id(5, 5), []string{
+			id(4, 4), id(4, 5), id(4, 6),
+			id(5, 4), id(5, 6),
+			id(6, 4), id(6, 5), id(6, 6),
+		}},
+		{"south-west corner", id(0, 0), []string{id(0, 1), id(1, 0), id(1, 1)}},
+		{"north-east corner", id(lastRow, lastCol), []string{
+			id(lastRow-1, lastCol-1), id(lastRow-1, lastCol), id(lastRow, lastCol-1),
+		}},
+		{"southern edge", id(0, 5), []string{id(0, 4), id(0, 6), id(1, 4), id(1, 5), id(1, 6)}},
+		{"invalid ID", "nope", nil},
+		{"outside grid", id(lastRow+1, 0), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NeighborTiles(tt.id, 50); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NeighborTiles(%q) = %v, want %v", tt.id, got, tt.want)
+			}
+		})
+	}
+
+	if got := NeighborTiles(id(5, 5), 0); got != nil {
+		t.Errorf("NeighborTiles() with zero size = %v, want nil", got)
+	}
+}
@@ -2,15 +2,19 @@ module github.com/Dorico-Dynamics/txova-go-validation
 
 go 1.25.6
 
-require github.com/Dorico-Dynamics/txova-go-types v1.1.1
+require (
+	github.com/Dorico-Dynamics/txova-go-types v1.1.1
+	github.com/go-playground/validator/v10 v10.30.1
+	golang.org/x/text v0.32.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20251202230838-ff82c1b0f217
+)
 
 require (
 	github.com/gabriel-vasile/mimetype v1.4.12 // indirect
 	github.com/go-playground/locales v0.14.1 // indirect
 	github.com/go-playground/universal-translator v0.18.1 // indirect
-	github.com/go-playground/validator/v10 v10.30.1 // indirect
 	github.com/leodido/go-urn v1.4.0 // indirect
 	golang.org/x/crypto v0.46.0 // indirect
 	golang.org/x/sys v0.39.0 // indirect
-	golang.org/x/text v0.32.0 // indirect
+	google.golang.org/protobuf v1.36.10 // indirect
 )
@@ -2,10 +2,14 @@
 package phone
 
 import (
+	"errors"
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/Dorico-Dynamics/txova-go-types/contact"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
 )
 
 // MozambiqueCountryCode is the country calling code for Mozambique.
@@ -21,6 +25,22 @@ var validPrefixes = map[string]bool{
 	"87": true,
 }
 
+// LandlineLength is the number of digits in a fixed-line number without the
+// country code, including the area code.
+const LandlineLength = 8
+
+// LandlineAreaCodes maps fixed-line area codes to the city they serve.
+var LandlineAreaCodes = map[string]string{
+	"21": "Maputo",
+	"23": "Beira",
+	"24": "Quelimane",
+	"25": "Tete",
+	"26": "Nampula",
+	"27": "Pemba",
+	"28": "Xai-Xai",
+	"29": "Inhambane",
+}
+
 // digitsOnly matches all non-digit characters.
 var digitsOnly = regexp.MustCompile(`\D`)
 
@@ -90,6 +110,192 @@ func Normalize(input string) (string, error) {
 	return "+" + MozambiqueCountryCode + localNumber, nil
 }
 
+// ValidateAny checks if the input is a valid Mozambique mobile or landline
+// number.
+func ValidateAny(input string) bool {
+	_, err := NormalizeAny(input)
+	return err == nil
+}
+
+// NormalizeAny is like Normalize but also accepts landline numbers: 8 digits
+// starting with an area code from LandlineAreaCodes, e.g. 21123456, with the
+// same country code and separator formats. Landlines normalize to
+// +258XXXXXXXX, e.g. +25821123456. Mobile numbers normalize as in Normalize,
+// and for input that is neither, Normalize's error is returned.
+func NormalizeAny(input string) (string, error) {
+	normalized, err := Normalize(input)
+	if err == nil {
+		return normalized, nil
+	}
+	if landline, ok := normalizeLandline(input); ok {
+		return landline, nil
+	}
+	return "", err
+}
+
+// IsMobile returns true if the input is a valid Mozambique mobile number.
+func IsMobile(input string) bool {
+	return Validate(input)
+}
+
+// IsLandline returns true if the input is a valid Mozambique landline number.
+func IsLandline(input string) bool {
+	_, ok := normalizeLandline(input)
+	return ok
+}
+
+// normalizeLandline converts a landline number to +258XXXXXXXX.
+func normalizeLandline(input string) (string, bool) {
+	digits := digitsOnly.ReplaceAllString(input, "")
+
+	var localNumber string
+	switch {
+	case len(digits) == LandlineLength:
+		localNumber = digits
+	case len(digits) == LandlineLength+3 && strings.HasPrefix(digits, MozambiqueCountryCode):
+		localNumber = digits[3:]
+	case len(digits) == LandlineLength+5 && strings.HasPrefix(digits, "00"+MozambiqueCountryCode):
+		localNumber = digits[5:]
+	default:
+		return "", false
+	}
+
+	if _, ok := LandlineAreaCodes[localNumber[:2]]; !ok {
+		return "", false
+	}
+	return "+" + MozambiqueCountryCode + localNumber, true
+}
+
+// ValidateDetailed validates a phone number like Validate and explains why
+// an invalid number was rejected, with a single error on "phone":
+//   - an empty input is REQUIRED;
+//   - an input with letters or without digits is INVALID_FORMAT;
+//   - a number that is not 9 digits, 12 with 258, or 14 with 00258 is
+//     INVALID_FORMAT with the digit count in Param("length");
+//   - a number with another country code is INVALID_FORMAT with the code in
+//     Param("country_code");
+//   - a number with a prefix other than 82-87 is INVALID_OPTION.
+//
+// The error's Value shows only the last 4 digits, e.g. "***4567", so it is
+// safe to return to clients and log. Returns nil if the number is valid.
+func ValidateDetailed(input string) valerrors.ValidationErrors {
+	if _, err := Normalize(input); err == nil {
+		return nil
+	}
+	if strings.TrimSpace(input) == "" {
+		return valerrors.ValidationErrors{valerrors.Required("phone")}
+	}
+
+	digits := digitsOnly.ReplaceAllString(input, "")
+	masked := maskDigits(digits)
+	if digits == "" || strings.IndexFunc(input, unicode.IsLetter) >= 0 {
+		return valerrors.ValidationErrors{valerrors.InvalidFormatWithValue("phone", "digits only", masked)}
+	}
+
+	var local string
+	switch {
+	case len(digits) == 9:
+		local = digits
+	case len(digits) == 12 && !strings.HasPrefix(digits, MozambiqueCountryCode):
+		return countryCodeError(digits[:3], masked)
+	case len(digits) == 12:
+		local = digits[3:]
+	case len(digits) == 14 && strings.HasPrefix(digits, "00") && !strings.HasPrefix(digits, "00"+MozambiqueCountryCode):
+		return countryCodeError(digits[2:5], masked)
+	case len(digits) == 14 && strings.HasPrefix(digits, "00"):
+		local = digits[5:]
+	default:
+		return valerrors.ValidationErrors{
+			valerrors.InvalidFormatWithValue("phone", "9 digits, 12 with 258, or 14 with 00258", masked).
+				WithParam("length", len(digits)),
+		}
+	}
+
+	return valerrors.ValidationErrors{
+		valerrors.InvalidOptionWithValue("phone", []string{"82", "83", "84", "85", "86", "87"}, masked).
+			WithParam("prefix", local[:2]),
+	}
+}
+
+// countryCodeError reports a number whose country code is not Mozambique's.
+func countryCodeError(code, masked string) valerrors.ValidationErrors {
+	return valerrors.ValidationErrors{
+		valerrors.NewWithValue("phone", valerrors.CodeInvalidFormat,
+			"phone has country code "+code+", expected "+MozambiqueCountryCode, masked).
+			WithParam("country_code", code),
+	}
+}
+
+// maskDigits hides all but the last 4 digits, or every digit of a shorter
+// input.
+func maskDigits(digits string) string {
+	if len(digits) <= 4 {
+		return "***"
+	}
+	return "***" + digits[len(digits)-4:]
+}
+
+// ValidateAndNormalizeBatch normalizes many phone numbers in one call.
+// The returned map holds original input -> +258XXXXXXXXX for every valid,
+// unique entry. Invalid entries are reported with Field set to the original
+// input. When several inputs normalize to the same number, each of them is
+// reported with CodeDuplicate and none is added to the map.
+// Errors are returned in input order, or nil if every entry is valid.
+func ValidateAndNormalizeBatch(numbers []string) (map[string]string, valerrors.ValidationErrors) {
+	normalized := make([]string, len(numbers))
+	failures := make([]error, len(numbers))
+	counts := make(map[string]int, len(numbers))
+
+	for i, input := range numbers {
+		normalized[i], failures[i] = Normalize(input)
+		if failures[i] == nil {
+			counts[normalized[i]]++
+		}
+	}
+
+	result := make(map[string]string, len(numbers))
+	var errs valerrors.ValidationErrors
+	for i, input := range numbers {
+		switch {
+		case errors.Is(failures[i], contact.ErrInvalidMobilePrefix):
+			errs.Add(valerrors.InvalidFormatWithValue(input, "valid Mozambique mobile prefix (82-87)", input))
+		case failures[i] != nil:
+			errs.Add(valerrors.InvalidFormatWithValue(input, "valid Mozambique phone number", input))
+		case counts[normalized[i]] > 1:
+			errs.Add(valerrors.NewWithValue(input, valerrors.CodeDuplicate,
+				"phone number appears more than once in the batch", normalized[i]))
+		default:
+			result[input] = normalized[i]
+		}
+	}
+
+	if len(errs) == 0 {
+		return result, nil
+	}
+	return result, errs
+}
+
+// Mask returns a phone number for logs and support views with the middle
+// digits hidden, e.g. "+258 84 *** 4567". Returns "***" if the input is not a
+// valid number, so no digits of a malformed input are ever shown.
+func Mask(input string) string {
+	local := MaskLocal(input)
+	if local == "***" {
+		return local
+	}
+	return "+" + MozambiqueCountryCode + " " + local
+}
+
+// MaskLocal is like Mask without the country code, e.g. "84 *** 4567".
+func MaskLocal(input string) string {
+	normalized, err := Normalize(input)
+	if err != nil {
+		return "***"
+	}
+	local := normalized[len("+"+MozambiqueCountryCode):]
+	return local[:2] + " *** " + local[len(local)-4:]
+}
+
 // IdentifyOperator returns the mobile network operator name for the given phone number.
 // Returns an empty string if the number is invalid or operator cannot be determined.
 func IdentifyOperator(input string) string {
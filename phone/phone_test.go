@@ -1,7 +1,10 @@
 package phone
 
 import (
+	"strings"
 	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
 )
 
 func TestValidate(t *testing.T) {
@@ -277,3 +280,224 @@ func TestNormalize_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAndNormalizeBatch(t *testing.T) {
+	numbers := []string{
+		"841234567",
+		"+258 82 765 4321",
+		"invalid",
+		"881234567",
+		"258861112222",
+		"+258861112222",
+		"87 555 0000",
+	}
+
+	got, errs := ValidateAndNormalizeBatch(numbers)
+
+	wantMap := map[string]string{
+		"841234567":        "+258841234567",
+		"+258 82 765 4321": "+258827654321",
+		"87 555 0000":      "+258875550000",
+	}
+	if len(got) != len(wantMap) {
+		t.Errorf("len(result) = %d, want %d: %v", len(got), len(wantMap), got)
+	}
+	for input, want := range wantMap {
+		if got[input] != want {
+			t.Errorf("result[%q] = %q, want %q", input, got[input], want)
+		}
+	}
+
+	wantErrs := []struct {
+		field string
+		code  string
+	}{
+		{"invalid", valerrors.CodeInvalidFormat},
+		{"881234567", valerrors.CodeInvalidFormat},
+		{"258861112222", valerrors.CodeDuplicate},
+		{"+258861112222", valerrors.CodeDuplicate},
+	}
+	if len(errs) != len(wantErrs) {
+		t.Fatalf("len(errs) = %d, want %d: %v", len(errs), len(wantErrs), errs)
+	}
+	for i, want := range wantErrs {
+		if errs[i].Field != want.field || errs[i].Code != want.code {
+			t.Errorf("errs[%d] = %s/%s, want %s/%s", i, errs[i].Field, errs[i].Code, want.field, want.code)
+		}
+	}
+	if errs[2].Value != "+258861112222" {
+		t.Errorf("duplicate Value = %v, want normalized number", errs[2].Value)
+	}
+}
+
+func TestValidateAndNormalizeBatch_AllValid(t *testing.T) {
+	got, errs := ValidateAndNormalizeBatch([]string{"841234567", "861234567"})
+	if errs != nil {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if len(got) != 2 {
+		t.Errorf("len(result) = %d, want 2", len(got))
+	}
+}
+
+func TestValidateAndNormalizeBatch_Empty(t *testing.T) {
+	got, errs := ValidateAndNormalizeBatch(nil)
+	if errs != nil {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if got == nil || len(got) != 0 {
+		t.Errorf("result = %v, want empty map", got)
+	}
+}
+
+func TestValidateAndNormalizeBatch_RepeatedInput(t *testing.T) {
+	got, errs := ValidateAndNormalizeBatch([]string{"841234567", "841234567"})
+	if len(got) != 0 {
+		t.Errorf("result = %v, want empty", got)
+	}
+	if len(errs) != 2 || errs.GetByCode(valerrors.CodeDuplicate) == nil {
+		t.Errorf("errs = %v, want two DUPLICATE errors", errs)
+	}
+}
+
+func TestValidateDetailed(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		code      string
+		wantValue string
+		param     string
+		wantParam interface{}
+	}{
+		{"valid", "+258841234567", "", "", "", nil},
+		{"valid local with separators", "84 123-4567", "", "", "", nil},
+		{"empty", "", valerrors.CodeRequired, "", "", nil},
+		{"whitespace", "   ", valerrors.CodeRequired, "", "", nil},
+		{"letters only", "abcdefghi", valerrors.CodeInvalidFormat, "***", "", nil},
+		{"mixed letters", "84abc4567", valerrors.CodeInvalidFormat, "***4567", "", nil},
+		{"too short", "8412345", valerrors.CodeInvalidFormat, "***2345", "length", 7},
+		{"too long", "8412345678901", valerrors.CodeInvalidFormat, "***8901", "length", 13},
+		{"wrong country code", "+254841234567", valerrors.CodeInvalidFormat, "***4567", "country_code", "254"},
+		{"wrong country code with 00", "00254841234567", valerrors.CodeInvalidFormat, "***4567", "country_code", "254"},
+		{"invalid prefix", "881234567", valerrors.CodeInvalidOption, "***4567", "prefix", "88"},
+		{"invalid prefix international", "+258801234567", valerrors.CodeInvalidOption, "***4567", "prefix", "80"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateDetailed(tt.input)
+			if tt.code == "" {
+				if errs != nil {
+					t.Errorf("ValidateDetailed(%q) = %v, want nil", tt.input, errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("ValidateDetailed(%q) = %v, want one error", tt.input, errs)
+			}
+			ve := errs[0]
+			if ve.Field != "phone" || ve.Code != tt.code {
+				t.Errorf("error = %s/%s, want phone/%s", ve.Field, ve.Code, tt.code)
+			}
+			if tt.wantValue != "" && ve.Value != tt.wantValue {
+				t.Errorf("Value = %v, want %q", ve.Value, tt.wantValue)
+			}
+			if tt.param != "" && ve.Param(tt.param) != tt.wantParam {
+				t.Errorf("Params[%q] = %v, want %v", tt.param, ve.Param(tt.param), tt.wantParam)
+			}
+		})
+	}
+}
+
+func TestValidateDetailed_LengthMessage(t *testing.T) {
+	errs := ValidateDetailed("8412345")
+	if len(errs) != 1 {
+		t.Fatalf("ValidateDetailed() = %v, want one error", errs)
+	}
+	for _, want := range []string{"9 digits", "12 with 258", "14 with 00258"} {
+		if !strings.Contains(errs[0].Message, want) {
+			t.Errorf("Message = %q, want it to mention %q", errs[0].Message, want)
+		}
+	}
+}
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      string
+		wantLocal string
+	}{
+		{"prefix 82", "821234567", "+258 82 *** 4567", "82 *** 4567"},
+		{"prefix 83", "+258831112222", "+258 83 *** 2222", "83 *** 2222"},
+		{"prefix 84", "84 123 4567", "+258 84 *** 4567", "84 *** 4567"},
+		{"prefix 85", "00258859876543", "+258 85 *** 6543", "85 *** 6543"},
+		{"prefix 86", "258861234567", "+258 86 *** 4567", "86 *** 4567"},
+		{"prefix 87", "87-123-4567", "+258 87 *** 4567", "87 *** 4567"},
+		{"invalid prefix", "881234567", "***", "***"},
+		{"too short", "8412345", "***", "***"},
+		{"empty", "", "***", "***"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mask(tt.input); got != tt.want {
+				t.Errorf("Mask(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if got := MaskLocal(tt.input); got != tt.wantLocal {
+				t.Errorf("MaskLocal(%q) = %q, want %q", tt.input, got, tt.wantLocal)
+			}
+		})
+	}
+}
+
+func TestNormalizeAny(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		want     string
+		landline bool
+	}{
+		{"maputo", "21123456", "+25821123456", true},
+		{"beira", "23 123 456", "+25823123456", true},
+		{"quelimane", "+258 24 123 456", "+25824123456", true},
+		{"tete", "25-123-456", "+25825123456", true},
+		{"nampula", "25826123456", "+25826123456", true},
+		{"pemba", "0025827123456", "+25827123456", true},
+		{"xai-xai", "28123456", "+25828123456", true},
+		{"inhambane", "29123456", "+25829123456", true},
+		{"mobile", "84 123 4567", "+258841234567", false},
+		{"mobile international", "+258871234567", "+258871234567", false},
+		{"7-digit partial", "2112345", "", false},
+		{"unknown area code", "22123456", "", false},
+		{"invalid mobile prefix", "881234567", "", false},
+		{"empty", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeAny(tt.input)
+			if (err != nil) != (tt.want == "") {
+				t.Fatalf("NormalizeAny(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeAny(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if ValidateAny(tt.input) != (tt.want != "") {
+				t.Errorf("ValidateAny(%q) = %v", tt.input, tt.want == "")
+			}
+			if IsLandline(tt.input) != tt.landline {
+				t.Errorf("IsLandline(%q) = %v, want %v", tt.input, !tt.landline, tt.landline)
+			}
+			if wantMobile := tt.want != "" && !tt.landline; IsMobile(tt.input) != wantMobile {
+				t.Errorf("IsMobile(%q) = %v, want %v", tt.input, !wantMobile, wantMobile)
+			}
+		})
+	}
+}
+
+func TestNormalize_RejectsLandline(t *testing.T) {
+	if Validate("21123456") {
+		t.Error("Validate(21123456) = true, want mobile-only behavior")
+	}
+}
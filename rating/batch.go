@@ -0,0 +1,98 @@
+package rating
+
+import (
+	"runtime"
+	"sync"
+)
+
+// BatchReviewItem is the outcome of processing one review in a batch.
+type BatchReviewItem struct {
+	// Index is the position of the review in the input.
+	Index int
+	// Result is the ReviewResult, filled in as far as processing got even
+	// when Err is set.
+	Result ReviewResult
+	// Err is the validation error, if the review was rejected.
+	Err error
+}
+
+// BatchReviewResult holds the per-review outcomes of ProcessReviews and
+// counts across the batch.
+type BatchReviewResult struct {
+	// Items holds one entry per input review, in input order.
+	Items []BatchReviewItem
+	// Total is the number of reviews processed.
+	Total int
+	// Failed counts reviews rejected with an error.
+	Failed int
+	// Profanity counts reviews in which profanity was found.
+	Profanity int
+	// Spam counts reviews with spam flags or links.
+	Spam int
+	// RequiresReview counts reviews flagged for manual moderation.
+	RequiresReview int
+	// Duplicates lists groups of indices of accepted reviews that share a
+	// ReviewFingerprint or are at least ReviewOptions.DuplicateThreshold
+	// similar, each in ascending order and ordered by first index.
+	Duplicates [][]int
+}
+
+// ProcessReviews runs ProcessReviewWithOptions on every text and aggregates
+// the outcomes. Reviews are processed concurrently by opts.Workers
+// goroutines; Items are always in input order. Counts include rejected
+// reviews, so a review rejected for severe profanity counts toward both
+// Failed and Profanity. Accepted reviews are also grouped into Duplicates.
+func ProcessReviews(texts []string, opts ReviewOptions) BatchReviewResult {
+	items := make([]BatchReviewItem, len(texts))
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(texts))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result, err := ProcessReviewWithOptions(texts[i], opts)
+				items[i] = BatchReviewItem{Index: i, Result: result, Err: err}
+			}
+		}()
+	}
+	for i := range texts {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	batch := BatchReviewResult{Items: items, Total: len(items)}
+	accepted := make([]string, len(items))
+	failed := make([]bool, len(items))
+	for i, item := range items {
+		accepted[i] = item.Result.Text
+		if item.Err != nil {
+			failed[i] = true
+			batch.Failed++
+		}
+		if item.Result.HasProfanity {
+			batch.Profanity++
+		}
+		if len(item.Result.SpamFlags) > 0 || item.Result.ContainsLinks {
+			batch.Spam++
+		}
+		if item.Result.RequiresReview {
+			batch.RequiresReview++
+		}
+	}
+
+	threshold := opts.DuplicateThreshold
+	if threshold <= 0 {
+		threshold = DefaultDuplicateSimilarity
+	}
+	batch.Duplicates = findDuplicates(accepted, failed, threshold)
+	return batch
+}
@@ -0,0 +1,82 @@
+package rating
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestProcessReviews(t *testing.T) {
+	texts := []string{
+		"Motorista excelente, muito pontual",
+		"Que merda de viagem",
+		strings.Repeat("a", MaxReviewLength+1),
+		"Vejam wa.me/258841234567",
+		"asdfasdfasdfasdf",
+		"",
+	}
+
+	for _, workers := range []int{0, 1, 4, 100} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			batch := ProcessReviews(texts, ReviewOptions{Workers: workers})
+
+			if len(batch.Items) != len(texts) {
+				t.Fatalf("len(Items) = %d, want %d", len(batch.Items), len(texts))
+			}
+			for i, item := range batch.Items {
+				want, wantErr := ProcessReview(texts[i])
+				if item.Index != i || item.Result.Text != want.Text || (item.Err == nil) != (wantErr == nil) {
+					t.Errorf("Items[%d] = %+v, want result for input %d", i, item, i)
+				}
+			}
+
+			ve, ok := batch.Items[2].Err.(valerrors.ValidationError)
+			if !ok || ve.Code != valerrors.CodeTooLong {
+				t.Errorf("Items[2].Err = %v, want TOO_LONG", batch.Items[2].Err)
+			}
+
+			// The over-length review fails before the spam check runs.
+			got := [5]int{batch.Total, batch.Failed, batch.Profanity, batch.Spam, batch.RequiresReview}
+			if want := [5]int{6, 1, 1, 2, 3}; got != want {
+				t.Errorf("ProcessReviews() counts (total, failed, profanity, spam, review) = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestProcessReviews_Empty(t *testing.T) {
+	batch := ProcessReviews(nil, ReviewOptions{Workers: 4})
+	if batch.Total != 0 || len(batch.Items) != 0 {
+		t.Errorf("ProcessReviews(nil) = %+v, want empty result", batch)
+	}
+}
+
+// syntheticReviews returns n reviews mixing clean, profane, spammy, and
+// linked text.
+func syntheticReviews(n int) []string {
+	samples := []string{
+		"Motorista muito simpático e carro limpo, recomendo a todos.",
+		"The driver was late and the car smelled bad, not great.",
+		"Que merda, o motorista nem sabia o caminho para o aeroporto.",
+		"PÉSSIMO SERVIÇO, NUNCA MAIS VOU USAR ESTA APLICAÇÃO!!!!",
+		"Usem antes a outra app: https://promo.example.com/boleia",
+	}
+	texts := make([]string, n)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("%s #%d", samples[i%len(samples)], i)
+	}
+	return texts
+}
+
+func BenchmarkProcessReviews(b *testing.B) {
+	texts := syntheticReviews(10000)
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for b.Loop() {
+				ProcessReviews(texts, ReviewOptions{Workers: workers})
+			}
+		})
+	}
+}
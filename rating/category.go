@@ -0,0 +1,111 @@
+package rating
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// Rating categories riders can score in addition to the overall rating.
+const (
+	CategoryCleanliness      = "cleanliness"
+	CategoryDriving          = "driving"
+	CategoryFriendliness     = "friendliness"
+	CategoryVehicleCondition = "vehicle_condition"
+)
+
+// DefaultRatingCategories lists the categories accepted when no allowed list
+// is given.
+var DefaultRatingCategories = []string{
+	CategoryCleanliness, CategoryDriving, CategoryFriendliness, CategoryVehicleCondition,
+}
+
+// CategoryRatingOptions configures ValidateCategoryRatingsWithOptions.
+type CategoryRatingOptions struct {
+	// Allowed lists the accepted categories; nil means DefaultRatingCategories.
+	Allowed []string
+	// Required lists categories that must be present.
+	Required []string
+}
+
+// ValidateCategoryRatings validates a map of category scores. Every category
+// must be in allowed (DefaultRatingCategories if nil) and every score must
+// pass ValidateRating. Missing categories are fine. Errors use field paths
+// such as "ratings[cleanliness]" and are ordered by category.
+// Returns nil if every entry is valid.
+func ValidateCategoryRatings(ratings map[string]int, allowed []string) valerrors.ValidationErrors {
+	return ValidateCategoryRatingsWithOptions(ratings, CategoryRatingOptions{Allowed: allowed})
+}
+
+// ValidateCategoryRatingsWithOptions is ValidateCategoryRatings with a list
+// of required categories. Missing required categories are reported as
+// REQUIRED.
+func ValidateCategoryRatingsWithOptions(ratings map[string]int, opts CategoryRatingOptions) valerrors.ValidationErrors {
+	allowed := opts.Allowed
+	if allowed == nil {
+		allowed = DefaultRatingCategories
+	}
+
+	categories := make([]string, 0, len(ratings))
+	for category := range ratings {
+		categories = append(categories, category)
+	}
+	for _, category := range opts.Required {
+		if _, ok := ratings[category]; !ok {
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+
+	var errs valerrors.ValidationErrors
+	for _, category := range categories {
+		field := categoryField(category)
+		score, ok := ratings[category]
+		switch {
+		case !ok:
+			errs.Add(valerrors.Required(field))
+		case !slices.Contains(allowed, category):
+			errs.Add(valerrors.InvalidOptionWithValue(field, allowed, category))
+		default:
+			if ve, isVE := ValidateRatingField(field, score).(valerrors.ValidationError); isVE {
+				errs.Add(ve)
+			}
+		}
+	}
+	return errs
+}
+
+// ValidateOverallConsistency flags an overall rating that differs from the
+// mean of the category scores by more than tolerance stars. The error is
+// OUT_OF_RANGE on "rating" with SeverityWarning, since the rider may simply
+// weigh categories differently. Returns nil when there are no categories.
+// A negative tolerance is reported as INVALID_FORMAT on "tolerance".
+func ValidateOverallConsistency(overall int, categories map[string]int, tolerance int) error {
+	if tolerance < 0 {
+		return valerrors.InvalidFormatWithValue("tolerance", "non-negative number of stars", tolerance)
+	}
+	if len(categories) == 0 {
+		return nil
+	}
+
+	var sum int
+	for _, score := range categories {
+		sum += score
+	}
+	mean := float64(sum) / float64(len(categories))
+
+	if math.Abs(float64(overall)-mean) > float64(tolerance) {
+		ve := valerrors.OutOfRangeWithValue("rating", mean-float64(tolerance), mean+float64(tolerance), overall)
+		ve.Message = fmt.Sprintf("overall rating differs from the category average of %.1f", mean)
+		return ve.WithParam("category_mean", mean).WithSeverity(valerrors.SeverityWarning)
+	}
+	return nil
+}
+
+// categoryField returns the field path for a category score.
+func categoryField(category string) string {
+	return "ratings[" + category + "]"
+}
@@ -0,0 +1,99 @@
+package rating
+
+import (
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateCategoryRatings(t *testing.T) {
+	tests := []struct {
+		name      string
+		ratings   map[string]int
+		allowed   []string
+		wantField []string
+		wantCode  []string
+	}{
+		{"all valid", map[string]int{CategoryCleanliness: 5, CategoryDriving: 4, CategoryFriendliness: 3}, nil, nil, nil},
+		{"empty", map[string]int{}, nil, nil, nil},
+		{"nil", nil, nil, nil, nil},
+		{"unknown key", map[string]int{"music": 4}, nil,
+			[]string{"ratings[music]"}, []string{valerrors.CodeInvalidOption}},
+		{"out of range", map[string]int{CategoryDriving: 0, CategoryCleanliness: 6}, nil,
+			[]string{"ratings[cleanliness]", "ratings[driving]"},
+			[]string{valerrors.CodeOutOfRange, valerrors.CodeOutOfRange}},
+		{"custom allowed list", map[string]int{"punctuality": 4, CategoryDriving: 5}, []string{"punctuality"},
+			[]string{"ratings[driving]"}, []string{valerrors.CodeInvalidOption}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateCategoryRatings(tt.ratings, tt.allowed)
+			if len(errs) != len(tt.wantField) {
+				t.Fatalf("ValidateCategoryRatings() = %v, want fields %v", errs, tt.wantField)
+			}
+			for i, e := range errs {
+				if e.Field != tt.wantField[i] || e.Code != tt.wantCode[i] {
+					t.Errorf("errs[%d] = %s/%s, want %s/%s", i, e.Field, e.Code, tt.wantField[i], tt.wantCode[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCategoryRatingsWithOptions(t *testing.T) {
+	opts := CategoryRatingOptions{Required: []string{CategoryDriving, CategoryCleanliness}}
+
+	errs := ValidateCategoryRatingsWithOptions(map[string]int{CategoryDriving: 4}, opts)
+	if len(errs) != 1 || errs[0].Field != "ratings[cleanliness]" || errs[0].Code != valerrors.CodeRequired {
+		t.Errorf("ValidateCategoryRatingsWithOptions() = %v, want ratings[cleanliness]/REQUIRED", errs)
+	}
+
+	complete := map[string]int{CategoryDriving: 4, CategoryCleanliness: 5}
+	if errs := ValidateCategoryRatingsWithOptions(complete, opts); errs != nil {
+		t.Errorf("ValidateCategoryRatingsWithOptions() = %v, want nil", errs)
+	}
+}
+
+func TestValidateOverallConsistency(t *testing.T) {
+	categories := map[string]int{CategoryCleanliness: 4, CategoryDriving: 5, CategoryFriendliness: 3} // mean 4
+
+	tests := []struct {
+		name       string
+		overall    int
+		categories map[string]int
+		tolerance  int
+		wantErr    bool
+	}{
+		{"equal to mean", 4, categories, 1, false},
+		{"within tolerance", 5, categories, 1, false},
+		{"on the boundary", 3, categories, 1, false},
+		{"outside tolerance", 1, categories, 1, true},
+		{"zero tolerance", 5, categories, 0, true},
+		{"no categories", 1, nil, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOverallConsistency(tt.overall, tt.categories, tt.tolerance)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("ValidateOverallConsistency() error = %v, want nil", err)
+				}
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Field != "rating" || ve.Code != valerrors.CodeOutOfRange || !ve.IsWarning() {
+				t.Fatalf("ValidateOverallConsistency() error = %v, want rating/OUT_OF_RANGE warning", err)
+			}
+			if ve.Param("category_mean") != 4.0 {
+				t.Errorf("Params[category_mean] = %v, want 4", ve.Param("category_mean"))
+			}
+		})
+	}
+
+	err := ValidateOverallConsistency(4, categories, -1)
+	if ve, ok := err.(valerrors.ValidationError); !ok || ve.Field != "tolerance" {
+		t.Errorf("ValidateOverallConsistency() with negative tolerance = %v, want tolerance error", err)
+	}
+}
@@ -0,0 +1,207 @@
+package rating
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/Dorico-Dynamics/txova-go-validation/sanitize"
+)
+
+// Near-duplicate detection defaults.
+const (
+	// DefaultDuplicateSimilarity is the SimilarityRatio at or above which
+	// ProcessReviews groups two reviews as duplicates when
+	// ReviewOptions.DuplicateThreshold is zero.
+	DefaultDuplicateSimilarity = 0.7
+	// MinSimilarityWords is the fewest words both reviews need before
+	// ProcessReviews compares them by similarity. Shorter reviews such as
+	// "Muito bom" are only grouped when their fingerprints match.
+	MinSimilarityWords = 5
+)
+
+// normalizeDuplicateText reduces text to the form used for duplicate
+// detection: lookalike characters folded, accents stripped, lowercase,
+// letters and digits only, and repeated characters collapsed, so "Óóótimo!!"
+// becomes "otimo".
+func normalizeDuplicateText(text string) string {
+	var b strings.Builder
+	var last rune
+	for _, r := range norm.NFD.String(sanitize.FoldConfusables(text)) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			continue
+		}
+		r = unicode.ToLower(r)
+		if r != last {
+			b.WriteRune(r)
+			last = r
+		}
+	}
+	return b.String()
+}
+
+// duplicateWords splits text into normalized words for duplicate detection,
+// dropping words that normalize to nothing, such as emoji.
+func duplicateWords(text string) []string {
+	var words []string
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	}) {
+		if w := normalizeDuplicateText(field); w != "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// ReviewFingerprint returns a stable hash of a review for finding copies of
+// it. The text is normalized first: lookalike characters are folded, accents,
+// punctuation, whitespace, and emoji are removed, letters are lowercased, and
+// repeated characters are collapsed. Reviews that differ only in those
+// respects share a fingerprint. Returns "" for text with no letters or
+// digits. Nothing is stored; callers persist fingerprints to compare against
+// earlier reviews.
+func ReviewFingerprint(text string) string {
+	normalized := normalizeDuplicateText(text)
+	if normalized == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// SimilarityRatio returns the Jaccard similarity of the sets of normalized
+// words in a and b: the number of distinct words they share divided by the
+// number of distinct words in either, from 0 (nothing in common) to 1 (same
+// words). Word order and repetition are ignored. Two texts without words are
+// identical.
+func SimilarityRatio(a, b string) float64 {
+	return jaccard(wordSet(duplicateWords(a)), wordSet(duplicateWords(b)))
+}
+
+// wordSet returns the distinct words.
+func wordSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// jaccard returns the Jaccard similarity of two sets.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// findDuplicates groups the texts that share a fingerprint or whose
+// SimilarityRatio is at least threshold. Texts with skip set are ignored.
+// Groups list indices in ascending order and are ordered by their first
+// index.
+//
+// Similar pairs are found with prefix filtering: with every word set ordered
+// from rarest to most common word, two sets with Jaccard similarity of at
+// least threshold must share a word among the first len-ceil(threshold*len)+1
+// words of each, so only texts sharing such a word are compared.
+func findDuplicates(texts []string, skip []bool, threshold float64) [][]int {
+	parent := make([]int, len(texts))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(i, j int) {
+		if ri, rj := find(i), find(j); ri != rj {
+			parent[max(ri, rj)] = min(ri, rj)
+		}
+	}
+
+	sets := make([]map[string]bool, len(texts))
+	frequency := make(map[string]int)
+	byFingerprint := make(map[string]int)
+	for i, text := range texts {
+		if skip[i] {
+			continue
+		}
+		if fp := ReviewFingerprint(text); fp != "" {
+			if first, ok := byFingerprint[fp]; ok {
+				union(first, i)
+			} else {
+				byFingerprint[fp] = i
+			}
+		}
+		if words := wordSet(duplicateWords(text)); len(words) >= MinSimilarityWords {
+			sets[i] = words
+			for w := range words {
+				frequency[w]++
+			}
+		}
+	}
+
+	if threshold <= 1 {
+		index := make(map[string][]int)
+		for i, set := range sets {
+			if set == nil {
+				continue
+			}
+			ordered := make([]string, 0, len(set))
+			for w := range set {
+				ordered = append(ordered, w)
+			}
+			sort.Slice(ordered, func(a, b int) bool {
+				if frequency[ordered[a]] != frequency[ordered[b]] {
+					return frequency[ordered[a]] < frequency[ordered[b]]
+				}
+				return ordered[a] < ordered[b]
+			})
+			// The tolerance keeps e.g. 0.7*10 from rounding up to 8 shared words.
+			prefix := len(ordered) - int(math.Ceil(threshold*float64(len(ordered))-1e-9)) + 1
+
+			compared := make(map[int]bool)
+			for _, w := range ordered[:min(prefix, len(ordered))] {
+				for _, j := range index[w] {
+					if !compared[j] && find(j) != find(i) {
+						compared[j] = true
+						if jaccard(set, sets[j]) >= threshold {
+							union(j, i)
+						}
+					}
+				}
+				index[w] = append(index[w], i)
+			}
+		}
+	}
+
+	groups := make(map[int][]int)
+	for i := range texts {
+		if !skip[i] {
+			root := find(i)
+			groups[root] = append(groups[root], i)
+		}
+	}
+	var duplicates [][]int
+	for i := range texts {
+		if group := groups[i]; len(group) > 1 {
+			duplicates = append(duplicates, group)
+		}
+	}
+	return duplicates
+}
@@ -0,0 +1,115 @@
+package rating
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReviewFingerprint(t *testing.T) {
+	base := ReviewFingerprint("Óptimo motorista, muito simpático!")
+	if len(base) != 64 {
+		t.Fatalf("ReviewFingerprint() = %q, want a hex SHA-256", base)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		same bool
+	}{
+		{"identical", "Óptimo motorista, muito simpático!", true},
+		{"emoji and punctuation", "👍 Óptimo motorista... muito simpático 😀😀", true},
+		{"case and accents", "OPTIMO MOTORISTA MUITO SIMPATICO", true},
+		{"repeated letters and spacing", "Óóóptimo   motorista, muuuito simpático!!!", true},
+		{"cyrillic lookalike", "Óptimo motorista, muito simpáticо", true},
+		{"different word", "Óptimo motorista, muito pontual!", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReviewFingerprint(tt.text) == base; got != tt.same {
+				t.Errorf("ReviewFingerprint(%q) == base is %v, want %v", tt.text, got, tt.same)
+			}
+		})
+	}
+
+	if got := ReviewFingerprint("👍👍 !!"); got != "" {
+		t.Errorf("ReviewFingerprint() without letters = %q, want empty", got)
+	}
+}
+
+func TestSimilarityRatio(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		wantMin float64
+		wantMax float64
+	}{
+		{"identical", "Motorista muito bom", "motorista, muito bom!", 1, 1},
+		{"reordered", "muito bom motorista", "motorista muito bom", 1, 1},
+		{
+			"two words changed",
+			"Excelente motorista, carro limpo e confortável, chegou a horas e conduziu com muito cuidado pela cidade toda",
+			"Excelente motorista, carro novo e confortável, chegou cedo e conduziu com muito cuidado pela cidade toda",
+			DefaultDuplicateSimilarity, 0.8,
+		},
+		{
+			"different reviews",
+			"Excelente motorista, carro limpo e confortável, chegou a horas",
+			"O motorista enganou-se no caminho e cobrou demais pela viagem",
+			0, 0.2,
+		},
+		{"both empty", "", "👍", 1, 1},
+		{"one empty", "bom", "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SimilarityRatio(tt.a, tt.b)
+			if got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("SimilarityRatio() = %v, want between %v and %v", got, tt.wantMin, tt.wantMax)
+			}
+			if back := SimilarityRatio(tt.b, tt.a); back != got {
+				t.Errorf("SimilarityRatio() is not symmetric: %v vs %v", got, back)
+			}
+		})
+	}
+}
+
+func TestProcessReviews_Duplicates(t *testing.T) {
+	texts := []string{
+		"Excelente motorista, carro limpo e confortável, chegou a horas e conduziu com muito cuidado pela cidade toda",
+		"O motorista enganou-se no caminho e cobrou demais pela viagem",
+		"Motorista muito bom!!! 👍",
+		"Excelente motorista, carro novo e confortável, chegou cedo e conduziu com muito cuidado pela cidade toda",
+		"motorista MUITO bom",
+		"Muito bom",
+		"Excelente motorista, carro limpo e confortável, chegou a horas e conduziu com muito cuidado pela cidade toda 😀",
+	}
+
+	batch := ProcessReviews(texts, ReviewOptions{Workers: 2})
+	want := [][]int{{0, 3, 6}, {2, 4}}
+	if !reflect.DeepEqual(batch.Duplicates, want) {
+		t.Errorf("Duplicates = %v, want %v", batch.Duplicates, want)
+	}
+
+	// A threshold above 1 keeps only identical fingerprints.
+	batch = ProcessReviews(texts, ReviewOptions{DuplicateThreshold: 1.1})
+	if want := [][]int{{0, 6}, {2, 4}}; !reflect.DeepEqual(batch.Duplicates, want) {
+		t.Errorf("Duplicates with fingerprints only = %v, want %v", batch.Duplicates, want)
+	}
+
+	// A stricter threshold separates the near-duplicate.
+	batch = ProcessReviews(texts, ReviewOptions{DuplicateThreshold: 0.9})
+	if want := [][]int{{0, 6}, {2, 4}}; !reflect.DeepEqual(batch.Duplicates, want) {
+		t.Errorf("Duplicates at 0.9 = %v, want %v", batch.Duplicates, want)
+	}
+}
+
+func TestProcessReviews_DuplicatesSkipFailed(t *testing.T) {
+	long := strings.Repeat("Excelente motorista, muito bom. ", MaxReviewLength/10)
+	batch := ProcessReviews([]string{long, long, "Bom"}, ReviewOptions{})
+	if batch.Failed != 2 || batch.Duplicates != nil {
+		t.Errorf("ProcessReviews() Failed = %d, Duplicates = %v; want failed reviews left out", batch.Failed, batch.Duplicates)
+	}
+}
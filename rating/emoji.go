@@ -0,0 +1,104 @@
+package rating
+
+import (
+	"unicode"
+)
+
+// Code points that combine with a preceding emoji rather than standing alone.
+const (
+	zeroWidthJoiner   = '\u200D'
+	variationText     = '\uFE0E'
+	variationEmoji    = '\uFE0F'
+	combiningKeycap   = '\u20E3'
+	skinToneFirst     = '\U0001F3FB'
+	skinToneLast      = '\U0001F3FF'
+	regionalFirst     = '\U0001F1E6'
+	regionalLast      = '\U0001F1FF'
+	emojiTagFirst     = '\U000E0020'
+	emojiTagLast      = '\U000E007F'
+	pictographicFirst = '\U0001F000'
+	pictographicLast  = '\U0001FAFF'
+)
+
+// EmojiStats describes the emoji in a text, as returned by AnalyzeEmoji.
+type EmojiStats struct {
+	// Count is the number of perceived emoji. A ZWJ sequence such as 👨‍👩‍👧,
+	// an emoji with a skin-tone modifier, or a flag counts once.
+	Count int
+	// Ratio is the share of non-whitespace runes that belong to emoji,
+	// including joiners and modifiers.
+	Ratio float64
+	// EmojiOnly is true when the text has emoji and nothing else but whitespace.
+	EmojiOnly bool
+}
+
+// AnalyzeEmoji counts the emoji in a text.
+func AnalyzeEmoji(text string) EmojiStats {
+	var (
+		stats              EmojiStats
+		emojiRunes, total  int
+		inEmoji, joining   bool
+		pendingRegionalRun bool
+	)
+
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			inEmoji, joining, pendingRegionalRun = false, false, false
+			continue
+		}
+		total++
+
+		switch {
+		case inEmoji && isEmojiModifier(r):
+			emojiRunes++
+			joining = r == zeroWidthJoiner
+		case r >= regionalFirst && r <= regionalLast:
+			// Flags are pairs of regional indicators.
+			emojiRunes++
+			if !pendingRegionalRun {
+				stats.Count++
+			}
+			pendingRegionalRun = !pendingRegionalRun
+			inEmoji, joining = true, false
+		case isEmojiBase(r):
+			emojiRunes++
+			if !joining {
+				stats.Count++
+			}
+			inEmoji, joining, pendingRegionalRun = true, false, false
+		default:
+			inEmoji, joining, pendingRegionalRun = false, false, false
+		}
+	}
+
+	if total > 0 {
+		stats.Ratio = float64(emojiRunes) / float64(total)
+	}
+	stats.EmojiOnly = stats.Count > 0 && emojiRunes == total
+	return stats
+}
+
+// isEmojiBase reports whether r is a pictographic emoji code point.
+func isEmojiBase(r rune) bool {
+	switch {
+	case r >= pictographicFirst && r <= pictographicLast && !(r >= skinToneFirst && r <= skinToneLast):
+		return true
+	case r >= '\u2600' && r <= '\u27BF': // miscellaneous symbols and dingbats
+		return true
+	case r >= '\u2300' && r <= '\u23FF': // miscellaneous technical, e.g. ⌚ and ⏰
+		return true
+	case r >= '\u2B00' && r <= '\u2BFF': // arrows and stars, e.g. ⭐
+		return true
+	case r == '\u203C' || r == '\u2049': // ‼ and ⁉
+		return true
+	default:
+		return false
+	}
+}
+
+// isEmojiModifier reports whether r attaches to the preceding emoji: a
+// joiner, variation selector, skin tone, keycap, or tag.
+func isEmojiModifier(r rune) bool {
+	return r == zeroWidthJoiner || r == variationText || r == variationEmoji || r == combiningKeycap ||
+		(r >= skinToneFirst && r <= skinToneLast) || (r >= emojiTagFirst && r <= emojiTagLast)
+}
@@ -0,0 +1,75 @@
+package rating
+
+import (
+	"math"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestAnalyzeEmoji(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		wantCount     int
+		wantRatio     float64
+		wantEmojiOnly bool
+	}{
+		// Pure emoji
+		{"single", "👍", 1, 1, true},
+		{"repeated", "👍👍👍", 3, 1, true},
+		{"with spaces", " 👍 ❤️  🚗 ", 3, 1, true},
+		{"skin tone", "👍🏽", 1, 1, true},
+		{"zwj family", "👨‍👩‍👧", 1, 1, true},
+		{"zwj with skin tones", "🧑🏽‍🤝‍🧑🏿", 1, 1, true},
+		{"flag", "🇲🇿", 1, 1, true},
+		{"two flags", "🇲🇿🇿🇦", 2, 1, true},
+		{"variation selector", "❤️", 1, 1, true},
+		{"star", "⭐⭐⭐⭐⭐", 5, 1, true},
+
+		// Mixed
+		{"mostly text", "Great driver 👍", 1, 1.0 / 12, false},
+		{"half and half", "ok 👍👍", 2, 0.5, false},
+		{"mostly emoji", "ok👍👍👍👍👍👍", 6, 0.75, false},
+
+		// No emoji
+		{"plain text", "Great driver!", 0, 0, false},
+		{"portuguese", "Ótimo serviço", 0, 0, false},
+		{"empty", "", 0, 0, false},
+		{"whitespace", "   ", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AnalyzeEmoji(tt.text)
+			if got.Count != tt.wantCount || math.Abs(got.Ratio-tt.wantRatio) > 1e-9 || got.EmojiOnly != tt.wantEmojiOnly {
+				t.Errorf("AnalyzeEmoji(%q) = %+v, want {Count:%d Ratio:%v EmojiOnly:%v}",
+					tt.text, got, tt.wantCount, tt.wantRatio, tt.wantEmojiOnly)
+			}
+		})
+	}
+}
+
+func TestProcessReview_EmojiOnly(t *testing.T) {
+	result, err := ProcessReview("👍👍👍")
+	if err != nil {
+		t.Fatalf("ProcessReview() error = %v", err)
+	}
+	if !result.EmojiOnly {
+		t.Error("EmojiOnly = false, want true")
+	}
+
+	result, _ = ProcessReview("Great driver 👍")
+	if result.EmojiOnly {
+		t.Error("EmojiOnly = true for mixed text")
+	}
+
+	_, err = ProcessReviewWithOptions("👍 👍", ReviewOptions{RejectEmojiOnly: true})
+	ve, ok := err.(valerrors.ValidationError)
+	if !ok || ve.Field != "review" || ve.Code != valerrors.CodeInvalidFormat {
+		t.Errorf("ProcessReviewWithOptions() error = %v, want review/INVALID_FORMAT", err)
+	}
+	if _, err := ProcessReviewWithOptions("Great 👍", ReviewOptions{RejectEmojiOnly: true}); err != nil {
+		t.Errorf("ProcessReviewWithOptions() mixed text error = %v", err)
+	}
+}
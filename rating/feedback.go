@@ -0,0 +1,50 @@
+package rating
+
+import (
+	"strconv"
+	"strings"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// Feedback tip constraints.
+const (
+	// MaxFeedbackTips is the most tips a single feedback may carry.
+	MaxFeedbackTips = 10
+	// MaxTipLength is the longest accepted tip in characters.
+	MaxTipLength = 50
+)
+
+// Feedback is a rider's feedback on a completed ride.
+type Feedback struct {
+	DriverRating  int
+	VehicleRating int
+	Review        string
+	// Tips are short free-text compliments or suggestions, e.g. "Carro limpo".
+	Tips []string
+}
+
+// ValidateFeedback validates all fields of a Feedback in one call. Errors use
+// the fields "driver_rating", "vehicle_rating", "review", "tips", and paths
+// such as "tips[2]" for individual tips, which must be non-blank and at most
+// MaxTipLength characters. Returns nil if the feedback is valid.
+func ValidateFeedback(f Feedback) valerrors.ValidationErrors {
+	var errs valerrors.ValidationErrors
+	add := func(err error) {
+		if ve, ok := err.(valerrors.ValidationError); ok {
+			errs.Add(ve)
+		}
+	}
+
+	add(ValidateRatingField("driver_rating", f.DriverRating))
+	add(ValidateRatingField("vehicle_rating", f.VehicleRating))
+	add(ValidateReviewTextField("review", f.Review))
+
+	if len(f.Tips) > MaxFeedbackTips {
+		add(valerrors.OutOfRangeWithValue("tips", 0, MaxFeedbackTips, len(f.Tips)))
+	}
+	for i, tip := range f.Tips {
+		add(validateTextLength("tips["+strconv.Itoa(i)+"]", strings.TrimSpace(tip), 1, MaxTipLength))
+	}
+	return errs
+}
@@ -0,0 +1,79 @@
+package rating
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateRatingField(t *testing.T) {
+	err := ValidateRatingField("driver_rating", 6)
+	ve, ok := err.(valerrors.ValidationError)
+	if !ok || ve.Field != "driver_rating" || ve.Code != valerrors.CodeOutOfRange {
+		t.Errorf("ValidateRatingField() error = %v, want driver_rating/OUT_OF_RANGE", err)
+	}
+	if err := ValidateRatingField("driver_rating", 4); err != nil {
+		t.Errorf("ValidateRatingField() error = %v, want nil", err)
+	}
+
+	ve, _ = ValidateRating(0).(valerrors.ValidationError)
+	if ve.Field != "rating" {
+		t.Errorf("ValidateRating() field = %q, want rating", ve.Field)
+	}
+}
+
+func TestValidateReviewTextField(t *testing.T) {
+	err := ValidateReviewTextField("comment", strings.Repeat("a", MaxReviewLength+1))
+	ve, ok := err.(valerrors.ValidationError)
+	if !ok || ve.Field != "comment" || ve.Code != valerrors.CodeTooLong {
+		t.Errorf("ValidateReviewTextField() error = %v, want comment/TOO_LONG", err)
+	}
+
+	ve, _ = ValidateReviewText(strings.Repeat("a", MaxReviewLength+1)).(valerrors.ValidationError)
+	if ve.Field != "review" {
+		t.Errorf("ValidateReviewText() field = %q, want review", ve.Field)
+	}
+}
+
+func TestValidateFeedback(t *testing.T) {
+	tests := []struct {
+		name       string
+		feedback   Feedback
+		wantFields []string
+	}{
+		{"valid", Feedback{5, 4, "Óptimo", []string{"Carro limpo", "Pontual"}}, nil},
+		{"valid without review or tips", Feedback{DriverRating: 3, VehicleRating: 3}, nil},
+		{"both ratings invalid", Feedback{0, 6, "", nil}, []string{"driver_rating", "vehicle_rating"}},
+		{"vehicle rating only", Feedback{5, 9, "", nil}, []string{"vehicle_rating"}},
+		{"long review", Feedback{5, 5, strings.Repeat("a", MaxReviewLength+1), nil}, []string{"review"}},
+		{"bad tips", Feedback{5, 5, "", []string{"ok", "  ", strings.Repeat("a", MaxTipLength+1)}},
+			[]string{"tips[1]", "tips[2]"}},
+		{"too many tips", Feedback{5, 5, "", strings.Split(strings.Repeat("ok,", MaxFeedbackTips), ",")},
+			[]string{"tips", "tips[10]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateFeedback(tt.feedback)
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Field)
+			}
+			if !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("ValidateFeedback() fields = %v, want %v (errors: %v)", got, tt.wantFields, errs)
+			}
+		})
+	}
+}
+
+func TestValidateFeedback_DistinctRatingFields(t *testing.T) {
+	errs := ValidateFeedback(Feedback{DriverRating: 0, VehicleRating: 7})
+	if !errs.HasField("driver_rating") || !errs.HasField("vehicle_rating") || errs.HasField("rating") {
+		t.Fatalf("ValidateFeedback() = %v, want separate driver_rating and vehicle_rating errors", errs)
+	}
+	if v := errs.GetByField("vehicle_rating")[0].Value; v != 7 {
+		t.Errorf("vehicle_rating Value = %v, want 7", v)
+	}
+}
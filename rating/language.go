@@ -0,0 +1,80 @@
+package rating
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Languages reported by DetectLanguage.
+const (
+	LanguagePortuguese = "pt"
+	LanguageEnglish    = "en"
+	LanguageUnknown    = "unknown"
+)
+
+// MinLanguageDetectionWords is the fewest words DetectLanguage needs before
+// it guesses a language; shorter texts are LanguageUnknown.
+const MinLanguageDetectionWords = 4
+
+// portugueseStopwords are common Portuguese words that are not also common in
+// English. Ambiguous words such as "a", "as", "do", and "no" are left out.
+var portugueseStopwords = map[string]bool{
+	"o": true, "os": true, "de": true, "da": true, "das": true, "dos": true, "e": true, "é": true,
+	"em": true, "na": true, "nas": true, "nos": true, "um": true, "uma": true, "que": true,
+	"não": true, "com": true, "para": true, "por": true, "pelo": true, "pela": true, "muito": true,
+	"muita": true, "mas": true, "foi": true, "mais": true, "ele": true, "ela": true, "eu": true,
+	"meu": true, "minha": true, "seu": true, "sua": true, "ao": true, "à": true, "já": true,
+	"também": true, "só": true, "está": true, "estava": true, "como": true, "bem": true,
+	"isso": true, "este": true, "esta": true, "mim": true, "comigo": true, "sem": true,
+	"obrigado": true, "obrigada": true, "bom": true, "boa": true, "carro": true, "motorista": true,
+	"viagem": true, "serviço": true,
+}
+
+// englishStopwords are common English words that are not also common in
+// Portuguese.
+var englishStopwords = map[string]bool{
+	"the": true, "and": true, "is": true, "was": true, "were": true, "are": true, "be": true,
+	"to": true, "of": true, "in": true, "it": true, "on": true, "at": true, "with": true,
+	"for": true, "not": true, "but": true, "this": true, "that": true, "my": true, "me": true,
+	"i": true, "he": true, "she": true, "we": true, "you": true, "they": true, "his": true,
+	"her": true, "have": true, "had": true, "did": true, "would": true, "will": true, "very": true,
+	"so": true, "too": true, "again": true, "thank": true, "thanks": true, "driver": true,
+	"car": true, "ride": true, "trip": true, "good": true, "great": true,
+}
+
+// portugueseLetters are letters that occur in Portuguese but not English.
+const portugueseLetters = "ãõçáéíóúâêôà"
+
+// DetectLanguage guesses whether a review is in Portuguese or English from
+// common function words and Portuguese-only letters. It returns
+// LanguagePortuguese, LanguageEnglish, or LanguageUnknown when the text has
+// fewer than MinLanguageDetectionWords words or the evidence is balanced.
+func DetectLanguage(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if len(words) < MinLanguageDetectionWords {
+		return LanguageUnknown
+	}
+
+	var pt, en int
+	for _, w := range words {
+		switch {
+		case portugueseStopwords[w]:
+			pt++
+		case englishStopwords[w]:
+			en++
+		case strings.ContainsAny(w, portugueseLetters):
+			pt++
+		}
+	}
+
+	switch {
+	case pt > en:
+		return LanguagePortuguese
+	case en > pt:
+		return LanguageEnglish
+	default:
+		return LanguageUnknown
+	}
+}
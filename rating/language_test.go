@@ -0,0 +1,93 @@
+package rating
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"portuguese", "O motorista foi muito simpático", LanguagePortuguese},
+		{"portuguese without accents", "Gostei muito do carro e do motorista", LanguagePortuguese},
+		{"english", "The driver was very friendly", LanguageEnglish},
+		{"english with place names", "Great ride from Maputo to Matola", LanguageEnglish},
+		{"too short portuguese", "Muito bom serviço", LanguageUnknown},
+		{"too short english", "Great driver, thanks", LanguageUnknown},
+		{"no stopwords", "Xai-Xai Inhambane Vilankulo Pemba", LanguageUnknown},
+		{"empty", "", LanguageUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLanguage(tt.text); got != tt.want {
+				t.Errorf("DetectLanguage(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectLanguage_Accuracy(t *testing.T) {
+	corpora := []struct {
+		file string
+		lang string
+	}{
+		{"testdata/reviews_pt.txt", LanguagePortuguese},
+		{"testdata/reviews_en.txt", LanguageEnglish},
+	}
+
+	for _, c := range corpora {
+		t.Run(c.lang, func(t *testing.T) {
+			reviews := readCorpus(t, c.file)
+			correct := 0
+			for _, review := range reviews {
+				if got := DetectLanguage(review); got == c.lang {
+					correct++
+				} else {
+					t.Logf("DetectLanguage(%q) = %q", review, got)
+				}
+			}
+			if accuracy := float64(correct) / float64(len(reviews)); accuracy < 0.9 {
+				t.Errorf("accuracy = %.0f%% (%d/%d), want at least 90%%", accuracy*100, correct, len(reviews))
+			}
+		})
+	}
+}
+
+func TestProcessReview_Language(t *testing.T) {
+	result, err := ProcessReview("O motorista foi muito simpático e pontual")
+	if err != nil {
+		t.Fatalf("ProcessReview() error = %v", err)
+	}
+	if result.Language != LanguagePortuguese {
+		t.Errorf("Language = %q, want %q", result.Language, LanguagePortuguese)
+	}
+}
+
+// readCorpus returns the non-empty, non-comment lines of a testdata file.
+func readCorpus(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return lines
+}
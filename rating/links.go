@@ -0,0 +1,82 @@
+package rating
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LinkPlaceholder replaces each link removed by RedactLinks.
+const LinkPlaceholder = "[link]"
+
+// MinPromotionAmounts is the number of currency amounts that makes a review
+// look promotional on its own, e.g. "antes 500 MT, agora 300 MT".
+const MinPromotionAmounts = 2
+
+// linkPattern matches the link forms reported by DetectLinks. Alternatives are
+// tried left to right at each position, so a URL is reported whole rather
+// than as the bare domain inside it.
+var linkPattern = regexp.MustCompile(`(?i)` +
+	// http(s) URLs.
+	`\bhttps?://[^\s<>"']+` +
+	// WhatsApp chat links.
+	`|\b(?:wa\.me|chat\.whatsapp\.com)/[^\s<>"']*` +
+	// A call to action followed by a Mozambican mobile number, e.g. "ligue 84 123 4567".
+	`|\b(?:ligue|liga|ligar|ligue-me|contacte|contacto|whatsapp|zap|sms|call|text)\b[\s:]*(?:\+?258[\s-]?)?8[2-7](?:[\s-]?\d){7}\b` +
+	// Bare domains with a common top-level domain.
+	`|\b(?:www\.)?(?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+` +
+	`(?:com|net|org|info|biz|io|co|me|app|link|ly|online|site|shop|store|xyz|mz|pt|br|za)\b(?:/[^\s<>"']*)?`)
+
+// linkTrailingPunctuation is stripped from the end of detected links, since it
+// usually ends the sentence rather than the link.
+const linkTrailingPunctuation = ".,;:!?)]}'\""
+
+// promotionPattern matches promotional keywords in Portuguese and English.
+var promotionPattern = regexp.MustCompile(`(?i)\b(?:` +
+	`descontos?|promoção|promocao|promoções|promocoes|código promocional|codigo promocional|` +
+	`cupão|cupao|cupom|use o código|use o codigo|` +
+	`promo ?codes?|discounts?|coupons?|use (?:the )?code` +
+	`)\b|\d+\s?%\s?(?:off|desconto)\b`)
+
+// currencyAmountPattern matches amounts in meticais, dollars, euros, or rand,
+// with the currency before or after the number.
+var currencyAmountPattern = regexp.MustCompile(`(?i)` +
+	`\b\d[\d.,]*\s?(?:mt|mts|mzn|meticais|usd|eur|zar)\b` +
+	`|(?:\$|€|\bmzn|\bmt|\bzar)\s?\d[\d.,]*`)
+
+// DetectLinks returns the links found in text, in order of appearance:
+// http(s) URLs, WhatsApp links such as wa.me/258841234567, bare domains with
+// a common top-level domain such as promo.co.mz, and Mozambican mobile
+// numbers following a call to action such as "ligue 841234567". Trailing
+// sentence punctuation is not part of a link. Returns nil if there are none.
+func DetectLinks(text string) []string {
+	var links []string
+	for _, m := range linkPattern.FindAllString(text, -1) {
+		links = append(links, strings.TrimRight(m, linkTrailingPunctuation))
+	}
+	return links
+}
+
+// ContainsLinks returns true if DetectLinks finds any link in text.
+func ContainsLinks(text string) bool {
+	return linkPattern.MatchString(text)
+}
+
+// RedactLinks replaces every link DetectLinks would report with
+// LinkPlaceholder, keeping any trailing sentence punctuation.
+func RedactLinks(text string) string {
+	return linkPattern.ReplaceAllStringFunc(text, func(m string) string {
+		link := strings.TrimRight(m, linkTrailingPunctuation)
+		return LinkPlaceholder + m[len(link):]
+	})
+}
+
+// ContainsPromotion reports whether text looks like advertising: it mentions
+// a discount, promotion, coupon, or promo code, or quotes at least
+// MinPromotionAmounts currency amounts. Praise such as "a melhor empresa" is
+// not promotional on its own.
+func ContainsPromotion(text string) bool {
+	if promotionPattern.MatchString(text) {
+		return true
+	}
+	return len(currencyAmountPattern.FindAllStringIndex(text, MinPromotionAmounts)) >= MinPromotionAmounts
+}
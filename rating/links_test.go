@@ -0,0 +1,134 @@
+package rating
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"https url", "Veja https://promo.example.com/oferta?id=1.", []string{"https://promo.example.com/oferta?id=1"}},
+		{"http url", "go to http://bit.ly/abc now", []string{"http://bit.ly/abc"}},
+		{"whatsapp link", "Fala comigo: wa.me/258841234567", []string{"wa.me/258841234567"}},
+		{"whatsapp url reported whole", "https://wa.me/258841234567", []string{"https://wa.me/258841234567"}},
+		{"whatsapp group", "chat.whatsapp.com/AbC123", []string{"chat.whatsapp.com/AbC123"}},
+		{"bare domain", "Usem a MelhorBoleia.co.mz, é mais barato", []string{"MelhorBoleia.co.mz"}},
+		{"www domain", "www.outraapp.com!", []string{"www.outraapp.com"}},
+		{"call to action", "Para boleias baratas ligue 84 123 4567", []string{"ligue 84 123 4567"}},
+		{"call to action with country code", "WhatsApp: +258 861234567", []string{"WhatsApp: +258 861234567"}},
+		{"several links", "site.com e wa.me/258821234567", []string{"site.com", "wa.me/258821234567"}},
+		{"benign praise", "A melhor empresa de transporte em Maputo!", nil},
+		{"number without call to action", "Cheguei às 8h, paguei 841 meticais", nil},
+		{"decimal amount", "Paguei 3.50 a mais", nil},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectLinks(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DetectLinks(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+			if got, want := ContainsLinks(tt.text), tt.want != nil; got != want {
+				t.Errorf("ContainsLinks(%q) = %v, want %v", tt.text, got, want)
+			}
+		})
+	}
+}
+
+func TestRedactLinks(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Veja https://x.com/a.", "Veja [link]."},
+		{"ligue 841234567 ou wa.me/258841234567", "[link] ou [link]"},
+		{"Motorista simpático", "Motorista simpático"},
+	}
+
+	for _, tt := range tests {
+		if got := RedactLinks(tt.text); got != tt.want {
+			t.Errorf("RedactLinks(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestContainsPromotion(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{"desconto", "Peçam desconto na outra app", true},
+		{"promo code", "Use promo code TXOVA10", true},
+		{"codigo promocional", "Código promocional: BOLEIA", true},
+		{"percent off", "20% off na primeira viagem", true},
+		{"repeated amounts", "Lá paga 150 MT, aqui paguei 300 MT", true},
+		{"currency first", "$5 vs $10", true},
+		{"single amount", "A viagem custou 250 MT", false},
+		{"benign praise", "A melhor empresa, recomendo a todos", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ContainsPromotion(tt.text); got != tt.want {
+				t.Errorf("ContainsPromotion(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessReview_Links(t *testing.T) {
+	t.Run("plain link", func(t *testing.T) {
+		result, err := ProcessReview("Bom serviço, mas vejam wa.me/258841234567")
+		if err != nil {
+			t.Fatalf("ProcessReview() error = %v", err)
+		}
+		if !result.ContainsLinks || !result.RequiresReview {
+			t.Errorf("ProcessReview() = %+v, want ContainsLinks and RequiresReview", result)
+		}
+	})
+
+	// Sanitization strips the anchor tag together with its href, so links are
+	// detected in the original text.
+	t.Run("link inside stripped anchor", func(t *testing.T) {
+		result, err := ProcessReview(`Óptimo <a href="https://promo.example.com">clique aqui</a>`)
+		if err != nil {
+			t.Fatalf("ProcessReview() error = %v", err)
+		}
+		if result.Text != "Óptimo clique aqui" {
+			t.Errorf("Text = %q, want the href stripped", result.Text)
+		}
+		if !result.ContainsLinks || !result.RequiresReview {
+			t.Errorf("ProcessReview() = %+v, want ContainsLinks and RequiresReview", result)
+		}
+	})
+
+	t.Run("benign review", func(t *testing.T) {
+		result, err := ProcessReview("A melhor empresa, motorista pontual")
+		if err != nil {
+			t.Fatalf("ProcessReview() error = %v", err)
+		}
+		if result.ContainsLinks || result.RequiresReview {
+			t.Errorf("ProcessReview() = %+v, want no links", result)
+		}
+	})
+
+	t.Run("redacted", func(t *testing.T) {
+		result, err := ProcessReviewWithOptions("Que merda, ligue 841234567", ReviewOptions{RedactLinks: true})
+		if err != nil {
+			t.Fatalf("ProcessReviewWithOptions() error = %v", err)
+		}
+		if result.Text != "Que merda, [link]" || result.MaskedText != "Que *****, [link]" {
+			t.Errorf("Text = %q, MaskedText = %q, want links redacted", result.Text, result.MaskedText)
+		}
+		if !result.ContainsLinks {
+			t.Error("ContainsLinks = false, want true")
+		}
+	})
+}
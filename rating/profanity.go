@@ -0,0 +1,595 @@
+package rating
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Dorico-Dynamics/txova-go-validation/sanitize"
+)
+
+// Profanity severity tiers, ordered so a higher value is more offensive.
+// CheckProfanityLevel reports ProfanityClean for text without profanity.
+const (
+	ProfanityClean  = 0
+	ProfanityMild   = 1 // e.g. "damn"; usually fine to publish
+	ProfanityStrong = 2 // e.g. "shit"; worth a moderator's look
+	ProfanitySevere = 3 // explicit insults and slurs
+)
+
+// MinStrictProfanityLetters is the number of real, non-wildcard letters an
+// obfuscated word must share with a profanity term for CheckProfanityStrict to
+// match it, so "f*ck" matches but "****" does not. Terms shorter than this
+// must match every letter.
+const MinStrictProfanityLetters = 3
+
+// minSpacedLetters is the shortest run of single characters, as in "p o r r a",
+// that CheckProfanityStrict joins into one word.
+const minSpacedLetters = 3
+
+// profanitySuffixes are the endings with which a profanity term still
+// matches, so inflections such as "fucking", "bitches", and "merdas" and
+// compounds such as "asshole" are caught. A term may also double its last
+// letter before the ending, as in "shitty" or "crapper". Any other letters
+// right after a term, as in "assessment" or "Dickson", mean no match; compounds
+// with other words in front, such as "bullshit", are list entries of their own.
+var profanitySuffixes = []string{
+	"s", "es", "ed", "er", "ers", "ing", "in", "y",
+	"head", "heads", "hole", "holes",
+}
+
+// strictWildcard stands for any single letter in obfuscated text.
+const strictWildcard = '*'
+
+// leetSubstitutions maps the digits and symbols commonly used to disguise
+// profanity to the letters they stand for.
+var leetSubstitutions = map[rune]rune{
+	'1': 'i', '3': 'e', '4': 'a', '0': 'o', '@': 'a', '$': 's',
+}
+
+// ProfanityEntry is a word or phrase on the profanity list with its severity.
+type ProfanityEntry struct {
+	Word string
+	// Severity is ProfanityMild, ProfanityStrong, or ProfanitySevere; other
+	// values are treated as ProfanitySevere.
+	Severity int
+}
+
+// defaultProfanityWords maps common profanity terms in Portuguese and English to their severity.
+// This is a conservative list for flagging, not blocking. It is never modified.
+var defaultProfanityWords = map[string]int{
+	// English common terms
+	"damn": ProfanityMild, "crap": ProfanityMild, "piss": ProfanityMild,
+	"shit": ProfanityStrong, "ass": ProfanityStrong, "bastard": ProfanityStrong,
+	"dick": ProfanityStrong, "cock": ProfanityStrong,
+	"bullshit": ProfanityStrong, "horseshit": ProfanityStrong,
+	"dumbass": ProfanityStrong, "jackass": ProfanityStrong,
+	"fuck": ProfanitySevere, "bitch": ProfanitySevere, "motherfuck": ProfanitySevere,
+	// Portuguese common terms
+	"porra": ProfanityMild,
+	"merda": ProfanityStrong, "corno": ProfanityStrong, "foda": ProfanityStrong,
+	"caralho": ProfanitySevere, "puta": ProfanitySevere, "filho da puta": ProfanitySevere,
+	"fdp": ProfanitySevere, "cabrão": ProfanitySevere,
+}
+
+// The profanity list is copy-on-write: readers load an immutable snapshot
+// without locking, and writers, serialized by profanityMu, publish a modified
+// copy. A nil snapshot means defaultProfanityWords.
+var (
+	profanityMu   sync.Mutex
+	profanityList atomic.Pointer[map[string]int]
+	// profanityExceptions holds the phrases registered with
+	// AddProfanityException, published the same way.
+	profanityExceptions atomic.Pointer[[]string]
+)
+
+// profanitySnapshot returns the current profanity list. Callers must not modify it.
+func profanitySnapshot() map[string]int {
+	if list := profanityList.Load(); list != nil {
+		return *list
+	}
+	return defaultProfanityWords
+}
+
+// updateProfanity applies fn to a copy of the current list and publishes the result.
+func updateProfanity(fn func(list map[string]int)) {
+	profanityMu.Lock()
+	defer profanityMu.Unlock()
+
+	next := maps.Clone(profanitySnapshot())
+	fn(next)
+	profanityList.Store(&next)
+}
+
+// replaceProfanity publishes list as the new profanity list.
+func replaceProfanity(list map[string]int) {
+	profanityMu.Lock()
+	defer profanityMu.Unlock()
+
+	profanityList.Store(&list)
+}
+
+// normalizeProfanityTerm lowercases and trims a word for the profanity list.
+func normalizeProfanityTerm(word string) string {
+	return strings.ToLower(strings.TrimSpace(word))
+}
+
+// AddProfanityWords adds entries to the profanity list, replacing the severity
+// of words already on it. Words are matched case-insensitively; empty words
+// are ignored.
+func AddProfanityWords(entries ...ProfanityEntry) {
+	updateProfanity(func(list map[string]int) {
+		for _, e := range entries {
+			word := normalizeProfanityTerm(e.Word)
+			if word == "" {
+				continue
+			}
+			list[word] = normalizeSeverity(e.Severity)
+		}
+	})
+}
+
+// AddProfanityWithSeverity adds a word or phrase to the profanity list with
+// the given severity tier, replacing its severity if it is already listed.
+func AddProfanityWithSeverity(word string, severity int) {
+	AddProfanityWords(ProfanityEntry{Word: word, Severity: severity})
+}
+
+// normalizeSeverity maps values outside the severity tiers to ProfanitySevere.
+func normalizeSeverity(severity int) int {
+	if severity < ProfanityMild || severity > ProfanitySevere {
+		return ProfanitySevere
+	}
+	return severity
+}
+
+// AddProfanity adds words or phrases to the profanity list with ProfanitySevere severity.
+func AddProfanity(words ...string) {
+	entries := make([]ProfanityEntry, len(words))
+	for i, w := range words {
+		entries[i] = ProfanityEntry{Word: w, Severity: ProfanitySevere}
+	}
+	AddProfanityWords(entries...)
+}
+
+// RemoveProfanity removes words or phrases from the profanity list, including
+// built-in ones. Words not on the list are ignored.
+func RemoveProfanity(words ...string) {
+	updateProfanity(func(list map[string]int) {
+		for _, w := range words {
+			delete(list, normalizeProfanityTerm(w))
+		}
+	})
+}
+
+// SetProfanityList replaces the whole profanity list, including the built-in
+// words. Words on the built-in list keep their built-in severity and other
+// words get ProfanitySevere. Empty words are ignored.
+func SetProfanityList(words []string) {
+	entries := make([]ProfanityEntry, len(words))
+	for i, w := range words {
+		entries[i] = ProfanityEntry{Word: w, Severity: defaultSeverity(w)}
+	}
+	SetProfanityEntries(entries)
+}
+
+// SetProfanityEntries replaces the whole profanity list, including the
+// built-in words, with entries at their given severities. Empty words are
+// ignored; a word listed twice keeps its last severity.
+func SetProfanityEntries(entries []ProfanityEntry) {
+	list := make(map[string]int, len(entries))
+	for _, e := range entries {
+		if word := normalizeProfanityTerm(e.Word); word != "" {
+			list[word] = normalizeSeverity(e.Severity)
+		}
+	}
+	replaceProfanity(list)
+}
+
+// defaultSeverity returns the built-in severity of a word, or ProfanitySevere
+// if it is not on the built-in list.
+func defaultSeverity(word string) int {
+	if severity, ok := defaultProfanityWords[normalizeProfanityTerm(word)]; ok {
+		return severity
+	}
+	return ProfanitySevere
+}
+
+// profanitySeverityNames maps the severity names accepted by
+// LoadProfanityList to their tiers.
+var profanitySeverityNames = map[string]int{
+	"mild": ProfanityMild, "strong": ProfanityStrong, "severe": ProfanitySevere,
+	"1": ProfanityMild, "2": ProfanityStrong, "3": ProfanitySevere,
+}
+
+// LoadProfanityList replaces the profanity list with terms read from r, one
+// word or phrase per line, optionally followed by a tab and a severity:
+// "mild", "strong", "severe", or 1-3. Terms without a severity are added as
+// by SetProfanityList. Blank lines and lines starting with "#" are skipped.
+// If reading fails or a severity is unknown the current list is left
+// unchanged.
+func LoadProfanityList(r io.Reader) error {
+	var entries []ProfanityEntry
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word, name, hasSeverity := strings.Cut(line, "\t")
+		severity := defaultSeverity(word)
+		if hasSeverity {
+			var ok bool
+			if severity, ok = profanitySeverityNames[strings.ToLower(strings.TrimSpace(name))]; !ok {
+				return fmt.Errorf("profanity list line %d: unknown severity %q", n, name)
+			}
+		}
+		entries = append(entries, ProfanityEntry{Word: word, Severity: severity})
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading profanity list: %w", err)
+	}
+	SetProfanityEntries(entries)
+	return nil
+}
+
+// ResetProfanityList restores the built-in profanity list.
+func ResetProfanityList() {
+	profanityMu.Lock()
+	defer profanityMu.Unlock()
+
+	profanityList.Store(nil)
+}
+
+// AddProfanityException registers a phrase, such as the name of a place, in
+// which profanity matches are ignored. A match is exempt only if an occurrence
+// of an exception phrase fully covers it; a match that merely overlaps one is
+// still reported. Phrases match case-insensitively and as whole words, and may
+// span several words. The exception list starts empty. CheckProfanityStrict
+// does not consult it.
+func AddProfanityException(phrase string) {
+	phrase = normalizeProfanityTerm(phrase)
+	if phrase == "" {
+		return
+	}
+
+	profanityMu.Lock()
+	defer profanityMu.Unlock()
+
+	var next []string
+	if current := profanityExceptions.Load(); current != nil {
+		if slices.Contains(*current, phrase) {
+			return
+		}
+		next = slices.Clone(*current)
+	}
+	next = append(next, phrase)
+	profanityExceptions.Store(&next)
+}
+
+// ClearProfanityExceptions removes every phrase added with
+// AddProfanityException.
+func ClearProfanityExceptions() {
+	profanityMu.Lock()
+	defer profanityMu.Unlock()
+
+	profanityExceptions.Store(nil)
+}
+
+// profanityExceptionSnapshot returns the current exception phrases. Callers
+// must not modify it.
+func profanityExceptionSnapshot() []string {
+	if exceptions := profanityExceptions.Load(); exceptions != nil {
+		return *exceptions
+	}
+	return nil
+}
+
+// profanityMatch is an occurrence of a profanity list entry in a text,
+// located by rune offsets.
+type profanityMatch struct {
+	start, end int
+	word       string
+	severity   int
+}
+
+// findProfanity returns every occurrence of an entry of list in text. An entry
+// matches case-insensitively at the start of a word and must end the word,
+// optionally followed by one of profanitySuffixes; the suffix is part of the
+// match. Detection and masking both use it so they always agree. Lookalike characters are folded first with
+// sanitize.FoldConfusables, which keeps rune offsets aligned with text.
+// Matches covered by an AddProfanityException phrase are dropped.
+func findProfanity(list map[string]int, text string) []profanityMatch {
+	runes := []rune(sanitize.FoldConfusables(text))
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	var matches []profanityMatch
+	for word, severity := range list {
+		for _, occ := range profanityOccurrences(lower, []rune(word)) {
+			matches = append(matches, profanityMatch{start: occ[0], end: occ[1], word: word, severity: severity})
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	var covers [][2]int
+	for _, phrase := range profanityExceptionSnapshot() {
+		covers = append(covers, wholeWordOccurrences(lower, []rune(phrase))...)
+	}
+	return slices.DeleteFunc(matches, func(m profanityMatch) bool {
+		return slices.ContainsFunc(covers, func(c [2]int) bool {
+			return c[0] <= m.start && m.end <= c[1]
+		})
+	})
+}
+
+// wholeWordOccurrences returns the start and end offsets of every occurrence
+// of term in text that is not preceded or followed by a letter or digit.
+func wholeWordOccurrences(text, term []rune) [][2]int {
+	var occurrences [][2]int
+	for i := 0; i+len(term) <= len(text); i++ {
+		end := i + len(term)
+		if (i > 0 && isWordRune(text[i-1])) || (end < len(text) && isWordRune(text[end])) {
+			continue
+		}
+		if slices.Equal(text[i:end], term) {
+			occurrences = append(occurrences, [2]int{i, end})
+		}
+	}
+	return occurrences
+}
+
+// profanityOccurrences returns the start and end offsets of every occurrence
+// of term in text that starts a word and is followed by the end of the word
+// or by one of profanitySuffixes, which the end offset includes.
+func profanityOccurrences(text, term []rune) [][2]int {
+	var occurrences [][2]int
+	for i := 0; i+len(term) <= len(text); i++ {
+		end := i + len(term)
+		if (i > 0 && isWordRune(text[i-1])) || !slices.Equal(text[i:end], term) {
+			continue
+		}
+		if n, ok := suffixLength(text[end:], term[len(term)-1]); ok {
+			occurrences = append(occurrences, [2]int{i, end + n})
+		}
+	}
+	return occurrences
+}
+
+// suffixLength returns the length of the rest of the word at the start of
+// rest if it is empty or one of profanitySuffixes, optionally after a repeat
+// of last. It reports false for any other ending.
+func suffixLength(rest []rune, last rune) (int, bool) {
+	n := 0
+	for n < len(rest) && isWordRune(rest[n]) {
+		n++
+	}
+	if n == 0 {
+		return 0, true
+	}
+	ending := string(rest[:n])
+	if slices.Contains(profanitySuffixes, ending) {
+		return n, true
+	}
+	if rest[0] == last && slices.Contains(profanitySuffixes, string(rest[1:n])) {
+		return n, true
+	}
+	return 0, false
+}
+
+// isWordRune reports whether r is part of a word for profanity matching.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// maskMatches replaces the runes of each match in text with asterisks.
+func maskMatches(text string, matches []profanityMatch) string {
+	if len(matches) == 0 {
+		return text
+	}
+	runes := []rune(text)
+	for _, m := range matches {
+		for i := m.start; i < m.end; i++ {
+			runes[i] = '*'
+		}
+	}
+	return string(runes)
+}
+
+// CheckProfanity checks if the text contains potential profanity.
+// Returns true if profanity is detected, indicating the text should be flagged for moderation.
+// This uses a conservative approach - it only flags, doesn't reject.
+// Terms match case-insensitively as words or phrases, including common
+// inflections such as "fucking" or "merdas", but not inside other words such
+// as "assessment".
+func CheckProfanity(text string) bool {
+	return len(findProfanity(profanitySnapshot(), text)) > 0
+}
+
+// FindProfanity returns the profanity list entries found in the text with
+// their severity, once each and in alphabetical order. Returns nil if the
+// text is clean.
+func FindProfanity(text string) []ProfanityEntry {
+	return profanityEntries(findProfanity(profanitySnapshot(), text))
+}
+
+// profanityEntries returns the distinct entries of matches in alphabetical order.
+func profanityEntries(matches []profanityMatch) []ProfanityEntry {
+	var entries []ProfanityEntry
+	seen := make(map[string]bool)
+	for _, m := range matches {
+		if !seen[m.word] {
+			seen[m.word] = true
+			entries = append(entries, ProfanityEntry{Word: m.word, Severity: m.severity})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Word < entries[j].Word })
+	return entries
+}
+
+// maxSeverity returns the highest severity among matches, or ProfanityClean.
+func maxSeverity(matches []profanityMatch) int {
+	level := ProfanityClean
+	for _, m := range matches {
+		level = max(level, m.severity)
+	}
+	return level
+}
+
+// CheckProfanityLevel reports the most severe profanity tier in the text,
+// from ProfanityClean (0) to ProfanitySevere (3), along with the distinct
+// matched words and phrases in alphabetical order. Matching works like
+// CheckProfanity.
+func CheckProfanityLevel(text string) (level int, matches []string) {
+	found := findProfanity(profanitySnapshot(), text)
+	for _, e := range profanityEntries(found) {
+		matches = append(matches, e.Word)
+	}
+	return maxSeverity(found), matches
+}
+
+// MaskProfanity replaces every profanity match in the text with asterisks of
+// the same length in runes, e.g. "Que merda!" becomes "Que *****!".
+// Surrounding text and punctuation are unchanged, and clean text is returned
+// as is. Matching works like CheckProfanity.
+func MaskProfanity(text string) string {
+	return maskMatches(text, findProfanity(profanitySnapshot(), text))
+}
+
+// CheckProfanityStrict is CheckProfanity with defenses against common evasions.
+// Before matching it joins runs of single characters ("p o r r a"), reads
+// digits and symbols as letters in words that contain letters ("sh1t", "$hit",
+// "m3rda"), lets "*" stand for one letter ("f*ck"), and ignores repeated
+// letters ("shiiiit"). Numbers on their own, as in "route 34", are left
+// alone. It flags more text than CheckProfanity, including some false
+// positives, so it is a separate opt-in check.
+func CheckProfanityStrict(text string) bool {
+	return CheckProfanityStrictLevel(text) > ProfanityClean
+}
+
+// CheckProfanityStrictLevel is CheckProfanityLevel with the evasion handling
+// of CheckProfanityStrict: obfuscated words keep the severity of the term
+// they spell.
+func CheckProfanityStrictLevel(text string) int {
+	list := profanitySnapshot()
+	level := maxSeverity(findProfanity(list, text))
+
+	tokens := strictTokens(text)
+	for word, severity := range list {
+		if severity > level && containsStrictTerm(tokens, strings.Fields(word)) {
+			level = severity
+		}
+	}
+	return level
+}
+
+// strictTokens splits text into lowercase words for CheckProfanityStrict,
+// joining runs of single characters and undoing lookalike characters and
+// character substitutions.
+func strictTokens(text string) [][]rune {
+	fields := strings.FieldsFunc(strings.ToLower(sanitize.FoldConfusables(text)), func(r rune) bool {
+		_, leet := leetSubstitutions[r]
+		return !isWordRune(r) && !leet && r != strictWildcard
+	})
+
+	var tokens [][]rune
+	for i := 0; i < len(fields); {
+		j := i
+		for j < len(fields) && utf8.RuneCountInString(fields[j]) == 1 {
+			j++
+		}
+		switch {
+		case j-i >= minSpacedLetters:
+			tokens = append(tokens, deobfuscate(strings.Join(fields[i:j], "")))
+		case j > i:
+			for _, f := range fields[i:j] {
+				tokens = append(tokens, deobfuscate(f))
+			}
+		default:
+			tokens = append(tokens, deobfuscate(fields[i]))
+			j = i + 1
+		}
+		i = j
+	}
+	return tokens
+}
+
+// deobfuscate replaces substituted characters in a word with the letters they
+// stand for. Words without letters are returned unchanged.
+func deobfuscate(word string) []rune {
+	runes := []rune(word)
+	if !slices.ContainsFunc(runes, unicode.IsLetter) {
+		return runes
+	}
+	for i, r := range runes {
+		if letter, ok := leetSubstitutions[r]; ok {
+			runes[i] = letter
+		}
+	}
+	return runes
+}
+
+// containsStrictTerm reports whether the words of a profanity term appear as
+// consecutive tokens.
+func containsStrictTerm(tokens [][]rune, termWords []string) bool {
+	for start := 0; start+len(termWords) <= len(tokens); start++ {
+		matched := true
+		for k, w := range termWords {
+			if !matchStrictWord([]rune(w), tokens[start+k]) {
+				matched = false
+				break
+			}
+		}
+		if matched && len(termWords) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// matchStrictWord reports whether token spells term, allowing each letter to
+// repeat and strictWildcard to stand for any one letter, with at least
+// MinStrictProfanityLetters real letters matched.
+func matchStrictWord(term, token []rune) bool {
+	// best[i][j] is the most real letters with which term[:i] matches token[:j], or -1.
+	best := make([][]int, len(term)+1)
+	for i := range best {
+		best[i] = make([]int, len(token)+1)
+		for j := range best[i] {
+			best[i][j] = -1
+		}
+	}
+	best[0][0] = 0
+
+	for j := 1; j <= len(token); j++ {
+		r := token[j-1]
+		for i := 1; i <= len(term); i++ {
+			v := -1
+			if r == strictWildcard && best[i-1][j-1] >= 0 {
+				v = best[i-1][j-1]
+			}
+			if r == term[i-1] {
+				if best[i-1][j-1] >= 0 {
+					v = max(v, best[i-1][j-1]+1)
+				}
+				if best[i][j-1] >= 0 { // repeated letter
+					v = max(v, best[i][j-1])
+				}
+			}
+			best[i][j] = v
+		}
+	}
+	return best[len(term)][len(token)] >= min(MinStrictProfanityLetters, len(term))
+}
@@ -0,0 +1,499 @@
+package rating
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCheckProfanityLevel(t *testing.T) {
+	t.Cleanup(ResetProfanityList)
+	AddProfanityWords(ProfanityEntry{Word: "Bloody", Severity: ProfanityMild})
+
+	tests := []struct {
+		name        string
+		text        string
+		wantLevel   int
+		wantMatches []string
+	}{
+		{"clean", "The driver was excellent!", ProfanityClean, nil},
+		{"empty", "", ProfanityClean, nil},
+		{"built-in strong", "This was shit service", ProfanityStrong, []string{"shit"}},
+		{"built-in severe", "Que caralho", ProfanitySevere, []string{"caralho"}},
+		{"multiple sorted", "Que merda, SHIT", ProfanityStrong, []string{"merda", "shit"}},
+		{"mild only", "Bloody terrible", ProfanityMild, []string{"bloody"}},
+		{"mild and strong", "Bloody hell, merda", ProfanityStrong, []string{"bloody", "merda"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, matches := CheckProfanityLevel(tt.text)
+			if level != tt.wantLevel || !reflect.DeepEqual(matches, tt.wantMatches) {
+				t.Errorf("CheckProfanityLevel(%q) = %d, %v; want %d, %v",
+					tt.text, level, matches, tt.wantLevel, tt.wantMatches)
+			}
+			var words []string
+			for _, e := range FindProfanity(tt.text) {
+				words = append(words, e.Word)
+			}
+			if !reflect.DeepEqual(words, tt.wantMatches) {
+				t.Errorf("FindProfanity(%q) words = %v, want %v", tt.text, words, tt.wantMatches)
+			}
+			if got := CheckProfanity(tt.text); got != (tt.wantLevel > ProfanityClean) {
+				t.Errorf("CheckProfanity(%q) = %v, inconsistent with level %d", tt.text, got, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestMaskProfanity(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"mixed language", "Que merda, what a SHIT driver", "Que *****, what a **** driver"},
+		{"phrase", "Este filho da puta!", "Este *************!"},
+		{"repeated", "merda merda, merda.", "***** *****, *****."},
+		{"punctuation kept", "(porra)...\"fdp\"", "(*****)...\"***\""},
+		{"accented term", "seu cabrão.", "seu ******."},
+		{"embedded not masked", "A classic assessment", "A classic assessment"},
+		{"clean", "Motorista muito bom, obrigado! 👍", "Motorista muito bom, obrigado! 👍"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MaskProfanity(tt.text)
+			if got != tt.want {
+				t.Errorf("MaskProfanity(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+			if masked := got != tt.text; masked != CheckProfanity(tt.text) {
+				t.Errorf("MaskProfanity(%q) and CheckProfanity() disagree", tt.text)
+			}
+		})
+	}
+}
+
+func TestCheckProfanity_Inflections(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+		mask string
+	}{
+		{"compound entry", "This is bullshit", []string{"bullshit"}, "This is ********"},
+		{"ing", "Fucking late", []string{"fuck"}, "******* late"},
+		{"er with prefix entry", "Motherfucker!", []string{"motherfuck"}, "************!"},
+		{"es", "Those bitches", []string{"bitch"}, "Those *******"},
+		{"ed", "I was pissed", []string{"piss"}, "I was ******"},
+		{"doubled letter", "A shitty car", []string{"shit"}, "A ****** car"},
+		{"compound suffix", "What an asshole", []string{"ass"}, "What an *******"},
+		{"portuguese plural", "Que merdas", []string{"merda"}, "Que ******"},
+		{"assessment", "A classic assessment", nil, "A classic assessment"},
+		{"surname", "Mr Dickson drove well", nil, "Mr Dickson drove well"},
+		{"inside a word", "O computador", nil, "O computador"},
+		{"other ending", "Assert the cockpit is clean", nil, "Assert the cockpit is clean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var words []string
+			for _, e := range FindProfanity(tt.text) {
+				words = append(words, e.Word)
+			}
+			if !reflect.DeepEqual(words, tt.want) {
+				t.Errorf("FindProfanity(%q) = %v, want %v", tt.text, words, tt.want)
+			}
+			if got := MaskProfanity(tt.text); got != tt.mask {
+				t.Errorf("MaskProfanity(%q) = %q, want %q", tt.text, got, tt.mask)
+			}
+		})
+	}
+}
+
+func TestCheckProfanityStrict(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		// Evasions
+		{"digit for letter", "This was sh1t service", true},
+		{"symbol for letter", "$hit driver", true},
+		{"at sign", "what an @ss", true},
+		{"portuguese leetspeak", "que m3rd4", true},
+		{"zero for o", "p0rra", true},
+		{"wildcard", "What the f*ck", true},
+		{"repeated letters", "shiiiiit", true},
+		{"spaced letters", "p o r r a", true},
+		{"dotted letters", "p.o.r.r.a!", true},
+		{"spaced phrase", "f i l h o da puta", true},
+		{"spaced wildcard", "F * C K", true},
+		{"combined with enough letters", "s h 1 1 t", true},
+		{"plain profanity", "merda", true},
+
+		// Benign text
+		{"numbers", "route 34 shift", false},
+		{"time and money", "Paid 400 MZN at 3pm, arrived 10:45", false},
+		{"plate", "AAA-123-MC", false},
+		{"all wildcards", "**** ****", false},
+		{"too few real letters", "f**k", false},
+		{"embedded", "A classic assessment", false},
+		{"spaced short run", "a b driver", false},
+		{"clean", "Motorista muito bom, obrigado!", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckProfanityStrict(tt.text); got != tt.want {
+				t.Errorf("CheckProfanityStrict(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+
+	// The default check is unaffected.
+	if CheckProfanity("sh1t") || CheckProfanity("p o r r a") {
+		t.Error("CheckProfanity() matches obfuscated text")
+	}
+}
+
+func TestAddProfanityWords(t *testing.T) {
+	t.Cleanup(ResetProfanityList)
+
+	AddProfanityWords(
+		ProfanityEntry{Word: " Chulo "},
+		ProfanityEntry{Word: "idiota", Severity: 7},
+		ProfanityEntry{Word: "damn", Severity: ProfanityMild},
+		ProfanityEntry{Word: "   ", Severity: ProfanityMild},
+	)
+
+	tests := []struct {
+		text string
+		want int
+	}{
+		{"seu chulo", ProfanitySevere},
+		{"IDIOTA", ProfanitySevere},
+		{"damn it", ProfanityMild},
+		{"all good", ProfanityClean},
+	}
+
+	for _, tt := range tests {
+		if got, _ := CheckProfanityLevel(tt.text); got != tt.want {
+			t.Errorf("CheckProfanityLevel(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestAddProfanityWithSeverity(t *testing.T) {
+	t.Cleanup(ResetProfanityList)
+
+	AddProfanityWithSeverity("  Xiphukwana ", ProfanityStrong)
+	AddProfanityWithSeverity("shit", ProfanityMild)
+	AddProfanityWithSeverity("mbava", 0)
+
+	want := []ProfanityEntry{
+		{"mbava", ProfanitySevere},
+		{"shit", ProfanityMild},
+		{"xiphukwana", ProfanityStrong},
+	}
+	if got := FindProfanity("XIPHUKWANA, shit, mbava"); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindProfanity() = %v, want %v", got, want)
+	}
+}
+
+func TestProfanitySeverity_Paths(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{"mild", "damn", ProfanityMild},
+		{"strong", "shit", ProfanityStrong},
+		{"severe", "fdp", ProfanitySevere},
+		{"case-insensitive", "DaMn", ProfanityMild},
+		{"highest wins", "damn, what a bitch", ProfanitySevere},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := CheckProfanityLevel(tt.text); got != tt.want {
+				t.Errorf("CheckProfanityLevel(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+			if !CheckProfanity(tt.text) {
+				t.Errorf("CheckProfanity(%q) = false for tier %d", tt.text, tt.want)
+			}
+
+			result, err := ProcessReview(tt.text + " here")
+			if err != nil {
+				t.Fatalf("ProcessReview() error = %v", err)
+			}
+			if result.MaxSeverity != tt.want || result.MaskedText == "" {
+				t.Errorf("ProcessReview(%q) = %+v, want MaxSeverity %d and masked text", tt.text, result, tt.want)
+			}
+			// Masking must not lose the severity of what was masked.
+			if got, _ := CheckProfanityLevel(result.Text); got != result.MaxSeverity {
+				t.Errorf("severity after masking path = %d, want %d", got, result.MaxSeverity)
+			}
+		})
+	}
+
+	strict := []struct {
+		text string
+		want int
+	}{
+		{"d4mn", ProfanityMild},
+		{"sh1t", ProfanityStrong},
+		{"f d p", ProfanitySevere},
+		{"route 34 shift", ProfanityClean},
+	}
+	for _, tt := range strict {
+		if got := CheckProfanityStrictLevel(tt.text); got != tt.want {
+			t.Errorf("CheckProfanityStrictLevel(%q) = %d, want %d", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestAddProfanity(t *testing.T) {
+	t.Cleanup(ResetProfanityList)
+
+	const review = "O motorista é um xiphukwana"
+	if CheckProfanity(review) {
+		t.Fatalf("CheckProfanity(%q) = true before adding the word", review)
+	}
+
+	AddProfanity("Xiphukwana")
+	if !CheckProfanity(review) {
+		t.Errorf("CheckProfanity(%q) = false after AddProfanity", review)
+	}
+	result, err := ProcessReview(review)
+	if err != nil || !result.HasProfanity {
+		t.Errorf("ProcessReview(%q) = %+v, %v; want HasProfanity", review, result, err)
+	}
+	if !CheckProfanity("shit") {
+		t.Error("AddProfanity() dropped a default word")
+	}
+}
+
+func TestRemoveProfanity(t *testing.T) {
+	t.Cleanup(ResetProfanityList)
+
+	RemoveProfanity("DAMN", "not-on-the-list")
+	if CheckProfanity("damn good driver") {
+		t.Error("CheckProfanity() still flags a removed default word")
+	}
+	if !CheckProfanity("merda") {
+		t.Error("RemoveProfanity() removed other default words")
+	}
+
+	ResetProfanityList()
+	if !CheckProfanity("damn") {
+		t.Error("ResetProfanityList() did not restore the default word")
+	}
+}
+
+func TestSetProfanityList(t *testing.T) {
+	t.Cleanup(ResetProfanityList)
+
+	SetProfanityList([]string{"Palavrão", " "})
+	if !CheckProfanity("que PALAVRÃO") {
+		t.Error("CheckProfanity() does not flag a word from SetProfanityList")
+	}
+	if CheckProfanity("shit") {
+		t.Error("SetProfanityList() kept the default words")
+	}
+	if got := FindProfanity("palavrão"); !reflect.DeepEqual(got, []ProfanityEntry{{"palavrão", ProfanitySevere}}) {
+		t.Errorf("FindProfanity() = %v, want [palavrão]", got)
+	}
+
+	SetProfanityList([]string{"Damn", "porra", "mbava"})
+	want := []ProfanityEntry{{"damn", ProfanityMild}, {"mbava", ProfanitySevere}, {"porra", ProfanityMild}}
+	if got := FindProfanity("damn porra mbava"); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindProfanity() = %v, want built-in severities kept: %v", got, want)
+	}
+}
+
+func TestSetProfanityEntries(t *testing.T) {
+	t.Cleanup(ResetProfanityList)
+
+	SetProfanityEntries([]ProfanityEntry{
+		{"Mbava", ProfanityStrong},
+		{"merda", ProfanityMild},
+		{"xiphukwana", 0},
+		{" ", ProfanityMild},
+	})
+	want := []ProfanityEntry{{"mbava", ProfanityStrong}, {"merda", ProfanityMild}, {"xiphukwana", ProfanitySevere}}
+	if got := FindProfanity("mbava merda xiphukwana"); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindProfanity() = %v, want %v", got, want)
+	}
+	if CheckProfanity("shit") {
+		t.Error("SetProfanityEntries() kept the default words")
+	}
+}
+
+func TestLoadProfanityList(t *testing.T) {
+	t.Cleanup(ResetProfanityList)
+
+	input := "# Changana\nxiphukwana\n\n  # Sena\n  Mbava  \n"
+	if err := LoadProfanityList(strings.NewReader(input)); err != nil {
+		t.Fatalf("LoadProfanityList() error = %v", err)
+	}
+	want := []ProfanityEntry{{"mbava", ProfanitySevere}, {"xiphukwana", ProfanitySevere}}
+	if got := FindProfanity("xiphukwana mbava # sena"); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindProfanity() = %v, want %v", got, want)
+	}
+	if CheckProfanity("merda") {
+		t.Error("LoadProfanityList() kept the default words")
+	}
+
+	input = "mbava\tmild\nxiphukwana\t2\nmerda\n# porra\tsevere\nfilho da puta\tSEVERE\n"
+	if err := LoadProfanityList(strings.NewReader(input)); err != nil {
+		t.Fatalf("LoadProfanityList() with severities error = %v", err)
+	}
+	want = []ProfanityEntry{
+		{"filho da puta", ProfanitySevere},
+		{"mbava", ProfanityMild},
+		{"merda", ProfanityStrong},
+		{"xiphukwana", ProfanityStrong},
+	}
+	if got := FindProfanity("mbava xiphukwana merda porra filho da puta"); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindProfanity() = %v, want %v", got, want)
+	}
+
+	if err := LoadProfanityList(strings.NewReader("caralho\nmbava\tawful\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("LoadProfanityList() with unknown severity error = %v, want line 2", err)
+	}
+	if CheckProfanity("caralho") {
+		t.Error("LoadProfanityList() changed the list after an unknown severity")
+	}
+
+	readErr := errors.New("disk on fire")
+	if err := LoadProfanityList(iotest.ErrReader(readErr)); !errors.Is(err, readErr) {
+		t.Errorf("LoadProfanityList() error = %v, want %v", err, readErr)
+	}
+	if !CheckProfanity("mbava") {
+		t.Error("LoadProfanityList() changed the list after a read error")
+	}
+}
+
+func TestProfanityList_Concurrent(t *testing.T) {
+	t.Cleanup(ResetProfanityList)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				AddProfanity("xiphukwana")
+				RemoveProfanity("xiphukwana")
+				if i == 0 {
+					SetProfanityList([]string{"merda"})
+					ResetProfanityList()
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if !CheckProfanity("merda") {
+					t.Error("CheckProfanity() missed a word present in every snapshot")
+					return
+				}
+				_, _ = ProcessReview("Que merda de serviço")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCheckProfanity_Confusables(t *testing.T) {
+	t.Cleanup(ResetProfanityList)
+	AddProfanity("cop")
+
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{"cyrillic e", "Que mеrda de viagem", true},
+		{"fullwidth letters", "ＳＨＩＴ driver", true},
+		{"greek omicron", "pοrra!", true},
+		// Folded letter by letter, the Russian word for "litter" reads "cop".
+		{"genuine cyrillic word", "сор", false},
+		{"genuine russian review", "Водитель очень вежливый", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckProfanity(tt.text); got != tt.want {
+				t.Errorf("CheckProfanity(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+			if got := CheckProfanityStrict(tt.text); got != tt.want {
+				t.Errorf("CheckProfanityStrict(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+
+	if got, want := MaskProfanity("Que mеrda!"), "Que *****!"; got != want {
+		t.Errorf("MaskProfanity() = %q, want %q", got, want)
+	}
+}
+
+func TestAddProfanityException(t *testing.T) {
+	t.Cleanup(ClearProfanityExceptions)
+
+	if CheckProfanity("Fomos ao Bar Porra Louca") != true {
+		t.Fatal("CheckProfanity() = false before adding the exception")
+	}
+
+	AddProfanityException("Bar Porra Louca")
+	AddProfanityException("bar porra louca") // duplicate, ignored
+	AddProfanityException("  ")
+
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{"inside exception", "Fomos ao Bar Porra Louca ontem", false},
+		{"exception case-insensitive", "fomos ao BAR PORRA LOUCA", false},
+		{"standalone term", "Porra, que demora", true},
+		{"partial phrase", "Fomos ao Bar Porra", true},
+		{"exception and standalone", "Bar Porra Louca? Porra!", true},
+		{"exception inside a longer word", "Bar Porra Loucas", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckProfanity(tt.text); got != tt.want {
+				t.Errorf("CheckProfanity(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+			if got := FindProfanity(tt.text) != nil; got != tt.want {
+				t.Errorf("FindProfanity(%q) = %v, want matches %v", tt.text, FindProfanity(tt.text), tt.want)
+			}
+		})
+	}
+
+	if got, want := MaskProfanity("Bar Porra Louca? Porra!"), "Bar Porra Louca? *****!"; got != want {
+		t.Errorf("MaskProfanity() = %q, want %q", got, want)
+	}
+	if got := *profanityExceptions.Load(); len(got) != 1 {
+		t.Errorf("exceptions = %q, want one phrase", got)
+	}
+}
+
+func TestAddProfanityException_PartialOverlap(t *testing.T) {
+	t.Cleanup(ClearProfanityExceptions)
+
+	// The exception covers the match "puta" but only part of "filho da puta".
+	AddProfanityException("da puta")
+	got := FindProfanity("seu filho da puta")
+	if want := []ProfanityEntry{{"filho da puta", ProfanitySevere}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("FindProfanity() = %v, want %v", got, want)
+	}
+}
@@ -2,6 +2,8 @@
 package rating
 
 import (
+	"fmt"
+	"math"
 	"regexp"
 	"strings"
 	"unicode"
@@ -9,6 +11,7 @@ import (
 	"github.com/Dorico-Dynamics/txova-go-types/rating"
 
 	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+	"github.com/Dorico-Dynamics/txova-go-validation/sanitize"
 )
 
 // Review text constraints.
@@ -17,25 +20,65 @@ const (
 	MaxReviewLength = 500
 )
 
+// Low rating comment constraints.
+const (
+	// LowRatingThreshold is the highest rating that requires an explanatory comment.
+	LowRatingThreshold = 2
+	// DefaultLowRatingCommentLength is the default minimum comment length for low ratings.
+	DefaultLowRatingCommentLength = 20
+)
+
+// DefaultRatingStep is the increment accepted by ValidateRatingFloat: half stars.
+const DefaultRatingStep = 0.5
+
+// ratingStepTolerance absorbs floating-point error when checking a rating is
+// a whole number of steps.
+const ratingStepTolerance = 1e-6
+
 // htmlTagPattern matches HTML tags for stripping.
 var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
 
-// profanityWords contains common profanity terms in Portuguese and English.
-// This is a conservative list for flagging, not blocking.
-var profanityWords = map[string]bool{
-	// English common terms
-	"fuck": true, "shit": true, "damn": true, "ass": true, "bitch": true,
-	"bastard": true, "crap": true, "piss": true, "dick": true, "cock": true,
-	// Portuguese common terms
-	"merda": true, "porra": true, "caralho": true, "foda": true, "puta": true,
-	"corno": true, "filho da puta": true, "fdp": true, "cabrão": true,
-}
-
 // ValidateRating validates that a rating value is within the 1-5 range.
 func ValidateRating(value int) error {
+	return ValidateRatingField("rating", value)
+}
+
+// ValidateRatingField is ValidateRating reporting errors on the given field,
+// e.g. "driver_rating".
+func ValidateRatingField(field string, value int) error {
 	_, err := rating.NewRating(value)
 	if err != nil {
-		return valerrors.OutOfRangeWithValue("rating", rating.MinRating, rating.MaxRating, value)
+		return valerrors.OutOfRangeWithValue(field, rating.MinRating, rating.MaxRating, value)
+	}
+	return nil
+}
+
+// ValidateRatingFloat validates a fractional rating: a value in [1.0, 5.0] in
+// DefaultRatingStep (0.5) increments, such as 3.5.
+func ValidateRatingFloat(value float64) error {
+	return ValidateRatingFloatWithStep(value, DefaultRatingStep)
+}
+
+// ValidateRatingFloatWithStep validates a fractional rating in [1.0, 5.0] that
+// is a whole number of step increments above 1.0. NaN and infinite values are
+// INVALID_FORMAT; values outside the range are OUT_OF_RANGE. A step that is
+// not a positive finite number is reported as INVALID_FORMAT on "step".
+func ValidateRatingFloatWithStep(value, step float64) error {
+	if !(step > 0) || math.IsInf(step, 1) {
+		return valerrors.InvalidFormatWithValue("step", "positive number", step)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return valerrors.InvalidFormatWithValue("rating", "finite number", value)
+	}
+	minRating, maxRating := float64(rating.MinRating), float64(rating.MaxRating)
+	if value < minRating || value > maxRating {
+		return valerrors.OutOfRangeWithValue("rating", minRating, maxRating, value)
+	}
+
+	steps := (value - minRating) / step
+	if math.Abs(steps-math.Round(steps)) > ratingStepTolerance {
+		return valerrors.InvalidFormatWithValue("rating", fmt.Sprintf("multiple of %g", step), value).
+			WithParam("step", step)
 	}
 	return nil
 }
@@ -43,9 +86,28 @@ func ValidateRating(value int) error {
 // ValidateReviewText validates the length of review text.
 // Text is optional (can be empty) but must not exceed MaxReviewLength characters.
 func ValidateReviewText(text string) error {
+	return ValidateReviewTextField("review", text)
+}
+
+// ValidateReviewTextField is ValidateReviewText reporting errors on the
+// given field.
+func ValidateReviewTextField(field, text string) error {
+	return validateTextLength(field, text, MinReviewLength, MaxReviewLength)
+}
+
+// validateTextLength checks that text has between minLength and maxLength
+// characters, reporting errors on field. An empty text is REQUIRED when
+// minLength is positive.
+func validateTextLength(field, text string, minLength, maxLength int) error {
 	length := len([]rune(text)) // Count Unicode characters, not bytes
-	if length > MaxReviewLength {
-		return valerrors.TooLongWithValue("review", MaxReviewLength, length)
+	if length > maxLength {
+		return valerrors.TooLongWithValue(field, maxLength, length).WithParam("max_length", maxLength)
+	}
+	if minLength > 0 && length == 0 {
+		return valerrors.Required(field).WithParam("min_length", minLength)
+	}
+	if length < minLength {
+		return valerrors.TooShortWithValue(field, minLength, length).WithParam("min_length", minLength)
 	}
 	return nil
 }
@@ -86,20 +148,28 @@ func normalizeWhitespace(s string) string {
 	return result.String()
 }
 
-// CheckProfanity checks if the text contains potential profanity.
-// Returns true if profanity is detected, indicating the text should be flagged for moderation.
-// This uses a conservative approach - it only flags, doesn't reject.
-func CheckProfanity(text string) bool {
-	lower := strings.ToLower(text)
+// ValidateMinimumLowRatingComment requires an explanatory comment for low ratings.
+// For ratings at or below LowRatingThreshold the comment must be non-empty and,
+// once sanitized, at least minLength characters long. Higher ratings always pass.
+func ValidateMinimumLowRatingComment(rating int, comment string, minLength int) error {
+	if rating > LowRatingThreshold {
+		return nil
+	}
 
-	// Check for exact word matches and partial matches
-	for word := range profanityWords {
-		if strings.Contains(lower, word) {
-			return true
-		}
+	sanitized := SanitizeReviewText(comment)
+	if sanitized == "" {
+		return valerrors.Required("review")
+	}
+	if len([]rune(sanitized)) < minLength {
+		return valerrors.TooShort("review", minLength)
 	}
+	return nil
+}
 
-	return false
+// ValidateLowRatingCommentDefault validates a low rating comment using
+// DefaultLowRatingCommentLength as the minimum length.
+func ValidateLowRatingCommentDefault(rating int, comment string) error {
+	return ValidateMinimumLowRatingComment(rating, comment, DefaultLowRatingCommentLength)
 }
 
 // IsValidRating returns true if the rating is within the 1-5 range.
@@ -107,6 +177,11 @@ func IsValidRating(value int) bool {
 	return ValidateRating(value) == nil
 }
 
+// IsValidRatingFloat returns true if the value is a valid half-star rating.
+func IsValidRatingFloat(value float64) bool {
+	return ValidateRatingFloat(value) == nil
+}
+
 // IsValidReviewText returns true if the review text length is acceptable.
 func IsValidReviewText(text string) bool {
 	return ValidateReviewText(text) == nil
@@ -124,33 +199,157 @@ func ValidateAndSanitizeReview(text string) (string, error) {
 
 // ReviewResult contains the result of review validation and processing.
 type ReviewResult struct {
-	Text            string
-	HasProfanity    bool
+	Text         string
+	HasProfanity bool
+	// MaxSeverity is the highest severity tier of the profanity found, or
+	// ProfanityClean.
+	MaxSeverity int
+	// MaskedText is Text with profanity masked by MaskProfanity. It is empty
+	// when no profanity was found.
+	MaskedText string
+	// Language is the review's language as reported by DetectLanguage.
+	Language string
+	// EmojiOnly is true when the review is made only of emoji.
+	EmojiOnly bool
+	// SpamFlags lists the CheckSpam reason codes that fired, if any.
+	SpamFlags []string
+	// ContainsLinks is true when the review, before sanitization, contains a
+	// link reported by DetectLinks. Checking the original text catches URLs
+	// hidden in HTML attributes, which sanitization strips along with the tag.
+	ContainsLinks bool
+	// Title is the sanitized title set by ProcessReviewWithTitle.
+	Title string
+	// TitleHasProfanity, TitleMaxSeverity, TitleMaskedText, and
+	// TitleContainsLinks report the checks on Title like their
+	// counterparts for the body.
+	TitleHasProfanity  bool
+	TitleMaxSeverity   int
+	TitleMaskedText    string
+	TitleContainsLinks bool
+	// RequiresReview is true when the body or title needs manual moderation.
 	RequiresReview  bool
 	OriginalLength  int
 	SanitizedLength int
 }
 
+// ReviewOptions configures ProcessReviewWithOptions. The zero value
+// reproduces ProcessReview.
+type ReviewOptions struct {
+	// MaxLength is the longest accepted review in characters after
+	// sanitization. Zero or negative means MaxReviewLength.
+	MaxLength int
+	// MinLength is the shortest accepted review in characters after
+	// sanitization. When positive an empty review is REQUIRED, e.g. to demand
+	// feedback for 1-star ratings. Zero allows empty reviews.
+	MinLength int
+	// SkipProfanityCheck leaves HasProfanity and MaskedText unset.
+	SkipProfanityCheck bool
+	// SkipSpamCheck leaves SpamFlags unset.
+	SkipSpamCheck bool
+	// Sanitizer replaces the built-in SanitizeReviewText pipeline when set.
+	Sanitizer *sanitize.Sanitizer
+	// ProfanityReviewThreshold is the lowest profanity severity that sets
+	// RequiresReview, e.g. ProfanityStrong to let mild language through.
+	// Zero means ProfanityMild: any profanity requires review.
+	ProfanityReviewThreshold int
+	// ProfanityRejectThreshold is the lowest profanity severity that rejects
+	// the review as NOT_ALLOWED, e.g. ProfanitySevere. Zero never rejects.
+	ProfanityRejectThreshold int
+	// RejectEmojiOnly reports reviews made only of emoji as INVALID_FORMAT
+	// instead of accepting them with ReviewResult.EmojiOnly set.
+	RejectEmojiOnly bool
+	// RedactLinks replaces links in ReviewResult.Text and MaskedText with
+	// LinkPlaceholder.
+	RedactLinks bool
+	// Workers is the number of goroutines ProcessReviews uses. Zero or
+	// negative means runtime.GOMAXPROCS(0). It does not affect single
+	// reviews.
+	Workers int
+	// DuplicateThreshold is the SimilarityRatio at or above which
+	// ProcessReviews groups two reviews of at least MinSimilarityWords words
+	// as duplicates. Zero or negative means DefaultDuplicateSimilarity;
+	// above 1 only identical fingerprints are grouped.
+	DuplicateThreshold float64
+}
+
 // ProcessReview validates, sanitizes, and checks a review for profanity.
 // Returns a ReviewResult with all processing information.
 func ProcessReview(text string) (ReviewResult, error) {
+	return ProcessReviewWithOptions(text, ReviewOptions{})
+}
+
+// ProcessReviewWithOptions is ProcessReview with configurable length limits,
+// checks, and sanitization. Length errors report the configured limits.
+func ProcessReviewWithOptions(text string, opts ReviewOptions) (ReviewResult, error) {
 	result := ReviewResult{
 		OriginalLength: len([]rune(text)),
 	}
 
 	// Sanitize
-	sanitized := SanitizeReviewText(text)
+	var sanitized string
+	if opts.Sanitizer != nil {
+		sanitized = opts.Sanitizer.Apply(text)
+	} else {
+		sanitized = SanitizeReviewText(text)
+	}
 	result.Text = sanitized
 	result.SanitizedLength = len([]rune(sanitized))
 
 	// Validate
-	if err := ValidateReviewText(sanitized); err != nil {
+	maxLength := opts.MaxLength
+	if maxLength <= 0 {
+		maxLength = MaxReviewLength
+	}
+	if err := validateTextLength("review", sanitized, opts.MinLength, maxLength); err != nil {
 		return result, err
 	}
 
-	// Check profanity
-	result.HasProfanity = CheckProfanity(sanitized)
-	result.RequiresReview = result.HasProfanity
+	result.EmojiOnly = AnalyzeEmoji(sanitized).EmojiOnly
+	if result.EmojiOnly && opts.RejectEmojiOnly {
+		return result, valerrors.InvalidFormat("review", "text, not only emoji")
+	}
+
+	result.ContainsLinks = ContainsLinks(text)
+	if opts.RedactLinks {
+		sanitized = RedactLinks(sanitized)
+		result.Text = sanitized
+	}
+
+	result.Language = DetectLanguage(sanitized)
+
+	// Check profanity; detection and masking share one snapshot of the list
+	if !opts.SkipProfanityCheck {
+		if matches := findProfanity(profanitySnapshot(), sanitized); len(matches) > 0 {
+			result.HasProfanity = true
+			result.MaxSeverity = maxSeverity(matches)
+			result.MaskedText = maskMatches(sanitized, matches)
+		}
+	}
+	if !opts.SkipSpamCheck {
+		result.SpamFlags = CheckSpam(sanitized).Flags
+	}
+
+	result.RequiresReview = result.MaxSeverity >= opts.profanityReviewThreshold() ||
+		len(result.SpamFlags) > 0 || result.ContainsLinks
 
-	return result, nil
+	return result, opts.rejectProfanity("review", result.MaxSeverity)
+}
+
+// profanityReviewThreshold returns the lowest profanity severity that
+// requires review.
+func (opts ReviewOptions) profanityReviewThreshold() int {
+	if opts.ProfanityReviewThreshold <= ProfanityClean {
+		return ProfanityMild
+	}
+	return opts.ProfanityReviewThreshold
+}
+
+// rejectProfanity returns NOT_ALLOWED on field if severity reaches
+// ProfanityRejectThreshold.
+func (opts ReviewOptions) rejectProfanity(field string, severity int) error {
+	if opts.ProfanityRejectThreshold > ProfanityClean && severity >= opts.ProfanityRejectThreshold {
+		return valerrors.New(field, valerrors.CodeNotAllowed, field+" contains prohibited language").
+			WithParam("severity", severity)
+	}
+	return nil
 }
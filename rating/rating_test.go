@@ -1,12 +1,15 @@
 package rating
 
 import (
+	"math"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/Dorico-Dynamics/txova-go-types/rating"
 
 	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+	"github.com/Dorico-Dynamics/txova-go-validation/sanitize"
 )
 
 func TestValidateRating(t *testing.T) {
@@ -158,6 +161,8 @@ func TestCheckProfanity(t *testing.T) {
 		{"english profanity", "This was shit service", true},
 		{"english profanity 2", "What the fuck", true},
 		{"english embedded", "This is bullshit", true},
+		{"embedded in longer words", "A classic assessment of Dickson", false},
+		{"surrounded by punctuation", "(shit!)", true},
 
 		// Portuguese profanity
 		{"portuguese profanity", "Que merda de serviço", true},
@@ -183,6 +188,78 @@ func TestCheckProfanity(t *testing.T) {
 	}
 }
 
+func TestValidateRatingFloat(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		wantCode string
+	}{
+		{"below range 0.5", 0.5, valerrors.CodeOutOfRange},
+		{"minimum 1.0", 1.0, ""},
+		{"half star 3.5", 3.5, ""},
+		{"whole star 4.0", 4.0, ""},
+		{"maximum 5.0", 5.0, ""},
+		{"above range 5.5", 5.5, valerrors.CodeOutOfRange},
+		{"not a step 3.7", 3.7, valerrors.CodeInvalidFormat},
+		{"negative", -1, valerrors.CodeOutOfRange},
+		{"NaN", math.NaN(), valerrors.CodeInvalidFormat},
+		{"infinity", math.Inf(1), valerrors.CodeInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRatingFloat(tt.value)
+			if got := IsValidRatingFloat(tt.value); got != (tt.wantCode == "") {
+				t.Errorf("IsValidRatingFloat(%v) = %v", tt.value, got)
+			}
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("ValidateRatingFloat(%v) error = %v, want nil", tt.value, err)
+				}
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Field != "rating" || ve.Code != tt.wantCode {
+				t.Errorf("ValidateRatingFloat(%v) error = %v, want rating/%s", tt.value, err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestValidateRatingFloatWithStep(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     float64
+		step      float64
+		wantField string
+	}{
+		{"quarter star", 3.75, 0.25, ""},
+		{"tenth", 4.3, 0.1, ""},
+		{"whole stars only", 4.0, 1, ""},
+		{"half star with whole step", 3.5, 1, "rating"},
+		{"zero step", 3, 0, "step"},
+		{"negative step", 3, -0.5, "step"},
+		{"NaN step", 3, math.NaN(), "step"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRatingFloatWithStep(tt.value, tt.step)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateRatingFloatWithStep(%v, %v) error = %v, want nil", tt.value, tt.step, err)
+				}
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Field != tt.wantField || ve.Code != valerrors.CodeInvalidFormat {
+				t.Errorf("ValidateRatingFloatWithStep(%v, %v) error = %v, want %s/INVALID_FORMAT",
+					tt.value, tt.step, err, tt.wantField)
+			}
+		})
+	}
+}
+
 func TestIsValidRating(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -263,6 +340,9 @@ func TestProcessReview(t *testing.T) {
 		if result.HasProfanity {
 			t.Error("HasProfanity = true, want false")
 		}
+		if result.MaskedText != "" {
+			t.Errorf("MaskedText = %q, want empty", result.MaskedText)
+		}
 		if result.RequiresReview {
 			t.Error("RequiresReview = true, want false")
 		}
@@ -295,6 +375,9 @@ func TestProcessReview(t *testing.T) {
 		if !result.RequiresReview {
 			t.Error("RequiresReview = false, want true")
 		}
+		if result.MaskedText != "This was ****" {
+			t.Errorf("MaskedText = %q, want %q", result.MaskedText, "This was ****")
+		}
 	})
 
 	t.Run("review too long", func(t *testing.T) {
@@ -305,6 +388,126 @@ func TestProcessReview(t *testing.T) {
 	})
 }
 
+func TestProcessReviewWithOptions_Defaults(t *testing.T) {
+	inputs := []string{
+		"",
+		"  <b>Great driver!</b>  ",
+		"This was shit",
+		"asdfasdfasdf",
+		"O motorista foi muito simpático e pontual",
+		strings.Repeat("a", MaxReviewLength+1),
+	}
+
+	for _, text := range inputs {
+		want, wantErr := ProcessReview(text)
+		got, gotErr := ProcessReviewWithOptions(text, ReviewOptions{})
+		if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(gotErr, wantErr) {
+			t.Errorf("ProcessReviewWithOptions(%q, defaults) = %+v, %v; want %+v, %v", text, got, gotErr, want, wantErr)
+		}
+	}
+}
+
+func TestProcessReviewWithOptions(t *testing.T) {
+	t.Run("max length", func(t *testing.T) {
+		opts := ReviewOptions{MaxLength: 200}
+		if _, err := ProcessReviewWithOptions(strings.Repeat("a", 200), opts); err != nil {
+			t.Errorf("ProcessReviewWithOptions() at the limit error = %v", err)
+		}
+		_, err := ProcessReviewWithOptions(strings.Repeat("a", 201), opts)
+		ve, ok := err.(valerrors.ValidationError)
+		if !ok || ve.Code != valerrors.CodeTooLong || ve.Param("max_length") != 200 ||
+			!strings.Contains(ve.Message, "200") {
+			t.Errorf("ProcessReviewWithOptions() error = %+v, want TOO_LONG with max_length 200", err)
+		}
+
+		long := strings.Repeat("Good ride. ", 150)
+		if _, err := ProcessReviewWithOptions(long, ReviewOptions{MaxLength: 2000}); err != nil {
+			t.Errorf("ProcessReviewWithOptions() with MaxLength 2000 error = %v", err)
+		}
+	})
+
+	t.Run("min length", func(t *testing.T) {
+		opts := ReviewOptions{MinLength: 10}
+		tests := []struct {
+			text     string
+			wantCode string
+		}{
+			{"  <p></p> ", valerrors.CodeRequired},
+			{"Bad", valerrors.CodeTooShort},
+			{"Terrible driver", ""},
+		}
+		for _, tt := range tests {
+			_, err := ProcessReviewWithOptions(tt.text, opts)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("ProcessReviewWithOptions(%q) error = %v", tt.text, err)
+				}
+				continue
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Code != tt.wantCode || ve.Param("min_length") != 10 {
+				t.Errorf("ProcessReviewWithOptions(%q) error = %+v, want %s with min_length 10", tt.text, err, tt.wantCode)
+			}
+		}
+	})
+
+	t.Run("skip checks", func(t *testing.T) {
+		result, err := ProcessReviewWithOptions("SHIT SHIT SHIT SHIT", ReviewOptions{
+			SkipProfanityCheck: true,
+			SkipSpamCheck:      true,
+		})
+		if err != nil {
+			t.Fatalf("ProcessReviewWithOptions() error = %v", err)
+		}
+		if result.HasProfanity || result.MaskedText != "" || result.SpamFlags != nil || result.RequiresReview {
+			t.Errorf("ProcessReviewWithOptions() = %+v, want checks skipped", result)
+		}
+	})
+
+	t.Run("custom sanitizer", func(t *testing.T) {
+		s := sanitize.NewSanitizer().TrimWhitespace().Custom(strings.ToLower)
+		result, err := ProcessReviewWithOptions("  <b>Great</b>  ", ReviewOptions{Sanitizer: s})
+		if err != nil {
+			t.Fatalf("ProcessReviewWithOptions() error = %v", err)
+		}
+		if result.Text != "<b>great</b>" {
+			t.Errorf("Text = %q, want %q", result.Text, "<b>great</b>")
+		}
+	})
+}
+
+func TestProcessReviewWithOptions_ProfanityThresholds(t *testing.T) {
+	strongOnly := ReviewOptions{ProfanityReviewThreshold: ProfanityStrong}
+
+	result, err := ProcessReviewWithOptions("Damn, the traffic was slow", strongOnly)
+	if err != nil {
+		t.Fatalf("ProcessReviewWithOptions() error = %v", err)
+	}
+	if !result.HasProfanity || result.MaxSeverity != ProfanityMild || result.RequiresReview {
+		t.Errorf("mild review = %+v, want HasProfanity without RequiresReview", result)
+	}
+
+	result, _ = ProcessReviewWithOptions("This driver is a bitch", strongOnly)
+	if result.MaxSeverity != ProfanitySevere || !result.RequiresReview {
+		t.Errorf("severe review = %+v, want RequiresReview", result)
+	}
+
+	result, _ = ProcessReview("Damn, the traffic was slow")
+	if !result.RequiresReview {
+		t.Error("ProcessReview() default threshold should require review for mild language")
+	}
+
+	reject := ReviewOptions{ProfanityRejectThreshold: ProfanitySevere}
+	_, err = ProcessReviewWithOptions("Este motorista é um fdp", reject)
+	ve, ok := err.(valerrors.ValidationError)
+	if !ok || ve.Field != "review" || ve.Code != valerrors.CodeNotAllowed || ve.Param("severity") != ProfanitySevere {
+		t.Errorf("ProcessReviewWithOptions() error = %v, want review/NOT_ALLOWED with severity", err)
+	}
+	if _, err := ProcessReviewWithOptions("Que merda de trânsito", reject); err != nil {
+		t.Errorf("ProcessReviewWithOptions() strong review error = %v, want nil", err)
+	}
+}
+
 func TestConstants(t *testing.T) {
 	// Verify constants match PRD
 	if MinReviewLength != 0 {
@@ -322,3 +525,62 @@ func TestConstants(t *testing.T) {
 		t.Errorf("MaxRating = %d, want 5", rating.MaxRating)
 	}
 }
+
+func TestValidateMinimumLowRatingComment(t *testing.T) {
+	tests := []struct {
+		name      string
+		rating    int
+		comment   string
+		minLength int
+		wantErr   bool
+		errCode   string
+	}{
+		// High ratings never require a comment
+		{"rating 3 no comment", 3, "", 20, false, ""},
+		{"rating 5 no comment", 5, "", 20, false, ""},
+
+		// Low ratings require a comment
+		{"rating 1 no comment", 1, "", 20, true, valerrors.CodeRequired},
+		{"rating 2 whitespace only", 2, "   ", 20, true, valerrors.CodeRequired},
+		{"rating 2 html only", 2, "<b></b>", 20, true, valerrors.CodeRequired},
+		{"rating 1 too short", 1, "bad", 20, true, valerrors.CodeTooShort},
+		{"rating 2 short after sanitizing", 2, "<p>too     short</p>", 15, true, valerrors.CodeTooShort},
+		{"rating 1 long enough", 1, "Driver was rude and took a detour", 20, false, ""},
+		{"rating 2 exact length", 2, "abcde", 5, false, ""},
+		{"rating 1 unicode counted as runes", 1, "ãããããã", 6, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMinimumLowRatingComment(tt.rating, tt.comment, tt.minLength)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMinimumLowRatingComment(%d, %q, %d) error = %v, wantErr %v", tt.rating, tt.comment, tt.minLength, err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				ve, ok := err.(valerrors.ValidationError)
+				if !ok {
+					t.Fatalf("expected ValidationError, got %T", err)
+				}
+				if ve.Code != tt.errCode {
+					t.Errorf("error code = %v, want %v", ve.Code, tt.errCode)
+				}
+				if ve.Field != "review" {
+					t.Errorf("error field = %v, want review", ve.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateLowRatingCommentDefault(t *testing.T) {
+	if err := ValidateLowRatingCommentDefault(1, "too short"); err == nil {
+		t.Error("expected error for comment shorter than default minimum")
+	}
+	if err := ValidateLowRatingCommentDefault(1, "The car was dirty and smelled bad"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateLowRatingCommentDefault(4, ""); err != nil {
+		t.Errorf("unexpected error for high rating: %v", err)
+	}
+}
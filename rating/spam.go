@@ -0,0 +1,114 @@
+package rating
+
+import (
+	"unicode"
+)
+
+// Spam reason codes reported by CheckSpam.
+const (
+	// SpamRepeatedCharacters flags a letter or digit repeated more than
+	// SpamMaxRepeatedRunes times in a row, e.g. "aaaaaaaaaa".
+	SpamRepeatedCharacters = "REPEATED_CHARACTERS"
+	// SpamLowDiversity flags text made of very few distinct characters, e.g.
+	// keyboard mashing such as "asdfasdfasdf".
+	SpamLowDiversity = "LOW_DIVERSITY"
+	// SpamExcessiveCaps flags text that is mostly uppercase.
+	SpamExcessiveCaps = "EXCESSIVE_CAPS"
+	// SpamExcessivePunctuation flags a run of more than SpamMaxPunctuationRun
+	// punctuation marks, e.g. "!!!!!!".
+	SpamExcessivePunctuation = "EXCESSIVE_PUNCTUATION"
+)
+
+// Spam detection thresholds.
+const (
+	// SpamMaxRepeatedRunes is the most times a letter or digit may repeat consecutively.
+	SpamMaxRepeatedRunes = 5
+	// SpamMinDiversityLength is the fewest letters and digits needed before
+	// diversity is checked.
+	SpamMinDiversityLength = 10
+	// SpamDiversityWindow caps the length diversity is measured against, since
+	// the alphabet limits how many distinct characters long text can have.
+	SpamDiversityWindow = 40
+	// SpamMinDiversityRatio is the lowest acceptable ratio of distinct letters
+	// and digits to their count, capped at SpamDiversityWindow.
+	SpamMinDiversityRatio = 0.35
+	// SpamMinCapsLetters is the fewest letters needed before the caps ratio is checked.
+	SpamMinCapsLetters = 10
+	// SpamMaxCapsRatio is the highest acceptable share of uppercase letters.
+	SpamMaxCapsRatio = 0.7
+	// SpamMaxPunctuationRun is the most punctuation marks allowed in a row.
+	SpamMaxPunctuationRun = 3
+)
+
+// SpamResult holds the outcome of CheckSpam.
+type SpamResult struct {
+	// Flags lists the spam reason codes that fired, in the order they are
+	// documented. It is empty for clean text.
+	Flags []string
+}
+
+// IsSpam returns true if any spam check fired.
+func (r SpamResult) IsSpam() bool {
+	return len(r.Flags) > 0
+}
+
+// CheckSpam looks for signs of spam or low-quality text: long runs of one
+// character, very few distinct characters, mostly uppercase letters, and long
+// punctuation runs. Characters are counted as runes, case-insensitively, so
+// accented letters count like any other.
+func CheckSpam(text string) SpamResult {
+	var (
+		letters, upper, alnum int
+		distinct              = make(map[rune]bool)
+		prev                  rune
+		repeat, punctRun      int
+		repeated, punctuated  bool
+	)
+
+	for _, r := range text {
+		isAlnum := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isAlnum {
+			alnum++
+			distinct[unicode.ToLower(r)] = true
+			if unicode.ToLower(r) == unicode.ToLower(prev) {
+				repeat++
+			} else {
+				repeat = 1
+			}
+			repeated = repeated || repeat > SpamMaxRepeatedRunes
+		} else {
+			repeat = 0
+		}
+		prev = r
+
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+
+		if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			punctRun++
+			punctuated = punctuated || punctRun > SpamMaxPunctuationRun
+		} else {
+			punctRun = 0
+		}
+	}
+
+	var result SpamResult
+	if repeated {
+		result.Flags = append(result.Flags, SpamRepeatedCharacters)
+	}
+	if alnum >= SpamMinDiversityLength &&
+		float64(len(distinct))/float64(min(alnum, SpamDiversityWindow)) < SpamMinDiversityRatio {
+		result.Flags = append(result.Flags, SpamLowDiversity)
+	}
+	if letters >= SpamMinCapsLetters && float64(upper)/float64(letters) > SpamMaxCapsRatio {
+		result.Flags = append(result.Flags, SpamExcessiveCaps)
+	}
+	if punctuated {
+		result.Flags = append(result.Flags, SpamExcessivePunctuation)
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package rating
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheckSpam(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		// Spam
+		{"keyboard mashing", "asdfasdfasdf", []string{SpamLowDiversity}},
+		{"repeated character", strings.Repeat("a", 200), []string{SpamRepeatedCharacters, SpamLowDiversity}},
+		{"repeated within review", "Great driverrrrrrrr", []string{SpamRepeatedCharacters}},
+		{"shouting", "THIS DRIVER WAS TERRIBLE AND RUDE", []string{SpamExcessiveCaps}},
+		{"punctuation run", "Bad driver!!!!!!", []string{SpamExcessivePunctuation}},
+		{"mixed punctuation run", "Why?!?!?", []string{SpamExcessivePunctuation}},
+		{"everything", "AAAAAAAAAAAA!!!!", []string{
+			SpamRepeatedCharacters, SpamLowDiversity, SpamExcessiveCaps, SpamExcessivePunctuation,
+		}},
+
+		// Legitimate
+		{"enthusiastic", "GREAT driver!!", nil},
+		{"ellipsis", "It was ok...", nil},
+		{"portuguese with accents", "Ótimo serviço, o motorista foi muito simpático e atencioso.", nil},
+		{"portuguese caps", "MUITO BOM", nil},
+		{"short", "ok", nil},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CheckSpam(tt.text)
+			if !reflect.DeepEqual(got.Flags, tt.want) {
+				t.Errorf("CheckSpam(%q).Flags = %v, want %v", tt.text, got.Flags, tt.want)
+			}
+			if got.IsSpam() != (len(tt.want) > 0) {
+				t.Errorf("CheckSpam(%q).IsSpam() = %v", tt.text, got.IsSpam())
+			}
+		})
+	}
+}
+
+func TestCheckSpam_CountsRunes(t *testing.T) {
+	// Ten accented letters are ten runes, not twenty bytes, so five distinct
+	// letters are enough diversity.
+	if got := CheckSpam("áéíóú áéíóú"); got.IsSpam() {
+		t.Errorf("CheckSpam() = %v, want no flags", got.Flags)
+	}
+	if got := CheckSpam("ããããããã"); !reflect.DeepEqual(got.Flags, []string{SpamRepeatedCharacters}) {
+		t.Errorf("CheckSpam() = %v, want repeated characters", got.Flags)
+	}
+}
+
+func TestCheckSpam_Corpus(t *testing.T) {
+	for _, file := range []string{"testdata/reviews_pt.txt", "testdata/reviews_en.txt"} {
+		for _, review := range readCorpus(t, file) {
+			if got := CheckSpam(review); got.IsSpam() {
+				t.Errorf("CheckSpam(%q) = %v, want no flags for a genuine review", review, got.Flags)
+			}
+		}
+	}
+}
+
+func TestProcessReview_Spam(t *testing.T) {
+	result, err := ProcessReview("asdfasdfasdf")
+	if err != nil {
+		t.Fatalf("ProcessReview() error = %v", err)
+	}
+	if !reflect.DeepEqual(result.SpamFlags, []string{SpamLowDiversity}) || !result.RequiresReview {
+		t.Errorf("ProcessReview() = %+v, want SpamFlags [%s] and RequiresReview", result, SpamLowDiversity)
+	}
+	if result.HasProfanity {
+		t.Error("HasProfanity = true, want false")
+	}
+
+	result, _ = ProcessReview("GREAT driver!!")
+	if result.SpamFlags != nil || result.RequiresReview {
+		t.Errorf("ProcessReview() = %+v, want no spam flags", result)
+	}
+}
@@ -0,0 +1,108 @@
+package rating
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/Dorico-Dynamics/txova-go-types/rating"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// RatingSummaryEpsilon is the largest accepted difference between a summary's
+// Average and the mean of its histogram.
+const RatingSummaryEpsilon = 0.01
+
+// summaryFloatTolerance absorbs floating-point error when comparing against
+// RatingSummaryEpsilon, so an average exactly at the limit passes.
+const summaryFloatTolerance = 1e-9
+
+// RatingSummary is an aggregate of a driver's or rider's ratings.
+type RatingSummary struct {
+	// Average is the mean rating, or 0 when Count is 0.
+	Average float64
+	// Count is the number of ratings.
+	Count int
+	// Histogram maps each star value from 1 to 5 to the number of ratings
+	// with that value. Missing stars count as zero.
+	Histogram map[int]int
+}
+
+// ValidateRatingSummary checks that a RatingSummary is internally consistent:
+// histogram keys are 1-5 with non-negative counts, the counts add up to Count,
+// and Average is within RatingSummaryEpsilon of the histogram's mean. A
+// summary with Count 0 must have Average 0 and no ratings in the histogram.
+// Errors use the fields "average", "count", and paths such as "histogram[3]",
+// with histogram entries in star order. The totals are only compared when
+// every field is valid on its own. Returns nil if the summary is consistent.
+func ValidateRatingSummary(s RatingSummary) valerrors.ValidationErrors {
+	minStar, maxStar := int(rating.MinRating), int(rating.MaxRating)
+	var errs valerrors.ValidationErrors
+
+	switch {
+	case math.IsNaN(s.Average) || math.IsInf(s.Average, 0):
+		errs.Add(valerrors.InvalidFormatWithValue("average", "finite number", s.Average))
+	case s.Count > 0 && (s.Average < float64(minStar) || s.Average > float64(maxStar)):
+		errs.Add(valerrors.OutOfRangeWithValue("average", float64(minStar), float64(maxStar), s.Average))
+	}
+	if s.Count < 0 {
+		errs.Add(negativeCountError("count", s.Count))
+	}
+
+	stars := make([]int, 0, len(s.Histogram))
+	for star := range s.Histogram {
+		stars = append(stars, star)
+	}
+	sort.Ints(stars)
+
+	var total, weighted int
+	for _, star := range stars {
+		field := histogramField(star)
+		n := s.Histogram[star]
+		switch {
+		case star < minStar || star > maxStar:
+			errs.Add(valerrors.OutOfRangeWithValue(field, minStar, maxStar, star))
+		case n < 0:
+			errs.Add(negativeCountError(field, n))
+		default:
+			total += n
+			weighted += star * n
+		}
+	}
+	if errs.HasErrors() {
+		return errs
+	}
+
+	if total != s.Count {
+		ve := valerrors.OutOfRangeWithValue("count", total, total, s.Count)
+		ve.Message = fmt.Sprintf("count does not match the histogram total of %d", total)
+		errs.Add(ve.WithParam("histogram_total", total))
+		return errs
+	}
+
+	var mean float64
+	if total > 0 {
+		mean = float64(weighted) / float64(total)
+	}
+	if s.Count == 0 && s.Average != 0 {
+		errs.Add(valerrors.OutOfRangeWithValue("average", 0, 0, s.Average))
+	} else if math.Abs(s.Average-mean) > RatingSummaryEpsilon+summaryFloatTolerance {
+		ve := valerrors.OutOfRangeWithValue("average", mean-RatingSummaryEpsilon, mean+RatingSummaryEpsilon, s.Average)
+		ve.Message = fmt.Sprintf("average does not match the histogram mean of %.2f", mean)
+		errs.Add(ve.WithParam("histogram_mean", mean))
+	}
+	return errs
+}
+
+// negativeCountError reports a count below zero.
+func negativeCountError(field string, n int) valerrors.ValidationError {
+	return valerrors.NewWithValue(field, valerrors.CodeOutOfRange, field+" must not be negative", n).
+		WithParam("min", 0)
+}
+
+// histogramField returns the field path for a histogram entry.
+func histogramField(star int) string {
+	return "histogram[" + strconv.Itoa(star) + "]"
+}
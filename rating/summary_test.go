@@ -0,0 +1,81 @@
+package rating
+
+import (
+	"math"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateRatingSummary(t *testing.T) {
+	tests := []struct {
+		name      string
+		summary   RatingSummary
+		wantField string
+		wantCode  string
+	}{
+		{"consistent", RatingSummary{4.5, 4, map[int]int{4: 2, 5: 2}}, "", ""},
+		{"rounded average", RatingSummary{4.33, 3, map[int]int{4: 2, 5: 1}}, "", ""},
+		{"zero entries ignored", RatingSummary{5, 1, map[int]int{1: 0, 5: 1}}, "", ""},
+		{"average exactly at epsilon", RatingSummary{4.01, 2, map[int]int{3: 1, 5: 1}}, "", ""},
+		{"average just past epsilon", RatingSummary{4.0101, 2, map[int]int{3: 1, 5: 1}}, "average", valerrors.CodeOutOfRange},
+		{"empty", RatingSummary{0, 0, nil}, "", ""},
+		{"empty with zero entries", RatingSummary{0, 0, map[int]int{3: 0}}, "", ""},
+		{"sum mismatch", RatingSummary{4.5, 5, map[int]int{4: 2, 5: 2}}, "count", valerrors.CodeOutOfRange},
+		{"empty count with ratings", RatingSummary{0, 0, map[int]int{5: 1}}, "count", valerrors.CodeOutOfRange},
+		{"empty count with average", RatingSummary{4.2, 0, nil}, "average", valerrors.CodeOutOfRange},
+		{"average out of range", RatingSummary{5.5, 1, map[int]int{5: 1}}, "average", valerrors.CodeOutOfRange},
+		{"average NaN", RatingSummary{math.NaN(), 1, map[int]int{5: 1}}, "average", valerrors.CodeInvalidFormat},
+		{"negative count", RatingSummary{0, -1, nil}, "count", valerrors.CodeOutOfRange},
+		{"star out of range", RatingSummary{5, 1, map[int]int{5: 1, 6: 2}}, "histogram[6]", valerrors.CodeOutOfRange},
+		{"negative star count", RatingSummary{5, 1, map[int]int{3: -1, 5: 2}}, "histogram[3]", valerrors.CodeOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateRatingSummary(tt.summary)
+			if tt.wantField == "" {
+				if errs != nil {
+					t.Errorf("ValidateRatingSummary() = %v, want nil", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("ValidateRatingSummary() = %v, want one error", errs)
+			}
+			if errs[0].Field != tt.wantField || errs[0].Code != tt.wantCode {
+				t.Errorf("ValidateRatingSummary() error = %s/%s, want %s/%s",
+					errs[0].Field, errs[0].Code, tt.wantField, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestValidateRatingSummary_Params(t *testing.T) {
+	errs := ValidateRatingSummary(RatingSummary{3, 2, map[int]int{5: 2}})
+	if len(errs) != 1 || errs[0].Param("histogram_mean") != 5.0 {
+		t.Errorf("ValidateRatingSummary() = %v, want histogram_mean 5", errs)
+	}
+
+	errs = ValidateRatingSummary(RatingSummary{5, 3, map[int]int{5: 2}})
+	if len(errs) != 1 || errs[0].Param("histogram_total") != 2 {
+		t.Errorf("ValidateRatingSummary() = %v, want histogram_total 2", errs)
+	}
+}
+
+func TestValidateRatingSummary_MultipleErrors(t *testing.T) {
+	errs := ValidateRatingSummary(RatingSummary{
+		Average:   math.Inf(1),
+		Count:     -2,
+		Histogram: map[int]int{0: 1, 2: -3},
+	})
+	want := []string{"average", "count", "histogram[0]", "histogram[2]"}
+	if len(errs) != len(want) {
+		t.Fatalf("ValidateRatingSummary() = %v, want %d errors", errs, len(want))
+	}
+	for i, field := range want {
+		if errs[i].Field != field {
+			t.Errorf("errs[%d].Field = %q, want %q", i, errs[i].Field, field)
+		}
+	}
+}
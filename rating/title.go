@@ -0,0 +1,66 @@
+package rating
+
+import "strings"
+
+// Review title constraints.
+const (
+	MinTitleLength = 0
+	MaxTitleLength = 80
+)
+
+// ValidateReviewTitle validates the length of a review title. Titles are
+// optional but must not exceed MaxTitleLength characters. Errors are reported
+// on "title".
+func ValidateReviewTitle(title string) error {
+	return validateTextLength("title", title, MinTitleLength, MaxTitleLength)
+}
+
+// SanitizeReviewTitle sanitizes a review title by stripping HTML tags,
+// flattening it to a single line (newlines and runs of whitespace become one
+// space), and trimming leading/trailing whitespace.
+func SanitizeReviewTitle(title string) string {
+	result := htmlTagPattern.ReplaceAllString(title, "")
+	return strings.TrimSpace(normalizeWhitespace(result))
+}
+
+// ProcessReviewWithTitle is ProcessReviewWithOptions for a review with a
+// title. The title is sanitized with SanitizeReviewTitle, validated with
+// ValidateReviewTitle, and checked for profanity and links like the body,
+// with the outcome in the ReviewResult Title fields; opts.Sanitizer only
+// applies to the body. RequiresReview and the profanity thresholds consider
+// both. Errors about the title are reported on "title", errors about the body
+// on "review".
+func ProcessReviewWithTitle(title, body string, opts ReviewOptions) (ReviewResult, error) {
+	sanitizedTitle := SanitizeReviewTitle(title)
+	if err := ValidateReviewTitle(sanitizedTitle); err != nil {
+		return ReviewResult{Title: sanitizedTitle}, err
+	}
+
+	result, err := ProcessReviewWithOptions(body, opts)
+	result.Title = sanitizedTitle
+	if err != nil {
+		return result, err
+	}
+
+	result.TitleContainsLinks = ContainsLinks(title)
+	if opts.RedactLinks {
+		result.Title = RedactLinks(result.Title)
+	}
+	if !opts.SkipProfanityCheck {
+		if matches := findProfanity(profanitySnapshot(), result.Title); len(matches) > 0 {
+			result.TitleHasProfanity = true
+			result.TitleMaxSeverity = maxSeverity(matches)
+			result.TitleMaskedText = maskMatches(result.Title, matches)
+		}
+	}
+
+	result.RequiresReview = result.RequiresReview ||
+		result.TitleMaxSeverity >= opts.profanityReviewThreshold() || result.TitleContainsLinks
+
+	return result, opts.rejectProfanity("title", result.TitleMaxSeverity)
+}
+
+// IsValidReviewTitle returns true if the review title length is acceptable.
+func IsValidReviewTitle(title string) bool {
+	return ValidateReviewTitle(title) == nil
+}
@@ -0,0 +1,117 @@
+package rating
+
+import (
+	"strings"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateReviewTitle(t *testing.T) {
+	tests := []struct {
+		name    string
+		title   string
+		wantErr bool
+	}{
+		{"empty", "", false},
+		{"short", "Óptima viagem", false},
+		{"at max", strings.Repeat("á", MaxTitleLength), false},
+		{"over max", strings.Repeat("a", MaxTitleLength+1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReviewTitle(tt.title)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateReviewTitle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				ve, ok := err.(valerrors.ValidationError)
+				if !ok || ve.Field != "title" || ve.Code != valerrors.CodeTooLong {
+					t.Errorf("ValidateReviewTitle() error = %v, want title/TOO_LONG", err)
+				}
+			}
+			if IsValidReviewTitle(tt.title) == tt.wantErr {
+				t.Errorf("IsValidReviewTitle() = %v, want %v", !tt.wantErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSanitizeReviewTitle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Boa\nviagem", "Boa viagem"},
+		{"  <b>Motorista</b>\r\n\tsimpático  ", "Motorista simpático"},
+		{"Linha 1\n\nLinha 2", "Linha 1 Linha 2"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := SanitizeReviewTitle(tt.title); got != tt.want {
+			t.Errorf("SanitizeReviewTitle(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestProcessReviewWithTitle(t *testing.T) {
+	t.Run("multi-line title flattened", func(t *testing.T) {
+		result, err := ProcessReviewWithTitle("Boa\nviagem\n", "Motorista pontual", ReviewOptions{})
+		if err != nil {
+			t.Fatalf("ProcessReviewWithTitle() error = %v", err)
+		}
+		if result.Title != "Boa viagem" || result.Text != "Motorista pontual" || result.RequiresReview {
+			t.Errorf("ProcessReviewWithTitle() = %+v, want flattened clean title", result)
+		}
+	})
+
+	t.Run("over-length title", func(t *testing.T) {
+		_, err := ProcessReviewWithTitle(strings.Repeat("a", MaxTitleLength+1), "Motorista pontual", ReviewOptions{})
+		ve, ok := err.(valerrors.ValidationError)
+		if !ok || ve.Field != "title" || ve.Code != valerrors.CodeTooLong {
+			t.Errorf("ProcessReviewWithTitle() error = %v, want title/TOO_LONG", err)
+		}
+	})
+
+	t.Run("over-length body", func(t *testing.T) {
+		_, err := ProcessReviewWithTitle("Boa viagem", strings.Repeat("a", MaxReviewLength+1), ReviewOptions{})
+		ve, ok := err.(valerrors.ValidationError)
+		if !ok || ve.Field != "review" || ve.Code != valerrors.CodeTooLong {
+			t.Errorf("ProcessReviewWithTitle() error = %v, want review/TOO_LONG", err)
+		}
+	})
+
+	t.Run("profane title with clean body", func(t *testing.T) {
+		result, err := ProcessReviewWithTitle("Que merda", "O carro estava sujo", ReviewOptions{})
+		if err != nil {
+			t.Fatalf("ProcessReviewWithTitle() error = %v", err)
+		}
+		if result.HasProfanity || !result.TitleHasProfanity || !result.RequiresReview {
+			t.Errorf("ProcessReviewWithTitle() = %+v, want title-only profanity requiring review", result)
+		}
+		if result.TitleMaskedText != "Que *****" || result.TitleMaxSeverity != ProfanityStrong {
+			t.Errorf("TitleMaskedText = %q, TitleMaxSeverity = %d", result.TitleMaskedText, result.TitleMaxSeverity)
+		}
+	})
+
+	t.Run("profane title rejected", func(t *testing.T) {
+		_, err := ProcessReviewWithTitle("Filho da puta", "O carro estava sujo",
+			ReviewOptions{ProfanityRejectThreshold: ProfanitySevere})
+		ve, ok := err.(valerrors.ValidationError)
+		if !ok || ve.Field != "title" || ve.Code != valerrors.CodeNotAllowed {
+			t.Errorf("ProcessReviewWithTitle() error = %v, want title/NOT_ALLOWED", err)
+		}
+	})
+
+	t.Run("link in title", func(t *testing.T) {
+		result, err := ProcessReviewWithTitle("Vejam wa.me/258841234567", "Bom", ReviewOptions{RedactLinks: true})
+		if err != nil {
+			t.Fatalf("ProcessReviewWithTitle() error = %v", err)
+		}
+		if !result.TitleContainsLinks || result.ContainsLinks || !result.RequiresReview || result.Title != "Vejam [link]" {
+			t.Errorf("ProcessReviewWithTitle() = %+v, want redacted title link", result)
+		}
+	})
+}
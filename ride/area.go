@@ -0,0 +1,163 @@
+package ride
+
+import (
+	"math"
+	"sync"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+	geoval "github.com/Dorico-Dynamics/txova-go-validation/geo"
+)
+
+// AreaPolicy holds the fare and distance limits of one service area. A zero
+// field inherits the global limit (MinFareCentavos, MaxFareCentavos,
+// MinDistanceKM, or MaxDistanceKM), so a policy only needs to set the limits
+// that differ.
+type AreaPolicy struct {
+	MinFareCentavos int64
+	MaxFareCentavos int64
+	MinDistanceKM   float64
+	MaxDistanceKM   float64
+}
+
+// DefaultAreaPolicy returns the global fare and distance limits.
+func DefaultAreaPolicy() AreaPolicy {
+	return AreaPolicy{
+		MinFareCentavos: MinFareCentavos,
+		MaxFareCentavos: MaxFareCentavos,
+		MinDistanceKM:   MinDistanceKM,
+		MaxDistanceKM:   MaxDistanceKM,
+	}
+}
+
+// withDefaults returns p with zero fields replaced by the global limits.
+func (p AreaPolicy) withDefaults() AreaPolicy {
+	d := DefaultAreaPolicy()
+	if p.MinFareCentavos == 0 {
+		p.MinFareCentavos = d.MinFareCentavos
+	}
+	if p.MaxFareCentavos == 0 {
+		p.MaxFareCentavos = d.MaxFareCentavos
+	}
+	if p.MinDistanceKM == 0 {
+		p.MinDistanceKM = d.MinDistanceKM
+	}
+	if p.MaxDistanceKM == 0 {
+		p.MaxDistanceKM = d.MaxDistanceKM
+	}
+	return p
+}
+
+// areaPoliciesMu guards areaPolicies.
+var areaPoliciesMu sync.RWMutex
+
+// areaPolicies maps service area names to their policies, with defaults
+// already applied.
+var areaPolicies = map[string]AreaPolicy{}
+
+// AreaOption configures ValidateFareInArea and ValidateDistanceInArea.
+type AreaOption func(*areaOptions)
+
+// areaOptions holds the settings applied by AreaOption values.
+type areaOptions struct {
+	knownAreasOnly bool
+}
+
+// WithKnownAreasOnly rejects area names that are not registered geo service
+// areas with INVALID_OPTION on "area", instead of validating them against
+// the global limits.
+func WithKnownAreasOnly() AreaOption {
+	return func(o *areaOptions) {
+		o.knownAreasOnly = true
+	}
+}
+
+// SetAreaFarePolicy sets the fare and distance limits for the service area
+// with the given geo service area name, replacing any earlier policy. Zero
+// fields inherit the global limits. Returns REQUIRED on "area" for an empty
+// name and INVALID_FORMAT on "area_policy" if a limit is negative, NaN, or
+// infinite, or a minimum exceeds its maximum once defaults are applied.
+// Safe for concurrent use with validation functions.
+func SetAreaFarePolicy(area string, p AreaPolicy) error {
+	if area == "" {
+		return valerrors.Required("area")
+	}
+	merged := p.withDefaults()
+	if merged.MinFareCentavos < 0 || merged.MinFareCentavos > merged.MaxFareCentavos ||
+		!(merged.MinDistanceKM >= 0) || !(merged.MaxDistanceKM >= merged.MinDistanceKM) ||
+		math.IsInf(merged.MaxDistanceKM, 1) {
+		return valerrors.InvalidFormatWithValue("area_policy", "non-negative limits with minimums not above maximums", p)
+	}
+
+	areaPoliciesMu.Lock()
+	defer areaPoliciesMu.Unlock()
+	areaPolicies[area] = merged
+	return nil
+}
+
+// RemoveAreaFarePolicy removes the policy of a service area, so it falls back
+// to the global limits. Removing an area without a policy does nothing.
+func RemoveAreaFarePolicy(area string) {
+	areaPoliciesMu.Lock()
+	defer areaPoliciesMu.Unlock()
+	delete(areaPolicies, area)
+}
+
+// EffectivePolicy returns the limits that apply in a service area: its policy
+// with defaults applied, or DefaultAreaPolicy if it has none.
+func EffectivePolicy(area string) AreaPolicy {
+	areaPoliciesMu.RLock()
+	defer areaPoliciesMu.RUnlock()
+	if p, ok := areaPolicies[area]; ok {
+		return p
+	}
+	return DefaultAreaPolicy()
+}
+
+// areaPolicyFor returns the effective policy for area, or an error if the
+// options reject the area name.
+func areaPolicyFor(area string, opts []AreaOption) (AreaPolicy, error) {
+	var o areaOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.knownAreasOnly && geoval.GetServiceArea(area) == nil {
+		return AreaPolicy{}, valerrors.InvalidOptionWithValue("area", geoval.GetServiceAreas(), area)
+	}
+	return EffectivePolicy(area), nil
+}
+
+// ValidateFareInArea validates a fare amount (in centavos) against the fare
+// limits of a service area, or the global limits if the area has no policy.
+// Returns OUT_OF_RANGE on "fare" with the limits in the "min" and "max"
+// params and the area in "area".
+func ValidateFareInArea(centavos int64, area string, opts ...AreaOption) error {
+	p, err := areaPolicyFor(area, opts)
+	if err != nil {
+		return err
+	}
+	if centavos < p.MinFareCentavos || centavos > p.MaxFareCentavos {
+		return valerrors.OutOfRangeWithValue("fare", p.MinFareCentavos, p.MaxFareCentavos, centavos).
+			WithParam("min", p.MinFareCentavos).
+			WithParam("max", p.MaxFareCentavos).
+			WithParam("area", area)
+	}
+	return nil
+}
+
+// ValidateDistanceInArea validates a ride distance in kilometers against the
+// distance limits of a service area, or the global limits if the area has no
+// policy. Returns OUT_OF_RANGE on "distance" with the limits in the "min"
+// and "max" params and the area in "area".
+func ValidateDistanceInArea(km float64, area string, opts ...AreaOption) error {
+	p, err := areaPolicyFor(area, opts)
+	if err != nil {
+		return err
+	}
+	if !(km >= p.MinDistanceKM && km <= p.MaxDistanceKM) {
+		return valerrors.OutOfRangeWithValue("distance", p.MinDistanceKM, p.MaxDistanceKM, km).
+			WithParam("min", p.MinDistanceKM).
+			WithParam("max", p.MaxDistanceKM).
+			WithParam("area", area)
+	}
+	return nil
+}
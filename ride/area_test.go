@@ -0,0 +1,152 @@
+package ride
+
+import (
+	"math"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// setAreaPolicy sets a policy for the duration of the test.
+func setAreaPolicy(t *testing.T, area string, p AreaPolicy) {
+	t.Helper()
+	if err := SetAreaFarePolicy(area, p); err != nil {
+		t.Fatalf("SetAreaFarePolicy(%q) error = %v", area, err)
+	}
+	t.Cleanup(func() { RemoveAreaFarePolicy(area) })
+}
+
+func TestValidateFareInArea(t *testing.T) {
+	setAreaPolicy(t, "beira", AreaPolicy{MinFareCentavos: 3000})
+	setAreaPolicy(t, "maputo", AreaPolicy{MinFareCentavos: 6000, MaxFareCentavos: 4000000})
+
+	tests := []struct {
+		name     string
+		centavos int64
+		area     string
+		opts     []AreaOption
+		wantErr  bool
+		errCode  string
+		wantMin  int64
+	}{
+		{"beira lower minimum accepted", 3500, "beira", nil, false, "", 0},
+		{"beira below its minimum", 2500, "beira", nil, true, valerrors.CodeOutOfRange, 3000},
+		{"beira inherits global maximum", MaxFareCentavos, "beira", nil, false, "", 0},
+		{"beira fare rejected in maputo", 3500, "maputo", nil, true, valerrors.CodeOutOfRange, 6000},
+		{"maputo minimum", 6000, "maputo", nil, false, "", 0},
+		{"maputo over its maximum", 4000001, "maputo", nil, true, valerrors.CodeOutOfRange, 6000},
+		{"area without policy uses global minimum", 3500, "matola", nil, true, valerrors.CodeOutOfRange, MinFareCentavos},
+		{"unknown area uses defaults", MinFareCentavos, "nampula", nil, false, "", 0},
+		{"unknown area rejected", MinFareCentavos, "nampula", []AreaOption{WithKnownAreasOnly()}, true, valerrors.CodeInvalidOption, 0},
+		{"known area with option", 3500, "beira", []AreaOption{WithKnownAreasOnly()}, false, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFareInArea(tt.centavos, tt.area, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateFareInArea() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			ve := err.(valerrors.ValidationError)
+			if ve.Code != tt.errCode {
+				t.Errorf("error code = %v, want %v", ve.Code, tt.errCode)
+			}
+			if tt.errCode == valerrors.CodeOutOfRange {
+				if ve.Field != "fare" || ve.Param("area") != tt.area {
+					t.Errorf("error = %s %v, want fare with area %q", ve.Field, ve.Params, tt.area)
+				}
+				if ve.Param("min") != tt.wantMin {
+					t.Errorf("min param = %v, want %d", ve.Param("min"), tt.wantMin)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateDistanceInArea(t *testing.T) {
+	setAreaPolicy(t, "beira", AreaPolicy{MinDistanceKM: 0.3, MaxDistanceKM: 80})
+
+	tests := []struct {
+		name    string
+		km      float64
+		area    string
+		wantErr bool
+	}{
+		{"beira short ride", 0.3, "beira", false},
+		{"beira below minimum", 0.2, "beira", true},
+		{"beira over maximum", 81, "beira", true},
+		{"short ride rejected in maputo", 0.3, "maputo", true},
+		{"maputo long ride", 150, "maputo", false},
+		{"NaN", math.NaN(), "beira", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDistanceInArea(tt.km, tt.area)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateDistanceInArea() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if ve := err.(valerrors.ValidationError); ve.Field != "distance" || ve.Code != valerrors.CodeOutOfRange {
+					t.Errorf("error = %s/%s, want distance/OUT_OF_RANGE", ve.Field, ve.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestEffectivePolicy(t *testing.T) {
+	if got := EffectivePolicy("beira"); got != DefaultAreaPolicy() {
+		t.Errorf("EffectivePolicy() without policy = %+v, want defaults", got)
+	}
+
+	setAreaPolicy(t, "beira", AreaPolicy{MinFareCentavos: 3000})
+	want := DefaultAreaPolicy()
+	want.MinFareCentavos = 3000
+	if got := EffectivePolicy("beira"); got != want {
+		t.Errorf("EffectivePolicy() = %+v, want %+v", got, want)
+	}
+
+	RemoveAreaFarePolicy("beira")
+	if got := EffectivePolicy("beira"); got != DefaultAreaPolicy() {
+		t.Errorf("EffectivePolicy() after removal = %+v, want defaults", got)
+	}
+}
+
+func TestSetAreaFarePolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		area    string
+		policy  AreaPolicy
+		errCode string
+	}{
+		{"empty area", "", AreaPolicy{}, valerrors.CodeRequired},
+		{"negative min fare", "beira", AreaPolicy{MinFareCentavos: -1}, valerrors.CodeInvalidFormat},
+		{"min fare above global max", "beira", AreaPolicy{MinFareCentavos: MaxFareCentavos + 1}, valerrors.CodeInvalidFormat},
+		{"min distance above max", "beira", AreaPolicy{MinDistanceKM: 10, MaxDistanceKM: 5}, valerrors.CodeInvalidFormat},
+		{"negative min distance", "beira", AreaPolicy{MinDistanceKM: -1}, valerrors.CodeInvalidFormat},
+		{"NaN distance", "beira", AreaPolicy{MinDistanceKM: math.NaN()}, valerrors.CodeInvalidFormat},
+		{"NaN max distance", "beira", AreaPolicy{MaxDistanceKM: math.NaN()}, valerrors.CodeInvalidFormat},
+		{"NaN min and max distance", "beira", AreaPolicy{MinDistanceKM: math.NaN(), MaxDistanceKM: math.NaN()}, valerrors.CodeInvalidFormat},
+		{"negative max distance", "beira", AreaPolicy{MaxDistanceKM: -5}, valerrors.CodeInvalidFormat},
+		{"infinite distance", "beira", AreaPolicy{MaxDistanceKM: math.Inf(1)}, valerrors.CodeInvalidFormat},
+		{"infinite min distance", "beira", AreaPolicy{MinDistanceKM: math.Inf(1)}, valerrors.CodeInvalidFormat},
+		{"negative infinite max distance", "beira", AreaPolicy{MaxDistanceKM: math.Inf(-1)}, valerrors.CodeInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetAreaFarePolicy(tt.area, tt.policy)
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Code != tt.errCode {
+				t.Errorf("SetAreaFarePolicy() error = %v, want %s", err, tt.errCode)
+			}
+		})
+	}
+	if got := EffectivePolicy("beira"); got != DefaultAreaPolicy() {
+		t.Errorf("rejected policy was stored: %+v", got)
+	}
+}
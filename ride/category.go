@@ -0,0 +1,199 @@
+package ride
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// Vehicle categories a rider can request.
+const (
+	VehicleCategoryEconomy = "economy"
+	VehicleCategoryComfort = "comfort"
+	VehicleCategoryXL      = "xl"
+	VehicleCategoryMoto    = "moto"
+)
+
+// DefaultCategoryMultiplier is the built-in fare multiplier for each vehicle
+// category, applied by CalculateEstimatedFareForCategory.
+// SetCategoryMultiplier overrides it at run time; changing this map after
+// startup has no effect.
+var DefaultCategoryMultiplier = map[string]float64{
+	VehicleCategoryEconomy: 1.0,
+	VehicleCategoryComfort: 1.3,
+	VehicleCategoryXL:      1.6,
+	VehicleCategoryMoto:    0.7,
+}
+
+// registeredCategoryMultiplier is the fare multiplier of a category added with
+// RegisterPassengerCapacity until SetCategoryMultiplier changes it.
+const registeredCategoryMultiplier = 1.0
+
+// vehicleCategory holds the settings of a known vehicle category.
+type vehicleCategory struct {
+	maxPassengers int
+	multiplier    float64
+}
+
+// vehicleCategoriesMu guards vehicleCategories.
+var vehicleCategoriesMu sync.RWMutex
+
+// vehicleCategories is the single registry of known vehicle categories, read
+// by category validation, fare multipliers, and passenger limits alike.
+// RegisterPassengerCapacity adds categories to it.
+var vehicleCategories = defaultVehicleCategories()
+
+// builtinVehicleCategories returns the built-in vehicle categories in order.
+func builtinVehicleCategories() []string {
+	return []string{VehicleCategoryEconomy, VehicleCategoryComfort, VehicleCategoryXL, VehicleCategoryMoto}
+}
+
+// defaultVehicleCategories returns the built-in registry, from
+// DefaultPassengerCapacity and DefaultCategoryMultiplier.
+func defaultVehicleCategories() map[string]vehicleCategory {
+	table := make(map[string]vehicleCategory, len(DefaultPassengerCapacity))
+	for category, maxPassengers := range DefaultPassengerCapacity {
+		table[category] = vehicleCategory{maxPassengers: maxPassengers, multiplier: defaultMultiplier(category)}
+	}
+	return table
+}
+
+// defaultMultiplier returns the DefaultCategoryMultiplier of a category, or
+// registeredCategoryMultiplier for a registered one.
+func defaultMultiplier(category string) float64 {
+	if multiplier, ok := DefaultCategoryMultiplier[category]; ok {
+		return multiplier
+	}
+	return registeredCategoryMultiplier
+}
+
+// lookupVehicleCategory returns the registry entry of a category, matched
+// case-insensitively.
+func lookupVehicleCategory(category string) (vehicleCategory, bool) {
+	vehicleCategoriesMu.RLock()
+	defer vehicleCategoriesMu.RUnlock()
+	c, ok := vehicleCategories[normalizeVehicleCategory(category)]
+	return c, ok
+}
+
+// AllVehicleCategories returns the vehicle categories: the built-in ones in
+// order, followed by those added with RegisterPassengerCapacity in sorted
+// order.
+func AllVehicleCategories() []string {
+	vehicleCategoriesMu.RLock()
+	defer vehicleCategoriesMu.RUnlock()
+	return categoryNames(vehicleCategories)
+}
+
+// categoryNames returns the categories of a registry in AllVehicleCategories
+// order. Callers must hold vehicleCategoriesMu.
+func categoryNames(table map[string]vehicleCategory) []string {
+	builtin := builtinVehicleCategories()
+	var registered []string
+	for category := range table {
+		if !slices.Contains(builtin, category) {
+			registered = append(registered, category)
+		}
+	}
+	sort.Strings(registered)
+	return append(builtin, registered...)
+}
+
+// normalizeVehicleCategory lowercases and trims a vehicle category.
+func normalizeVehicleCategory(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
+
+// NormalizeVehicleCategory returns the canonical form of a vehicle category,
+// matched case-insensitively and ignoring surrounding whitespace, so
+// " Comfort " becomes "comfort". Returns the ValidateVehicleCategory error
+// for an unknown category.
+func NormalizeVehicleCategory(s string) (string, error) {
+	normalized := normalizeVehicleCategory(s)
+	if _, ok := lookupVehicleCategory(normalized); ok {
+		return normalized, nil
+	}
+	if normalized == "" {
+		return "", valerrors.Required("vehicle_category")
+	}
+	return "", valerrors.InvalidOptionWithValue("vehicle_category", AllVehicleCategories(), s)
+}
+
+// ValidateVehicleCategory validates a requested vehicle category, matched
+// case-insensitively. Returns REQUIRED on "vehicle_category" for an empty
+// category and INVALID_OPTION listing the valid categories for an unknown one.
+func ValidateVehicleCategory(category string) error {
+	_, err := NormalizeVehicleCategory(category)
+	return err
+}
+
+// SetCategoryMultiplier sets the fare multiplier of a vehicle category,
+// including one added with RegisterPassengerCapacity. Returns the
+// ValidateVehicleCategory error for an unknown category and OUT_OF_RANGE on
+// "multiplier" unless it is positive and finite.
+// Safe for concurrent use with fare estimation.
+func SetCategoryMultiplier(category string, multiplier float64) error {
+	normalized := normalizeVehicleCategory(category)
+	if normalized == "" {
+		return valerrors.Required("vehicle_category")
+	}
+	if !(multiplier > 0) || math.IsInf(multiplier, 1) {
+		return valerrors.NewWithValue("multiplier", valerrors.CodeOutOfRange,
+			"multiplier must be a positive finite number", multiplier)
+	}
+
+	vehicleCategoriesMu.Lock()
+	defer vehicleCategoriesMu.Unlock()
+	c, ok := vehicleCategories[normalized]
+	if !ok {
+		return valerrors.InvalidOptionWithValue("vehicle_category", categoryNames(vehicleCategories), category)
+	}
+	c.multiplier = multiplier
+	vehicleCategories[normalized] = c
+	return nil
+}
+
+// ResetCategoryMultipliers restores the multipliers to
+// DefaultCategoryMultiplier. Categories added with RegisterPassengerCapacity
+// are kept, with a multiplier of 1.
+func ResetCategoryMultipliers() {
+	vehicleCategoriesMu.Lock()
+	defer vehicleCategoriesMu.Unlock()
+	for category, c := range vehicleCategories {
+		c.multiplier = defaultMultiplier(category)
+		vehicleCategories[category] = c
+	}
+}
+
+// CategoryMultiplier returns the fare multiplier of a vehicle category and
+// whether the category is known. Categories are case-insensitive.
+func CategoryMultiplier(category string) (float64, bool) {
+	c, ok := lookupVehicleCategory(category)
+	return c.multiplier, ok
+}
+
+// CalculateEstimatedFareForCategory returns the CalculateEstimatedFare
+// amount scaled by the category's CategoryMultiplier. The multiplier applies
+// to the unrounded fare, which is then rounded half up to the nearest centavo
+// once, like EstimateFare. Returns the ValidateVehicleCategory error for an
+// unknown category.
+func CalculateEstimatedFareForCategory(distanceKM float64, baseFareCentavos, perKMCentavos int64, category string) (int64, error) {
+	multiplier, ok := CategoryMultiplier(category)
+	if !ok {
+		return 0, ValidateVehicleCategory(category)
+	}
+	return fareAmount(distanceKM, 0, FareConfig{
+		BaseFare: baseFareCentavos,
+		PerKM:    perKMCentavos,
+		Surge:    multiplier,
+	}), nil
+}
+
+// IsValidVehicleCategory returns true if the vehicle category is valid.
+func IsValidVehicleCategory(category string) bool {
+	return ValidateVehicleCategory(category) == nil
+}
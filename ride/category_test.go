@@ -0,0 +1,216 @@
+package ride
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/Dorico-Dynamics/txova-go-types/geo"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestNormalizeVehicleCategory(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		errCode string
+	}{
+		{"economy", "economy", VehicleCategoryEconomy, ""},
+		{"uppercase", "COMFORT", VehicleCategoryComfort, ""},
+		{"mixed case with spaces", "  Xl ", VehicleCategoryXL, ""},
+		{"moto", "Moto", VehicleCategoryMoto, ""},
+		{"unknown", "limo", "", valerrors.CodeInvalidOption},
+		{"empty", "  ", "", valerrors.CodeRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeVehicleCategory(tt.input)
+			if got != tt.want {
+				t.Errorf("NormalizeVehicleCategory(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if IsValidVehicleCategory(tt.input) != (tt.errCode == "") {
+				t.Errorf("IsValidVehicleCategory(%q) = %v", tt.input, tt.errCode != "")
+			}
+			if tt.errCode == "" {
+				if err != nil {
+					t.Errorf("NormalizeVehicleCategory(%q) error = %v", tt.input, err)
+				}
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Field != "vehicle_category" || ve.Code != tt.errCode {
+				t.Errorf("NormalizeVehicleCategory(%q) error = %v, want %s on vehicle_category", tt.input, err, tt.errCode)
+			}
+			if err := ValidateVehicleCategory(tt.input); err == nil {
+				t.Errorf("ValidateVehicleCategory(%q) = nil", tt.input)
+			}
+		})
+	}
+}
+
+func TestCategoryMultiplier(t *testing.T) {
+	tests := []struct {
+		category string
+		want     float64
+		wantOK   bool
+	}{
+		{"economy", 1.0, true},
+		{"Comfort", 1.3, true},
+		{"XL", 1.6, true},
+		{"moto", 0.7, true},
+		{"limo", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := CategoryMultiplier(tt.category)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("CategoryMultiplier(%q) = %v, %v, want %v, %v", tt.category, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestSetCategoryMultiplier(t *testing.T) {
+	t.Cleanup(ResetCategoryMultipliers)
+
+	if err := SetCategoryMultiplier("Comfort", 1.5); err != nil {
+		t.Fatalf("SetCategoryMultiplier() error = %v", err)
+	}
+	if got, _ := CategoryMultiplier(VehicleCategoryComfort); got != 1.5 {
+		t.Errorf("CategoryMultiplier(comfort) = %v, want 1.5", got)
+	}
+
+	invalid := []struct {
+		name       string
+		category   string
+		multiplier float64
+		errCode    string
+	}{
+		{"unknown category", "limo", 2, valerrors.CodeInvalidOption},
+		{"zero", VehicleCategoryXL, 0, valerrors.CodeOutOfRange},
+		{"negative", VehicleCategoryXL, -1, valerrors.CodeOutOfRange},
+		{"NaN", VehicleCategoryXL, math.NaN(), valerrors.CodeOutOfRange},
+		{"infinite", VehicleCategoryXL, math.Inf(1), valerrors.CodeOutOfRange},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetCategoryMultiplier(tt.category, tt.multiplier)
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Code != tt.errCode {
+				t.Errorf("SetCategoryMultiplier() error = %v, want %s", err, tt.errCode)
+			}
+			if ve.Param("max") != nil {
+				t.Errorf("Params[max] = %v, want no max", ve.Param("max"))
+			}
+		})
+	}
+
+	ResetCategoryMultipliers()
+	if got, _ := CategoryMultiplier(VehicleCategoryComfort); got != 1.3 {
+		t.Errorf("CategoryMultiplier(comfort) after reset = %v, want 1.3", got)
+	}
+}
+
+func TestCalculateEstimatedFareForCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		km       float64
+		base     int64
+		perKM    int64
+		category string
+		want     int64
+		wantErr  bool
+	}{
+		// 10 km at 50 MZN base and 10 MZN/km is 15000 centavos before the multiplier.
+		{"economy", 10, 5000, 1000, "economy", 15000, false},
+		{"comfort", 10, 5000, 1000, "comfort", 19500, false},
+		{"xl", 10, 5000, 1000, "XL", 24000, false},
+		{"moto", 10, 5000, 1000, "moto", 10500, false},
+		// 5001 * 0.7 = 3500.7
+		{"moto rounds up", 0, 5001, 1000, "moto", 3501, false},
+		// 5001 * 1.3 = 6501.3
+		{"comfort rounds down", 0, 5001, 1000, "comfort", 6501, false},
+		// 5005 * 0.7 = 3503.5
+		{"half rounds up", 0, 5005, 1000, "moto", 3504, false},
+		// 1000.5 * 0.7 = 700.35; rounding the estimate to 1001 first gave 701.
+		{"rounded once", 1.0005, 0, 1000, "moto", 700, false},
+		{"unknown category", 10, 5000, 1000, "limo", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CalculateEstimatedFareForCategory(tt.km, tt.base, tt.perKM, tt.category)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CalculateEstimatedFareForCategory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CalculateEstimatedFareForCategory() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisteredVehicleCategory(t *testing.T) {
+	t.Cleanup(func() {
+		ResetPassengerCapacity()
+		ResetCategoryMultipliers()
+	})
+
+	if err := RegisterPassengerCapacity("Van", 8); err != nil {
+		t.Fatalf("RegisterPassengerCapacity(van) error = %v", err)
+	}
+	if got, err := NormalizeVehicleCategory(" VAN "); err != nil || got != "van" {
+		t.Errorf("NormalizeVehicleCategory(van) = %q, %v", got, err)
+	}
+	if got, ok := CategoryMultiplier("van"); !ok || got != 1.0 {
+		t.Errorf("CategoryMultiplier(van) = %v, %v, want 1, true", got, ok)
+	}
+	if err := SetCategoryMultiplier("van", 1.8); err != nil {
+		t.Fatalf("SetCategoryMultiplier(van) error = %v", err)
+	}
+	if got, err := CalculateEstimatedFareForCategory(10, 5000, 1000, "van"); err != nil || got != 27000 {
+		t.Errorf("CalculateEstimatedFareForCategory(van) = %d, %v, want 27000", got, err)
+	}
+	want := []string{VehicleCategoryEconomy, VehicleCategoryComfort, VehicleCategoryXL, VehicleCategoryMoto, "van"}
+	if got := AllVehicleCategories(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AllVehicleCategories() = %v, want %v", got, want)
+	}
+
+	r := RideRequest{
+		Pickup:       geo.MustNewLocation(-25.9692, 32.5732),
+		Dropoff:      geo.MustNewLocation(-25.9000, 32.6000),
+		FareCentavos: 25000,
+		DistanceKM:   8.5,
+		Category:     "van",
+		Passengers:   7,
+	}
+	if errs := ValidateRideRequest(r, RequestOptions{}); errs != nil {
+		t.Errorf("ValidateRideRequest(van) = %v", errs)
+	}
+
+	// Resetting multipliers keeps the category; resetting capacity removes it.
+	ResetCategoryMultipliers()
+	if got, ok := CategoryMultiplier("van"); !ok || got != 1.0 {
+		t.Errorf("CategoryMultiplier(van) after reset = %v, %v, want 1, true", got, ok)
+	}
+	ResetPassengerCapacity()
+	if IsValidVehicleCategory("van") {
+		t.Error("IsValidVehicleCategory(van) = true after ResetPassengerCapacity")
+	}
+	if err := SetCategoryMultiplier("van", 1.8); err == nil {
+		t.Error("SetCategoryMultiplier(van) = nil after ResetPassengerCapacity")
+	}
+}
+
+func TestResetPassengerCapacity_KeepsMultipliers(t *testing.T) {
+	t.Cleanup(ResetCategoryMultipliers)
+
+	if err := SetCategoryMultiplier(VehicleCategoryComfort, 1.5); err != nil {
+		t.Fatalf("SetCategoryMultiplier() error = %v", err)
+	}
+	ResetPassengerCapacity()
+	if got, _ := CategoryMultiplier(VehicleCategoryComfort); got != 1.5 {
+		t.Errorf("CategoryMultiplier(comfort) = %v, want 1.5", got)
+	}
+}
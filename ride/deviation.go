@@ -0,0 +1,65 @@
+package ride
+
+import (
+	"fmt"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// Accepted ratios of a completed ride's actual distance to its estimate.
+const (
+	// DefaultMaxDistanceRatio allows the actual distance to be up to 50%
+	// longer than estimated, covering detours and traffic reroutes.
+	DefaultMaxDistanceRatio = 1.5
+	// MinDistanceRatio is the shortest actual distance accepted, as a
+	// fraction of the estimate. Rides much shorter than booked usually ended
+	// early or lost GPS.
+	MinDistanceRatio = 0.5
+)
+
+// distanceRatioTolerance absorbs floating-point error in the ratio, so a
+// distance exactly at a bound passes.
+const distanceRatioTolerance = 1e-9
+
+// ValidateDistanceDeviation checks a completed ride's actual GPS distance
+// against the distance estimated at booking. Both are first checked with
+// ValidateDistance, with the error reported on "estimated_distance" or
+// "actual_distance". The actual distance must then be between
+// MinDistanceRatio and maxRatio times the estimate; otherwise the error is
+// OUT_OF_RANGE on "actual_distance" with the accepted distances as the range
+// and Params "estimated_km", "actual_km", and "ratio". A maxRatio of zero or
+// less uses DefaultMaxDistanceRatio.
+func ValidateDistanceDeviation(estimatedKM, actualKM, maxRatio float64) error {
+	for _, d := range []struct {
+		field string
+		km    float64
+	}{{"estimated_distance", estimatedKM}, {"actual_distance", actualKM}} {
+		if err := ValidateDistance(d.km); err != nil {
+			ve := err.(valerrors.ValidationError)
+			ve.Field = d.field
+			ve.Message = fmt.Sprintf("%s must be between %v and %v", d.field, MinDistanceKM, MaxDistanceKM)
+			return ve
+		}
+	}
+	if !(maxRatio > 0) {
+		maxRatio = DefaultMaxDistanceRatio
+	}
+
+	// ValidateDistance guarantees the estimate is at least MinDistanceKM;
+	// the floor keeps the division safe should that ever change.
+	ratio := actualKM / max(estimatedKM, MinDistanceKM)
+	if ratio < MinDistanceRatio-distanceRatioTolerance || ratio > maxRatio+distanceRatioTolerance {
+		ve := valerrors.OutOfRangeWithValue("actual_distance", estimatedKM*MinDistanceRatio, estimatedKM*maxRatio, actualKM)
+		ve.Message = fmt.Sprintf("actual_distance is %.2f times the estimated %.1f km", ratio, estimatedKM)
+		return ve.WithParam("estimated_km", estimatedKM).
+			WithParam("actual_km", actualKM).
+			WithParam("ratio", ratio)
+	}
+	return nil
+}
+
+// IsSuspiciousDeviation returns true if ValidateDistanceDeviation rejects the
+// distances.
+func IsSuspiciousDeviation(estimatedKM, actualKM, maxRatio float64) bool {
+	return ValidateDistanceDeviation(estimatedKM, actualKM, maxRatio) != nil
+}
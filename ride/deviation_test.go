@@ -0,0 +1,68 @@
+package ride
+
+import (
+	"math"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateDistanceDeviation(t *testing.T) {
+	tests := []struct {
+		name      string
+		estimated float64
+		actual    float64
+		maxRatio  float64
+		errField  string
+	}{
+		{"as estimated", 10, 10, DefaultMaxDistanceRatio, ""},
+		{"at max ratio", 10, 15, DefaultMaxDistanceRatio, ""},
+		{"above max ratio", 10, 15.01, DefaultMaxDistanceRatio, "actual_distance"},
+		{"at min ratio", 10, 5, DefaultMaxDistanceRatio, ""},
+		{"below min ratio", 10, 4.99, DefaultMaxDistanceRatio, "actual_distance"},
+		{"custom max ratio", 10, 19, 2, ""},
+		{"custom max ratio exceeded", 10, 21, 2, "actual_distance"},
+		{"zero max ratio uses default", 10, 16, 0, "actual_distance"},
+		{"NaN max ratio uses default", 10, 15, math.NaN(), ""},
+		{"estimate at minimum distance", MinDistanceKM, 0.75, DefaultMaxDistanceRatio, ""},
+		{"estimate at minimum distance exceeded", MinDistanceKM, 0.76, DefaultMaxDistanceRatio, "actual_distance"},
+		// 0.3 km fails ValidateDistance before the ratio is checked.
+		{"actual below minimum distance", MinDistanceKM, 0.3, DefaultMaxDistanceRatio, "actual_distance"},
+		{"estimate below minimum distance", 0.1, 5, DefaultMaxDistanceRatio, "estimated_distance"},
+		{"zero estimate", 0, 5, DefaultMaxDistanceRatio, "estimated_distance"},
+		{"actual above maximum distance", 150, 250, DefaultMaxDistanceRatio, "actual_distance"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDistanceDeviation(tt.estimated, tt.actual, tt.maxRatio)
+			if IsSuspiciousDeviation(tt.estimated, tt.actual, tt.maxRatio) != (err != nil) {
+				t.Errorf("IsSuspiciousDeviation() disagrees with error %v", err)
+			}
+			if tt.errField == "" {
+				if err != nil {
+					t.Errorf("ValidateDistanceDeviation() error = %v", err)
+				}
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Field != tt.errField || ve.Code != valerrors.CodeOutOfRange {
+				t.Errorf("ValidateDistanceDeviation() error = %v, want OUT_OF_RANGE on %s", err, tt.errField)
+			}
+		})
+	}
+}
+
+func TestValidateDistanceDeviation_Params(t *testing.T) {
+	err := ValidateDistanceDeviation(10, 20, DefaultMaxDistanceRatio)
+	ve, ok := err.(valerrors.ValidationError)
+	if !ok {
+		t.Fatalf("ValidateDistanceDeviation() error = %v, want ValidationError", err)
+	}
+	if ve.Param("estimated_km") != 10.0 || ve.Param("actual_km") != 20.0 || ve.Param("ratio") != 2.0 {
+		t.Errorf("Params = %v, want estimated_km 10, actual_km 20, ratio 2", ve.Params)
+	}
+	if ve.Value != 20.0 {
+		t.Errorf("Value = %v, want 20", ve.Value)
+	}
+}
@@ -0,0 +1,65 @@
+package ride
+
+import (
+	"fmt"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// Duration constraints in minutes.
+const (
+	MinDurationMinutes = 1.0
+	MaxDurationMinutes = 600.0 // 10 hours
+)
+
+// Average speed constraints in km/h for a completed ride.
+const (
+	// MinAverageSpeedKMH catches meters left running after the ride ended.
+	MinAverageSpeedKMH = 2.0
+	// MaxAverageSpeedKMH catches GPS glitches that shorten the duration or
+	// stretch the distance.
+	MaxAverageSpeedKMH = 120.0
+)
+
+// ValidateDuration validates that a ride duration in minutes is within
+// acceptable range.
+func ValidateDuration(minutes float64) error {
+	if !(minutes >= MinDurationMinutes && minutes <= MaxDurationMinutes) {
+		return valerrors.OutOfRangeWithValue("duration", MinDurationMinutes, MaxDurationMinutes, minutes)
+	}
+	return nil
+}
+
+// ValidateDistanceDuration checks that a completed ride's distance and
+// duration imply a plausible average speed. The distance and duration are
+// first checked with ValidateDistance and ValidateDuration, whose errors are
+// returned unchanged. An average speed below MinAverageSpeedKMH or above
+// MaxAverageSpeedKMH is OUT_OF_RANGE on "duration", with the plausible
+// durations for the distance as the range and the implied speed in
+// Param("average_speed_kmh").
+func ValidateDistanceDuration(distanceKM, minutes float64) error {
+	if err := ValidateDistance(distanceKM); err != nil {
+		return err
+	}
+	if err := ValidateDuration(minutes); err != nil {
+		return err
+	}
+
+	speed := distanceKM / (minutes / 60)
+	if speed < MinAverageSpeedKMH || speed > MaxAverageSpeedKMH {
+		minMinutes := distanceKM / MaxAverageSpeedKMH * 60
+		maxMinutes := distanceKM / MinAverageSpeedKMH * 60
+		ve := valerrors.OutOfRangeWithValue("duration", minMinutes, maxMinutes, minutes)
+		ve.Message = fmt.Sprintf("duration implies an average speed of %.1f km/h", speed)
+		return ve.WithParam("average_speed_kmh", speed).
+			WithParam("min_speed_kmh", MinAverageSpeedKMH).
+			WithParam("max_speed_kmh", MaxAverageSpeedKMH)
+	}
+	return nil
+}
+
+// IsValidDuration returns true if the duration in minutes is within
+// acceptable range.
+func IsValidDuration(minutes float64) bool {
+	return ValidateDuration(minutes) == nil
+}
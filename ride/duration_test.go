@@ -0,0 +1,93 @@
+package ride
+
+import (
+	"math"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		minutes float64
+		wantErr bool
+	}{
+		{"minimum", MinDurationMinutes, false},
+		{"maximum", MaxDurationMinutes, false},
+		{"typical", 25, false},
+		{"below minimum", 0.5, true},
+		{"zero", 0, true},
+		{"negative", -5, true},
+		{"above maximum", MaxDurationMinutes + 0.1, true},
+		{"NaN", math.NaN(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDuration(tt.minutes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateDuration(%v) error = %v, wantErr %v", tt.minutes, err, tt.wantErr)
+			}
+			if err != nil {
+				ve, ok := err.(valerrors.ValidationError)
+				if !ok || ve.Field != "duration" || ve.Code != valerrors.CodeOutOfRange {
+					t.Errorf("ValidateDuration() error = %v, want duration/OUT_OF_RANGE", err)
+				}
+			}
+			if got := IsValidDuration(tt.minutes); got == tt.wantErr {
+				t.Errorf("IsValidDuration(%v) = %v, want %v", tt.minutes, got, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDistanceDuration(t *testing.T) {
+	tests := []struct {
+		name      string
+		km        float64
+		minutes   float64
+		wantField string
+	}{
+		// Maputo to Matola is about 15 km and takes around half an hour.
+		{"Maputo to Matola", 15, 30, ""},
+		{"at max speed", 120, 60, ""},
+		{"just above max speed", 120.5, 60, "duration"},
+		{"at min speed", 2, 60, ""},
+		{"just below min speed", 1.9, 60, "duration"},
+		{"GPS glitch", 200, 4, "duration"},
+		{"stuck meter", 1, 120, "duration"},
+		{"invalid distance", 300, 120, "distance"},
+		{"invalid duration", 10, 0, "duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDistanceDuration(tt.km, tt.minutes)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateDistanceDuration(%v, %v) error = %v, want nil", tt.km, tt.minutes, err)
+				}
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Field != tt.wantField || ve.Code != valerrors.CodeOutOfRange {
+				t.Errorf("ValidateDistanceDuration(%v, %v) error = %v, want %s/OUT_OF_RANGE",
+					tt.km, tt.minutes, err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestValidateDistanceDuration_Params(t *testing.T) {
+	ve, ok := ValidateDistanceDuration(200, 4).(valerrors.ValidationError)
+	if !ok {
+		t.Fatal("ValidateDistanceDuration() returned no ValidationError")
+	}
+	if speed := ve.Param("average_speed_kmh"); speed != 3000.0 {
+		t.Errorf("Params[average_speed_kmh] = %v, want 3000", speed)
+	}
+	if ve.Value != 4.0 {
+		t.Errorf("Value = %v, want 4", ve.Value)
+	}
+}
@@ -0,0 +1,64 @@
+package ride
+
+import (
+	"fmt"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// Stop dwell limits in minutes for multi-stop rides.
+const (
+	// FreeStopDwellMinutes is how long the driver waits at each stop before
+	// the dwell is billed.
+	FreeStopDwellMinutes = 5.0
+	// MaxStopDwellMinutes is the longest plausible dwell at a single stop.
+	MaxStopDwellMinutes = 30.0
+	// MaxTotalStopDwellMinutes is the longest plausible dwell across all
+	// stops of a ride.
+	MaxTotalStopDwellMinutes = 60.0
+)
+
+// ValidateStopDwell validates the minutes spent at the stop with the given
+// index, from 0 to MaxStopDwellMinutes. Returns OUT_OF_RANGE on the indexed
+// field, e.g. "stops[2].dwell", otherwise.
+func ValidateStopDwell(stopIndex int, dwellMinutes float64) error {
+	if !(dwellMinutes >= 0 && dwellMinutes <= MaxStopDwellMinutes) {
+		return valerrors.OutOfRangeWithValue(fmt.Sprintf("stops[%d].dwell", stopIndex),
+			0, MaxStopDwellMinutes, dwellMinutes)
+	}
+	return nil
+}
+
+// ValidateStopDwells validates the dwell of every stop with ValidateStopDwell,
+// where dwells[i] is the dwell at stops[i], and reports a total above
+// MaxTotalStopDwellMinutes as OUT_OF_RANGE on "stops.dwell". The total is only
+// checked when every dwell is valid. Returns nil if the dwells are valid.
+func ValidateStopDwells(dwells []float64) valerrors.ValidationErrors {
+	var errs valerrors.ValidationErrors
+	var total float64
+	for i, d := range dwells {
+		if ve, ok := ValidateStopDwell(i, d).(valerrors.ValidationError); ok {
+			errs.Add(ve)
+		}
+		total += d
+	}
+	if !errs.HasErrors() && total > MaxTotalStopDwellMinutes {
+		errs.Add(valerrors.OutOfRangeWithValue("stops.dwell", 0, MaxTotalStopDwellMinutes, total))
+	}
+	return errs
+}
+
+// BillableStopMinutes returns the dwell minutes to bill across all stops:
+// the time beyond freePerStop at each stop, summed. Stops shorter than
+// freePerStop bill nothing, and their unused free time does not carry over.
+// Dwells are not validated; see ValidateStopDwells. Bill the result with a
+// WaitConfig whose FreeMinutes is 0.
+func BillableStopMinutes(dwells []float64, freePerStop float64) float64 {
+	var billable float64
+	for _, d := range dwells {
+		if d > freePerStop {
+			billable += d - freePerStop
+		}
+	}
+	return billable
+}
@@ -0,0 +1,97 @@
+package ride
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateStopDwell(t *testing.T) {
+	tests := []struct {
+		name    string
+		index   int
+		minutes float64
+		wantErr bool
+	}{
+		{"zero", 0, 0, false},
+		{"at maximum", 1, MaxStopDwellMinutes, false},
+		{"over maximum", 2, 31, true},
+		{"negative", 0, -1, true},
+		{"NaN", 0, math.NaN(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStopDwell(tt.index, tt.minutes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateStopDwell() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && tt.index == 2 {
+				if ve := err.(valerrors.ValidationError); ve.Field != "stops[2].dwell" {
+					t.Errorf("error field = %q, want stops[2].dwell", ve.Field)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateStopDwells(t *testing.T) {
+	type fieldCode struct{ field, code string }
+	tests := []struct {
+		name   string
+		dwells []float64
+		want   []fieldCode
+	}{
+		{"no stops", nil, nil},
+		{"all fine", []float64{3, 10, 20}, nil},
+		{"single stop over limit", []float64{3, 45, 2},
+			[]fieldCode{{"stops[1].dwell", valerrors.CodeOutOfRange}}},
+		{"total over limit", []float64{25, 25, 25},
+			[]fieldCode{{"stops.dwell", valerrors.CodeOutOfRange}}},
+		{"several stops over limit", []float64{-1, 45},
+			[]fieldCode{{"stops[0].dwell", valerrors.CodeOutOfRange}, {"stops[1].dwell", valerrors.CodeOutOfRange}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []fieldCode
+			for _, e := range ValidateStopDwells(tt.dwells) {
+				got = append(got, fieldCode{e.Field, e.Code})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateStopDwells() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBillableStopMinutes(t *testing.T) {
+	tests := []struct {
+		name   string
+		dwells []float64
+		free   float64
+		want   float64
+	}{
+		{"no stops", nil, FreeStopDwellMinutes, 0},
+		{"all within free time", []float64{2, 5, 4.5}, FreeStopDwellMinutes, 0},
+		// Unused free time at the first stop does not carry over.
+		{"mixed", []float64{2, 8, 12.5}, FreeStopDwellMinutes, 10.5},
+		{"no free time", []float64{2, 3}, 0, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BillableStopMinutes(tt.dwells, tt.free); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("BillableStopMinutes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	fee, err := CalculateWaitFee(BillableStopMinutes([]float64{2, 8, 12.5}, FreeStopDwellMinutes),
+		WaitConfig{PerMinuteCentavos: PerWaitMinuteCentavos, Billing: WaitBillingExact})
+	if err != nil || fee != 5250 {
+		t.Errorf("CalculateWaitFee() = %d, %v, want 5250", fee, err)
+	}
+}
@@ -0,0 +1,76 @@
+package ride
+
+import (
+	"fmt"
+	"math"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// ETA constraints in minutes.
+const (
+	MinETAMinutes = 1
+	MaxETAMinutes = 180 // 3 hours
+)
+
+// Implied speed band in km/h for a quoted ETA.
+const (
+	MinETASpeedKMH = 5.0
+	MaxETASpeedKMH = 120.0
+)
+
+// etaTolerance absorbs floating-point error before rounding an ETA up, so
+// that 10 km at 30 km/h is 20 minutes rather than 21.
+const etaTolerance = 1e-9
+
+// ValidateETA checks an ETA quoted by dispatch against the ride distance.
+// The distance is first checked with ValidateDistance, whose error is returned
+// unchanged. An ETA outside MinETAMinutes to MaxETAMinutes is OUT_OF_RANGE on
+// "eta". An ETA implying a speed outside MinETASpeedKMH to MaxETASpeedKMH is
+// OUT_OF_RANGE on "eta", with the plausible ETAs for the distance as the range
+// and the implied speed in Param("implied_speed_kmh").
+func ValidateETA(minutes int, distanceKM float64) error {
+	if err := ValidateDistance(distanceKM); err != nil {
+		return err
+	}
+	if minutes < MinETAMinutes || minutes > MaxETAMinutes {
+		return valerrors.OutOfRangeWithValue("eta", MinETAMinutes, MaxETAMinutes, minutes).
+			WithParam("min", MinETAMinutes).
+			WithParam("max", MaxETAMinutes)
+	}
+
+	speed := distanceKM / (float64(minutes) / 60)
+	if speed < MinETASpeedKMH || speed > MaxETASpeedKMH {
+		minMinutes := distanceKM / MaxETASpeedKMH * 60
+		maxMinutes := distanceKM / MinETASpeedKMH * 60
+		ve := valerrors.OutOfRangeWithValue("eta", minMinutes, maxMinutes, minutes)
+		ve.Message = fmt.Sprintf("eta implies a speed of %.1f km/h", speed)
+		return ve.WithParam("implied_speed_kmh", speed).
+			WithParam("min_speed_kmh", MinETASpeedKMH).
+			WithParam("max_speed_kmh", MaxETASpeedKMH)
+	}
+	return nil
+}
+
+// EstimateETAMinutes estimates the minutes needed to cover distanceKM at
+// avgSpeedKMH, rounded up to a whole minute and at least MinETAMinutes. The
+// distance is checked with ValidateDistance; a speed that is not positive and
+// finite is OUT_OF_RANGE on "average_speed". The result is not capped at
+// MaxETAMinutes; pass it to ValidateETA to check it.
+func EstimateETAMinutes(distanceKM, avgSpeedKMH float64) (int, error) {
+	if err := ValidateDistance(distanceKM); err != nil {
+		return 0, err
+	}
+	if !(avgSpeedKMH > 0) || math.IsInf(avgSpeedKMH, 0) {
+		return 0, valerrors.NewWithValue("average_speed", valerrors.CodeOutOfRange,
+			"average_speed must be a positive number", avgSpeedKMH)
+	}
+
+	minutes := int(math.Ceil(distanceKM/avgSpeedKMH*60 - etaTolerance))
+	return max(minutes, MinETAMinutes), nil
+}
+
+// IsValidETA returns true if the ETA is plausible for the distance.
+func IsValidETA(minutes int, distanceKM float64) bool {
+	return ValidateETA(minutes, distanceKM) == nil
+}
@@ -0,0 +1,81 @@
+package ride
+
+import (
+	"math"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateETA(t *testing.T) {
+	tests := []struct {
+		name      string
+		minutes   int
+		km        float64
+		wantErr   bool
+		errField  string
+		wantSpeed float64
+	}{
+		{"consistent", 20, 10, false, "", 0},
+		{"slow traffic", 100, 10, false, "", 0},
+		{"zero eta", 0, 10, true, "eta", 0},
+		{"above maximum", 600, 10, true, "eta", 0},
+		{"implies 300 km/h", 20, 100, true, "eta", 300},
+		{"implies 3 km/h", 100, 5, true, "eta", 3},
+		{"invalid distance", 20, 0.1, true, "distance", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateETA(tt.minutes, tt.km)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateETA() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if IsValidETA(tt.minutes, tt.km) == tt.wantErr {
+				t.Errorf("IsValidETA() = %v", !tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			ve := err.(valerrors.ValidationError)
+			if ve.Field != tt.errField || ve.Code != valerrors.CodeOutOfRange {
+				t.Errorf("error = %s/%s, want %s/OUT_OF_RANGE", ve.Field, ve.Code, tt.errField)
+			}
+			if tt.wantSpeed != 0 {
+				if speed, ok := ve.Param("implied_speed_kmh").(float64); !ok || math.Abs(speed-tt.wantSpeed) > 1e-9 {
+					t.Errorf("implied_speed_kmh = %v, want %v", ve.Param("implied_speed_kmh"), tt.wantSpeed)
+				}
+			}
+		})
+	}
+}
+
+func TestEstimateETAMinutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		km      float64
+		speed   float64
+		want    int
+		wantErr bool
+	}{
+		{"exact minutes", 10, 30, 20, false},
+		{"rounds up", 10.1, 30, 21, false},
+		{"minimum one minute", 0.5, 120, 1, false},
+		{"zero speed", 10, 0, 0, true},
+		{"negative speed", 10, -30, 0, true},
+		{"NaN speed", 10, math.NaN(), 0, true},
+		{"invalid distance", 0, 30, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EstimateETAMinutes(tt.km, tt.speed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EstimateETAMinutes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("EstimateETAMinutes() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
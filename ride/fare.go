@@ -0,0 +1,243 @@
+package ride
+
+import (
+	"fmt"
+	"math"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// Maputo pricing used by DefaultFarePolicy.
+const (
+	DefaultBaseFareCentavos = 5000 // 50 MZN
+	DefaultPerKMCentavos    = 1000 // 10 MZN per km
+	// DefaultFareDeviation is the accepted difference from the expected fare,
+	// as a fraction of it: ±40%.
+	DefaultFareDeviation = 0.4
+)
+
+// fareBandTolerance absorbs floating-point error in the fare band bounds.
+const fareBandTolerance = 1e-6
+
+// FarePolicy describes the expected fare for a distance and how far a charged
+// fare may stray from it.
+type FarePolicy struct {
+	BaseFareCentavos int64
+	PerKMCentavos    int64
+	// MaxDeviation is the accepted difference from the expected fare as a
+	// fraction of it, e.g. 0.4 for ±40%. It covers discounts, surge pricing,
+	// and route changes.
+	MaxDeviation float64
+}
+
+// DefaultFarePolicy returns the current Maputo pricing with a ±40% band.
+func DefaultFarePolicy() FarePolicy {
+	return FarePolicy{
+		BaseFareCentavos: DefaultBaseFareCentavos,
+		PerKMCentavos:    DefaultPerKMCentavos,
+		MaxDeviation:     DefaultFareDeviation,
+	}
+}
+
+// FareConfig holds the prices used by EstimateFare. All amounts are in
+// centavos.
+type FareConfig struct {
+	BaseFare  int64
+	PerKM     int64
+	PerMinute int64
+	// MinimumFare is the lowest fare charged; smaller estimates are raised to
+	// it.
+	MinimumFare int64
+	// Surge multiplies the whole fare before rounding, e.g. 1.5. Zero means
+	// no surge.
+	Surge float64
+}
+
+// DefaultFareConfig returns the current Maputo pricing without time-based
+// pricing or surge, with MinFareCentavos as the minimum fare.
+func DefaultFareConfig() FareConfig {
+	return FareConfig{
+		BaseFare:    DefaultBaseFareCentavos,
+		PerKM:       DefaultPerKMCentavos,
+		MinimumFare: MinFareCentavos,
+	}
+}
+
+// EstimateFare returns the fare in centavos for a ride of distanceKM taking
+// durationMinutes: BaseFare plus PerKM per kilometer plus PerMinute per
+// minute, times Surge, rounded half up to the nearest centavo and raised to
+// MinimumFare. Only the final amount is rounded.
+//
+// Returns INVALID_FORMAT on "fare_config" for negative prices or a negative
+// or non-finite Surge, OUT_OF_RANGE on "distance" outside 0 to MaxDistanceKM
+// or "duration" outside 0 to MaxDurationMinutes, and OUT_OF_RANGE on "fare"
+// if the estimate exceeds MaxFareCentavos. The estimate is checked before it
+// is converted to centavos, so huge inputs cannot overflow.
+func EstimateFare(distanceKM, durationMinutes float64, cfg FareConfig) (int64, error) {
+	if cfg.BaseFare < 0 || cfg.PerKM < 0 || cfg.PerMinute < 0 || cfg.MinimumFare < 0 ||
+		!(cfg.Surge >= 0) || math.IsInf(cfg.Surge, 1) {
+		return 0, valerrors.InvalidFormatWithValue("fare_config", "non-negative prices and surge", cfg)
+	}
+	if !(distanceKM >= 0 && distanceKM <= MaxDistanceKM) {
+		return 0, valerrors.OutOfRangeWithValue("distance", 0, MaxDistanceKM, distanceKM)
+	}
+	if !(durationMinutes >= 0 && durationMinutes <= MaxDurationMinutes) {
+		return 0, valerrors.OutOfRangeWithValue("duration", 0, MaxDurationMinutes, durationMinutes)
+	}
+
+	raw := rawFare(distanceKM, durationMinutes, cfg)
+	// Anything from MaxFareCentavos+0.5 rounds above the maximum. Checking
+	// before roundFare keeps a huge or infinite raw fare from overflowing.
+	if !(raw < MaxFareCentavos+0.5-fareBandTolerance) {
+		return 0, valerrors.OutOfRangeWithValue("fare", cfg.MinimumFare, MaxFareCentavos, raw)
+	}
+	fare := max(roundFare(raw), cfg.MinimumFare)
+	if fare > MaxFareCentavos {
+		return 0, valerrors.OutOfRangeWithValue("fare", cfg.MinimumFare, MaxFareCentavos, fare)
+	}
+	return fare, nil
+}
+
+// fareAmount computes the fare for cfg without validation or the minimum
+// fare, rounded half up to the nearest centavo.
+func fareAmount(distanceKM, durationMinutes float64, cfg FareConfig) int64 {
+	return roundFare(rawFare(distanceKM, durationMinutes, cfg))
+}
+
+// rawFare computes the unrounded fare for cfg in centavos.
+func rawFare(distanceKM, durationMinutes float64, cfg FareConfig) float64 {
+	surge := cfg.Surge
+	if surge == 0 {
+		surge = 1
+	}
+	return (float64(cfg.BaseFare) + distanceKM*float64(cfg.PerKM) + durationMinutes*float64(cfg.PerMinute)) * surge
+}
+
+// roundFare rounds a fare half up to the nearest centavo. The tolerance keeps
+// amounts such as 10.5555 km at 1000 centavos/km, which is 10555.4999... in
+// floating point, rounding up like the exact value.
+func roundFare(raw float64) int64 {
+	return int64(math.Floor(raw + 0.5 + fareBandTolerance))
+}
+
+// ValidateFareForDistance checks that a fare is plausible for the distance
+// traveled. The distance and fare are first checked with ValidateDistance and
+// ValidateFare, whose errors are returned unchanged. The fare must then lie
+// within policy.MaxDeviation of CalculateEstimatedFare for the policy;
+// otherwise the error is OUT_OF_RANGE on "fare" with the bounds in Params
+// "min_fare" and "max_fare" and the estimate in "expected_fare", all in
+// centavos. A policy with negative prices or deviation is INVALID_FORMAT on
+// "fare_policy".
+func ValidateFareForDistance(centavos int64, distanceKM float64, policy FarePolicy) error {
+	if policy.BaseFareCentavos < 0 || policy.PerKMCentavos < 0 ||
+		!(policy.MaxDeviation >= 0) || math.IsInf(policy.MaxDeviation, 1) {
+		return valerrors.InvalidFormatWithValue("fare_policy", "non-negative prices and deviation", policy)
+	}
+	if err := ValidateDistance(distanceKM); err != nil {
+		return err
+	}
+	if err := ValidateFare(centavos); err != nil {
+		return err
+	}
+
+	expected := CalculateEstimatedFare(distanceKM, policy.BaseFareCentavos, policy.PerKMCentavos)
+	minFare := int64(math.Floor(float64(expected)*(1-policy.MaxDeviation) + fareBandTolerance))
+	maxFare := int64(math.Ceil(float64(expected)*(1+policy.MaxDeviation) - fareBandTolerance))
+	if centavos < minFare || centavos > maxFare {
+		ve := valerrors.OutOfRangeWithValue("fare", minFare, maxFare, centavos)
+		ve.Message = fmt.Sprintf("fare is outside the expected range for %.1f km", distanceKM)
+		return ve.WithParam("min_fare", minFare).
+			WithParam("max_fare", maxFare).
+			WithParam("expected_fare", expected)
+	}
+	return nil
+}
+
+// IsValidFareForDistance returns true if the fare is plausible for the
+// distance under the policy.
+func IsValidFareForDistance(centavos int64, distanceKM float64, policy FarePolicy) bool {
+	return ValidateFareForDistance(centavos, distanceKM, policy) == nil
+}
+
+// FareBreakdown itemizes a fare as shown on a receipt. All amounts are in
+// centavos.
+type FareBreakdown struct {
+	BaseCentavos     int64
+	DistanceCentavos int64
+	TimeCentavos     int64
+	SurgeCentavos    int64
+	// DiscountCentavos is zero or negative, since it reduces the total.
+	DiscountCentavos int64
+	TotalCentavos    int64
+	// SurgeMultiplier is the surge factor applied to the base, distance, and
+	// time components, e.g. 1.5. Zero means no multiplier was recorded and
+	// the surge amount is not checked against one.
+	SurgeMultiplier float64
+}
+
+// Sum returns the sum of the breakdown's components, excluding the total.
+func (b FareBreakdown) Sum() int64 {
+	return b.BaseCentavos + b.DistanceCentavos + b.TimeCentavos + b.SurgeCentavos + b.DiscountCentavos
+}
+
+// ValidateFareBreakdown checks that a fare breakdown adds up. The base,
+// distance, time, and surge components must not be negative and the discount
+// must not be positive, each reported on its own field ("base_fare",
+// "distance_fare", "time_fare", "surge_fare", "discount"). The total must be
+// within MinFareCentavos and MaxFareCentavos. A SurgeMultiplier, when set,
+// must be at least 1.
+//
+// Once every field is valid on its own, the total must equal Sum exactly,
+// otherwise OUT_OF_RANGE on "total" with the sum in the "computed_sum" param.
+// With a SurgeMultiplier, the surge amount must be within 1 centavo of the
+// base, distance, and time components times (SurgeMultiplier - 1), otherwise
+// OUT_OF_RANGE on "surge_fare" with the amount in "expected_surge".
+func ValidateFareBreakdown(b FareBreakdown) valerrors.ValidationErrors {
+	var errs valerrors.ValidationErrors
+
+	for _, c := range []struct {
+		field    string
+		centavos int64
+	}{
+		{"base_fare", b.BaseCentavos},
+		{"distance_fare", b.DistanceCentavos},
+		{"time_fare", b.TimeCentavos},
+		{"surge_fare", b.SurgeCentavos},
+	} {
+		if c.centavos < 0 {
+			errs.Add(valerrors.NewWithValue(c.field, valerrors.CodeOutOfRange, c.field+" must not be negative", c.centavos).
+				WithParam("min", 0))
+		}
+	}
+	if b.DiscountCentavos > 0 {
+		errs.Add(valerrors.NewWithValue("discount", valerrors.CodeOutOfRange, "discount must not be positive", b.DiscountCentavos).
+			WithParam("max", 0))
+	}
+	if b.TotalCentavos < MinFareCentavos || b.TotalCentavos > MaxFareCentavos {
+		errs.Add(valerrors.OutOfRangeWithValue("total", MinFareCentavos, MaxFareCentavos, b.TotalCentavos))
+	}
+	if b.SurgeMultiplier != 0 && (!(b.SurgeMultiplier >= 1) || math.IsInf(b.SurgeMultiplier, 1)) {
+		errs.Add(valerrors.NewWithValue("surge_multiplier", valerrors.CodeOutOfRange,
+			"surge_multiplier must be a finite number of at least 1", b.SurgeMultiplier).
+			WithParam("min", 1))
+	}
+	if errs.HasErrors() {
+		return errs
+	}
+
+	if sum := b.Sum(); b.TotalCentavos != sum {
+		ve := valerrors.OutOfRangeWithValue("total", sum, sum, b.TotalCentavos)
+		ve.Message = fmt.Sprintf("total does not match the sum of its components of %d", sum)
+		errs.Add(ve.WithParam("computed_sum", sum))
+	}
+	if b.SurgeMultiplier != 0 {
+		expected := float64(b.BaseCentavos+b.DistanceCentavos+b.TimeCentavos) * (b.SurgeMultiplier - 1)
+		if math.Abs(float64(b.SurgeCentavos)-expected) > 1+fareBandTolerance {
+			rounded := int64(math.Round(expected))
+			ve := valerrors.OutOfRangeWithValue("surge_fare", rounded-1, rounded+1, b.SurgeCentavos)
+			ve.Message = fmt.Sprintf("surge_fare does not match the %.2fx surge multiplier", b.SurgeMultiplier)
+			errs.Add(ve.WithParam("expected_surge", rounded))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,275 @@
+package ride
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateFareForDistance(t *testing.T) {
+	policy := DefaultFarePolicy()
+	// 10 km: 5000 + 10*1000 = 15000 centavos expected, band 9000-21000.
+	tests := []struct {
+		name      string
+		centavos  int64
+		km        float64
+		wantField string
+		wantCode  string
+	}{
+		{"expected fare", 15000, 10, "", ""},
+		{"lower band edge", 9000, 10, "", ""},
+		{"upper band edge", 21000, 10, "", ""},
+		{"just below band", 8999, 10, "fare", valerrors.CodeOutOfRange},
+		{"just above band", 21001, 10, "fare", valerrors.CodeOutOfRange},
+		{"discounted fare within band", 10500, 10, "", ""},
+		{"grossly inflated fare", 4000000, 2, "fare", valerrors.CodeOutOfRange},
+		{"distance invalid", 15000, 0.1, "distance", valerrors.CodeOutOfRange},
+		{"fare invalid", 100, 10, "fare", valerrors.CodeOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFareForDistance(tt.centavos, tt.km, policy)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateFareForDistance() error = %v, want nil", err)
+				}
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Field != tt.wantField || ve.Code != tt.wantCode {
+				t.Errorf("ValidateFareForDistance() error = %v, want %s/%s", err, tt.wantField, tt.wantCode)
+			}
+			if IsValidFareForDistance(tt.centavos, tt.km, policy) {
+				t.Error("IsValidFareForDistance() = true, want false")
+			}
+		})
+	}
+}
+
+func TestValidateFareForDistance_Params(t *testing.T) {
+	err := ValidateFareForDistance(4000000, 2, DefaultFarePolicy())
+	ve, _ := err.(valerrors.ValidationError)
+	// 2 km: 5000 + 2*1000 = 7000 expected, band 4200-9800.
+	if ve.Param("expected_fare") != int64(7000) || ve.Param("min_fare") != int64(4200) ||
+		ve.Param("max_fare") != int64(9800) {
+		t.Errorf("Params = %v, want expected 7000, range 4200-9800", ve.Params)
+	}
+	if ve.Value != int64(4000000) {
+		t.Errorf("Value = %v, want 4000000", ve.Value)
+	}
+}
+
+func TestValidateFareForDistance_InvalidPolicy(t *testing.T) {
+	policies := []FarePolicy{
+		{BaseFareCentavos: -1, PerKMCentavos: 1000, MaxDeviation: 0.4},
+		{BaseFareCentavos: 5000, PerKMCentavos: -1, MaxDeviation: 0.4},
+		{BaseFareCentavos: 5000, PerKMCentavos: 1000, MaxDeviation: -0.1},
+		{BaseFareCentavos: 5000, PerKMCentavos: 1000, MaxDeviation: math.NaN()},
+	}
+	for _, p := range policies {
+		ve, ok := ValidateFareForDistance(15000, 10, p).(valerrors.ValidationError)
+		if !ok || ve.Field != "fare_policy" || ve.Code != valerrors.CodeInvalidFormat {
+			t.Errorf("ValidateFareForDistance() with %+v error = %v, want fare_policy/INVALID_FORMAT", p, ve)
+		}
+	}
+}
+
+func TestValidateFareBreakdown(t *testing.T) {
+	// 50 + 80 + 20 MZN with a 1.5x surge of 75 MZN and a 10 MZN discount.
+	valid := FareBreakdown{
+		BaseCentavos:     5000,
+		DistanceCentavos: 8000,
+		TimeCentavos:     2000,
+		SurgeCentavos:    7500,
+		DiscountCentavos: -1000,
+		TotalCentavos:    21500,
+		SurgeMultiplier:  1.5,
+	}
+
+	tests := []struct {
+		name       string
+		modify     func(b *FareBreakdown)
+		wantFields []string
+	}{
+		{"valid surge breakdown", func(b *FareBreakdown) {}, nil},
+		{"no surge", func(b *FareBreakdown) {
+			b.SurgeCentavos, b.SurgeMultiplier, b.TotalCentavos = 0, 0, 14000
+		}, nil},
+		{"surge without multiplier unchecked", func(b *FareBreakdown) {
+			b.SurgeCentavos, b.SurgeMultiplier, b.TotalCentavos = 3000, 0, 17000
+		}, nil},
+		{"surge rounded within 1 centavo", func(b *FareBreakdown) {
+			// 15001 * 0.5 = 7500.5
+			b.TimeCentavos, b.SurgeCentavos, b.TotalCentavos = 2001, 7501, 21502
+		}, nil},
+		{"total off by one", func(b *FareBreakdown) { b.TotalCentavos = 21501 }, []string{"total"}},
+		{"negative distance component", func(b *FareBreakdown) {
+			b.DistanceCentavos = -8000
+		}, []string{"distance_fare"}},
+		{"positive discount", func(b *FareBreakdown) { b.DiscountCentavos = 1000 }, []string{"discount"}},
+		{"several negative components", func(b *FareBreakdown) {
+			b.BaseCentavos, b.TimeCentavos = -1, -1
+		}, []string{"base_fare", "time_fare"}},
+		{"total below minimum fare", func(b *FareBreakdown) {
+			*b = FareBreakdown{BaseCentavos: 4000, TotalCentavos: 4000}
+		}, []string{"total"}},
+		{"surge inconsistent with multiplier", func(b *FareBreakdown) {
+			b.SurgeCentavos, b.TotalCentavos = 7502, 21502
+		}, []string{"surge_fare"}},
+		{"surge multiplier below 1", func(b *FareBreakdown) { b.SurgeMultiplier = 0.5 }, []string{"surge_multiplier"}},
+		{"NaN surge multiplier", func(b *FareBreakdown) { b.SurgeMultiplier = math.NaN() }, []string{"surge_multiplier"}},
+		{"mismatched total and surge", func(b *FareBreakdown) {
+			b.SurgeCentavos = 9000
+		}, []string{"total", "surge_fare"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := valid
+			tt.modify(&b)
+			errs := ValidateFareBreakdown(b)
+			if got := errs.Fields(); !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("ValidateFareBreakdown() fields = %v, want %v (%v)", got, tt.wantFields, errs)
+			}
+			for _, e := range errs {
+				if e.Code != valerrors.CodeOutOfRange {
+					t.Errorf("error on %s code = %v, want OUT_OF_RANGE", e.Field, e.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateFareBreakdown_Params(t *testing.T) {
+	errs := ValidateFareBreakdown(FareBreakdown{
+		BaseCentavos:     5000,
+		DistanceCentavos: 8000,
+		SurgeCentavos:    1302,
+		TotalCentavos:    14300,
+		SurgeMultiplier:  1.1,
+	})
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if got := errs[0].Param("computed_sum"); got != int64(14302) {
+		t.Errorf("computed_sum = %v, want 14302", got)
+	}
+	if errs[1].Field != "surge_fare" {
+		t.Fatalf("second error field = %s, want surge_fare", errs[1].Field)
+	}
+	if got := errs[1].Param("expected_surge"); got != int64(1300) {
+		t.Errorf("expected_surge = %v, want 1300", got)
+	}
+}
+
+func TestEstimateFare(t *testing.T) {
+	tests := []struct {
+		name    string
+		km      float64
+		minutes float64
+		cfg     FareConfig
+		want    int64
+	}{
+		{"default config", 10, 20, DefaultFareConfig(), 15000},
+		{"per-minute component", 10, 12.5, FareConfig{BaseFare: 5000, PerKM: 1000, PerMinute: 150}, 16875},
+		// 10.5555 km at 10 MZN/km is 10555.5 centavos; truncation gave 10555.
+		{"half centavo rounds up", 10.5555, 0, FareConfig{PerKM: 1000}, 10556},
+		// 2.3456 km is 2345.6 centavos; truncation gave 2345.
+		{"fraction above half rounds up", 2.3456, 0, FareConfig{PerKM: 1000}, 2346},
+		{"fraction below half rounds down", 2.3454, 0, FareConfig{PerKM: 1000}, 2345},
+		// 0.5 + 2.5 = 3; rounding each component would give 1 + 3 = 4.
+		{"rounded only at the end", 0.5, 2.5, FareConfig{PerKM: 1, PerMinute: 1}, 3},
+		{"minimum fare clamp", 1, 5, FareConfig{BaseFare: 2000, PerKM: 1000, MinimumFare: 5000}, 5000},
+		{"above minimum fare", 4, 0, FareConfig{BaseFare: 2000, PerKM: 1000, MinimumFare: 5000}, 6000},
+		// (5000 + 10000 + 1500) * 1.5
+		{"surge", 10, 15, FareConfig{BaseFare: 5000, PerKM: 1000, PerMinute: 100, Surge: 1.5}, 24750},
+		// (5000 + 3333.3) * 1.3 = 10833.29
+		{"surge rounds final amount", 3.3333, 0, FareConfig{BaseFare: 5000, PerKM: 1000, Surge: 1.3}, 10833},
+		{"surge applies before minimum clamp", 1, 0, FareConfig{BaseFare: 2000, PerKM: 1000, MinimumFare: 5000, Surge: 1.5}, 5000},
+		{"zero distance and duration", 0, 0, DefaultFareConfig(), 5000},
+		{"maximum fare", 0, 0, FareConfig{BaseFare: MaxFareCentavos}, MaxFareCentavos},
+		{"maximum distance and duration", MaxDistanceKM, MaxDurationMinutes, DefaultFareConfig(), 205000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EstimateFare(tt.km, tt.minutes, tt.cfg)
+			if err != nil {
+				t.Fatalf("EstimateFare() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("EstimateFare() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateFare_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		km       float64
+		minutes  float64
+		cfg      FareConfig
+		errField string
+		errCode  string
+	}{
+		{"negative distance", -1, 10, DefaultFareConfig(), "distance", valerrors.CodeOutOfRange},
+		{"NaN distance", math.NaN(), 10, DefaultFareConfig(), "distance", valerrors.CodeOutOfRange},
+		{"negative duration", 5, -1, DefaultFareConfig(), "duration", valerrors.CodeOutOfRange},
+		{"infinite duration", 5, math.Inf(1), DefaultFareConfig(), "duration", valerrors.CodeOutOfRange},
+		{"negative per-minute rate", 5, 10, FareConfig{PerMinute: -1}, "fare_config", valerrors.CodeInvalidFormat},
+		{"negative surge", 5, 10, FareConfig{Surge: -1}, "fare_config", valerrors.CodeInvalidFormat},
+		{"NaN surge", 5, 10, FareConfig{Surge: math.NaN()}, "fare_config", valerrors.CodeInvalidFormat},
+		{"above maximum fare", 200, 0, FareConfig{PerKM: 100000}, "fare", valerrors.CodeOutOfRange},
+		{"distance above maximum", MaxDistanceKM + 1, 0, DefaultFareConfig(), "distance", valerrors.CodeOutOfRange},
+		{"huge distance", 1e17, 0, DefaultFareConfig(), "distance", valerrors.CodeOutOfRange},
+		{"astronomic distance", 1e300, 0, DefaultFareConfig(), "distance", valerrors.CodeOutOfRange},
+		{"duration above maximum", 5, MaxDurationMinutes + 1, DefaultFareConfig(), "duration", valerrors.CodeOutOfRange},
+		{"huge surge", 10, 0, FareConfig{BaseFare: 5000, PerKM: 1000, MinimumFare: 5000, Surge: 1e300}, "fare", valerrors.CodeOutOfRange},
+		{"largest finite surge", 10, 0, FareConfig{PerKM: 1000, Surge: math.MaxFloat64}, "fare", valerrors.CodeOutOfRange},
+		{"huge prices", MaxDistanceKM, MaxDurationMinutes,
+			FareConfig{BaseFare: math.MaxInt64, PerKM: math.MaxInt64, PerMinute: math.MaxInt64}, "fare", valerrors.CodeOutOfRange},
+		{"half centavo above maximum", 0, 0, FareConfig{PerKM: 1, BaseFare: MaxFareCentavos, Surge: 1.0000001}, "fare", valerrors.CodeOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := EstimateFare(tt.km, tt.minutes, tt.cfg)
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Field != tt.errField || ve.Code != tt.errCode {
+				t.Errorf("EstimateFare() error = %v, want %s on %s", err, tt.errCode, tt.errField)
+			}
+		})
+	}
+}
+
+func TestCalculateEstimatedFare_Rounding(t *testing.T) {
+	// 10.5555 km at 10 MZN/km is 10555.5 centavos, which used to truncate.
+	if got := CalculateEstimatedFare(10.5555, 5000, 1000); got != 15556 {
+		t.Errorf("CalculateEstimatedFare() = %d, want 15556", got)
+	}
+	// No minimum fare applies.
+	if got := CalculateEstimatedFare(0.5, 0, 1000); got != 500 {
+		t.Errorf("CalculateEstimatedFare() = %d, want 500", got)
+	}
+}
+
+func TestValidateFareBreakdown_SurgeMultiplierParams(t *testing.T) {
+	errs := ValidateFareBreakdown(FareBreakdown{
+		BaseCentavos:    5000,
+		TotalCentavos:   5000,
+		SurgeMultiplier: math.Inf(1),
+	})
+	if len(errs) != 1 || errs[0].Field != "surge_multiplier" {
+		t.Fatalf("ValidateFareBreakdown() = %v, want one error on surge_multiplier", errs)
+	}
+	if errs[0].Param("min") != 1 {
+		t.Errorf("Params[min] = %v, want 1", errs[0].Param("min"))
+	}
+	if errs[0].Param("max") != nil {
+		t.Errorf("Params[max] = %v, want no max", errs[0].Param("max"))
+	}
+}
@@ -0,0 +1,71 @@
+package ride
+
+import (
+	"fmt"
+
+	"github.com/Dorico-Dynamics/txova-go-types/geo"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+	geoval "github.com/Dorico-Dynamics/txova-go-validation/geo"
+)
+
+// LocationOptions configures ValidateRideLocations.
+type LocationOptions struct {
+	// RequireSameArea rejects rides whose pickup and dropoff are in different
+	// service areas, as reported by geo.FindServiceArea.
+	RequireSameArea bool
+}
+
+// ValidateRideLocations validates the pickup and dropoff of a ride request
+// together and reports every problem:
+//   - a zero pickup or dropoff is REQUIRED, and nothing else is checked;
+//   - points closer than MinPickupDropoffSeparationKM are OUT_OF_RANGE on
+//     "pickup_dropoff", as in geo.ValidateDisjointPickupDropoff;
+//   - a point outside every active service area is OUTSIDE_SERVICE_AREA on
+//     "pickup" or "dropoff";
+//   - points more than MaxDistanceKM apart in a straight line are
+//     OUT_OF_RANGE on "pickup_dropoff" with the distance as the value;
+//   - with opts.RequireSameArea, points in different service areas are
+//     NOT_ALLOWED on "pickup_dropoff", with the areas in the "pickup_area"
+//     and "dropoff_area" params. This is only checked when both points are
+//     inside a service area.
+//
+// Areas are compared by geo.FindServiceArea, which picks the
+// highest-priority area containing a point. Since Matola lies inside
+// Maputo's box with a higher priority, a ride from central Maputo to Matola
+// is in two different areas.
+//
+// Returns nil if the locations are valid.
+func ValidateRideLocations(pickup, dropoff geo.Location, opts LocationOptions) valerrors.ValidationErrors {
+	errs := geoval.ValidateDisjointPickupDropoff(pickup, dropoff, MinPickupDropoffSeparationKM)
+	if errs.HasField("pickup") || errs.HasField("dropoff") {
+		return errs
+	}
+
+	inAreas := true
+	for _, wp := range []waypoint{{"pickup", pickup}, {"dropoff", dropoff}} {
+		if ve, ok := geoval.ValidateAnyServiceArea(wp.loc.Latitude(), wp.loc.Longitude()).(valerrors.ValidationError); ok {
+			ve.Field = wp.field
+			errs.Add(ve)
+			inAreas = false
+		}
+	}
+
+	if distance := geoval.DistanceBetween(pickup, dropoff); distance > MaxDistanceKM {
+		errs.Add(valerrors.NewWithValue("pickup_dropoff", valerrors.CodeOutOfRange,
+			fmt.Sprintf("pickup and dropoff must be at most %g km apart", MaxDistanceKM), distance).
+			WithParam("max_km", MaxDistanceKM))
+	}
+
+	if opts.RequireSameArea && inAreas {
+		pickupArea := geoval.FindServiceArea(pickup.Latitude(), pickup.Longitude())
+		dropoffArea := geoval.FindServiceArea(dropoff.Latitude(), dropoff.Longitude())
+		if pickupArea != dropoffArea {
+			errs.Add(valerrors.New("pickup_dropoff", valerrors.CodeNotAllowed,
+				fmt.Sprintf("pickup in %s and dropoff in %s must be in the same service area", pickupArea, dropoffArea)).
+				WithParam("pickup_area", pickupArea).
+				WithParam("dropoff_area", dropoffArea))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,79 @@
+package ride
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dorico-Dynamics/txova-go-types/geo"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateRideLocations(t *testing.T) {
+	maputoCenter := geo.MustNewLocation(-25.9692, 32.5732)
+	maputoNorth := geo.MustNewLocation(-25.9000, 32.6000)
+	matola := geo.MustNewLocation(-25.9622, 32.4589)
+	beira := geo.MustNewLocation(-19.8436, 34.8389)
+	johannesburg := geo.MustNewLocation(-26.2041, 28.0473)
+	// About 30 meters from maputoCenter.
+	nearCenter := geo.MustNewLocation(-25.9695, 32.5732)
+
+	type fieldCode struct{ field, code string }
+	tests := []struct {
+		name    string
+		pickup  geo.Location
+		dropoff geo.Location
+		opts    LocationOptions
+		want    []fieldCode
+	}{
+		{"valid intra-city ride", maputoCenter, maputoNorth, LocationOptions{}, nil},
+		{"valid intra-city ride same area", maputoCenter, maputoNorth, LocationOptions{RequireSameArea: true}, nil},
+		{"maputo to beira", maputoCenter, beira, LocationOptions{},
+			[]fieldCode{{"pickup_dropoff", valerrors.CodeOutOfRange}}},
+		{"maputo to beira same area", maputoCenter, beira, LocationOptions{RequireSameArea: true},
+			[]fieldCode{{"pickup_dropoff", valerrors.CodeOutOfRange}, {"pickup_dropoff", valerrors.CodeNotAllowed}}},
+		{"maputo to matola", maputoCenter, matola, LocationOptions{}, nil},
+		// Matola has a higher priority than the Maputo box that contains it,
+		// so FindServiceArea puts the dropoff in a different area.
+		{"maputo to matola same area", maputoCenter, matola, LocationOptions{RequireSameArea: true},
+			[]fieldCode{{"pickup_dropoff", valerrors.CodeNotAllowed}}},
+		{"dropoff outside service areas", maputoCenter, johannesburg, LocationOptions{RequireSameArea: true},
+			[]fieldCode{{"dropoff", valerrors.CodeOutsideServiceArea}, {"pickup_dropoff", valerrors.CodeOutOfRange}}},
+		{"too close", maputoCenter, nearCenter, LocationOptions{},
+			[]fieldCode{{"pickup_dropoff", valerrors.CodeOutOfRange}}},
+		{"missing dropoff", maputoCenter, geo.Location{}, LocationOptions{},
+			[]fieldCode{{"dropoff", valerrors.CodeRequired}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateRideLocations(tt.pickup, tt.dropoff, tt.opts)
+			var got []fieldCode
+			for _, e := range errs {
+				got = append(got, fieldCode{e.Field, e.Code})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateRideLocations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRideLocations_Params(t *testing.T) {
+	maputo := geo.MustNewLocation(-25.9692, 32.5732)
+	beira := geo.MustNewLocation(-19.8436, 34.8389)
+
+	errs := ValidateRideLocations(maputo, beira, LocationOptions{RequireSameArea: true})
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if distance, ok := errs[0].Value.(float64); !ok || distance < 700 || distance > 800 {
+		t.Errorf("distance value = %v, want 700-800 km", errs[0].Value)
+	}
+	if errs[0].Param("max_km") != MaxDistanceKM {
+		t.Errorf("max_km = %v, want %v", errs[0].Param("max_km"), MaxDistanceKM)
+	}
+	if errs[1].Param("pickup_area") != "maputo" || errs[1].Param("dropoff_area") != "beira" {
+		t.Errorf("area params = %v, want maputo and beira", errs[1].Params)
+	}
+}
@@ -0,0 +1,104 @@
+package ride
+
+import (
+	"fmt"
+	"sort"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// MinPassengers is the fewest passengers a ride can carry in any category.
+const MinPassengers = 1
+
+// DefaultPassengerCapacity is the built-in maximum number of passengers for
+// each vehicle category. RegisterPassengerCapacity overrides or extends it at
+// run time; changing this map after startup has no effect.
+var DefaultPassengerCapacity = map[string]int{
+	VehicleCategoryEconomy: 4,
+	VehicleCategoryComfort: 4,
+	VehicleCategoryXL:      6,
+	VehicleCategoryMoto:    1,
+}
+
+// RegisterPassengerCapacity sets the maximum number of passengers for a
+// vehicle category, adding the category if it is new or replacing the limit
+// of an existing one. Categories are case-insensitive. A new category becomes
+// valid everywhere, including ValidateVehicleCategory, with a fare multiplier
+// of 1 until SetCategoryMultiplier changes it. Returns an error if the
+// category is empty or maxPassengers is below MinPassengers.
+// Safe for concurrent use with validation functions.
+func RegisterPassengerCapacity(category string, maxPassengers int) error {
+	normalized := normalizeVehicleCategory(category)
+	if normalized == "" {
+		return valerrors.Required("vehicle_category")
+	}
+	if maxPassengers < MinPassengers {
+		return valerrors.NewWithValue("max_passengers", valerrors.CodeOutOfRange,
+			fmt.Sprintf("max_passengers must be at least %d", MinPassengers), maxPassengers).
+			WithParam("min", MinPassengers)
+	}
+
+	vehicleCategoriesMu.Lock()
+	defer vehicleCategoriesMu.Unlock()
+	c, ok := vehicleCategories[normalized]
+	if !ok {
+		c.multiplier = defaultMultiplier(normalized)
+	}
+	c.maxPassengers = maxPassengers
+	vehicleCategories[normalized] = c
+	return nil
+}
+
+// ResetPassengerCapacity restores the capacity table to
+// DefaultPassengerCapacity, removing registered categories. Multipliers of
+// the built-in categories are kept.
+func ResetPassengerCapacity() {
+	vehicleCategoriesMu.Lock()
+	defer vehicleCategoriesMu.Unlock()
+	next := defaultVehicleCategories()
+	for category, c := range next {
+		if current, ok := vehicleCategories[category]; ok {
+			c.multiplier = current.multiplier
+			next[category] = c
+		}
+	}
+	vehicleCategories = next
+}
+
+// MaxPassengersFor returns the maximum number of passengers for a vehicle
+// category and whether the category is known. Categories are
+// case-insensitive.
+func MaxPassengersFor(category string) (int, bool) {
+	c, ok := lookupVehicleCategory(category)
+	return c.maxPassengers, ok
+}
+
+// PassengerCategories returns the known vehicle categories in sorted order.
+func PassengerCategories() []string {
+	categories := AllVehicleCategories()
+	sort.Strings(categories)
+	return categories
+}
+
+// ValidatePassengerCount checks a ride's passenger count against the capacity
+// of its vehicle category. Returns INVALID_OPTION on "vehicle_category" for an
+// unknown category and OUT_OF_RANGE on "passengers" when the count is below
+// MinPassengers or above the category's maximum, with the limits in the
+// "min" and "max" params.
+func ValidatePassengerCount(category string, passengers int) error {
+	maxPassengers, ok := MaxPassengersFor(category)
+	if !ok {
+		return valerrors.InvalidOptionWithValue("vehicle_category", PassengerCategories(), category)
+	}
+	if passengers < MinPassengers || passengers > maxPassengers {
+		return valerrors.OutOfRangeWithValue("passengers", MinPassengers, maxPassengers, passengers).
+			WithParam("min", MinPassengers).
+			WithParam("max", maxPassengers)
+	}
+	return nil
+}
+
+// IsValidPassengerCount returns true if the passenger count fits the vehicle category.
+func IsValidPassengerCount(category string, passengers int) bool {
+	return ValidatePassengerCount(category, passengers) == nil
+}
@@ -0,0 +1,130 @@
+package ride
+
+import (
+	"reflect"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidatePassengerCount(t *testing.T) {
+	tests := []struct {
+		name       string
+		category   string
+		passengers int
+		wantErr    bool
+		errField   string
+		errCode    string
+		wantMax    int
+	}{
+		{"economy min", VehicleCategoryEconomy, 1, false, "", "", 0},
+		{"economy max", VehicleCategoryEconomy, 4, false, "", "", 0},
+		{"economy over", VehicleCategoryEconomy, 5, true, "passengers", valerrors.CodeOutOfRange, 4},
+		{"comfort max", VehicleCategoryComfort, 4, false, "", "", 0},
+		{"comfort over", VehicleCategoryComfort, 5, true, "passengers", valerrors.CodeOutOfRange, 4},
+		{"xl max", VehicleCategoryXL, 6, false, "", "", 0},
+		{"xl over", VehicleCategoryXL, 7, true, "passengers", valerrors.CodeOutOfRange, 6},
+		{"moto max", VehicleCategoryMoto, 1, false, "", "", 0},
+		{"moto over", VehicleCategoryMoto, 2, true, "passengers", valerrors.CodeOutOfRange, 1},
+		{"moto nine", VehicleCategoryMoto, 9, true, "passengers", valerrors.CodeOutOfRange, 1},
+		{"zero passengers", VehicleCategoryXL, 0, true, "passengers", valerrors.CodeOutOfRange, 6},
+		{"negative passengers", VehicleCategoryEconomy, -1, true, "passengers", valerrors.CodeOutOfRange, 4},
+		{"category case-insensitive", " XL ", 6, false, "", "", 0},
+		{"unknown category", "limo", 2, true, "vehicle_category", valerrors.CodeInvalidOption, 0},
+		{"empty category", "", 1, true, "vehicle_category", valerrors.CodeInvalidOption, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePassengerCount(tt.category, tt.passengers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidatePassengerCount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if IsValidPassengerCount(tt.category, tt.passengers) == tt.wantErr {
+				t.Errorf("IsValidPassengerCount() = %v, want %v", !tt.wantErr, !tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			ve := err.(valerrors.ValidationError)
+			if ve.Field != tt.errField || ve.Code != tt.errCode {
+				t.Errorf("error = %s/%s, want %s/%s", ve.Field, ve.Code, tt.errField, tt.errCode)
+			}
+			if tt.wantMax != 0 && ve.Param("max") != tt.wantMax {
+				t.Errorf("max param = %v, want %d", ve.Param("max"), tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestMaxPassengersFor(t *testing.T) {
+	for category, want := range DefaultPassengerCapacity {
+		got, ok := MaxPassengersFor(category)
+		if !ok || got != want {
+			t.Errorf("MaxPassengersFor(%q) = %d, %v, want %d, true", category, got, ok, want)
+		}
+	}
+	if _, ok := MaxPassengersFor("limo"); ok {
+		t.Error("MaxPassengersFor(limo) reported a known category")
+	}
+}
+
+func TestRegisterPassengerCapacity(t *testing.T) {
+	t.Cleanup(ResetPassengerCapacity)
+
+	if err := RegisterPassengerCapacity("Van", 8); err != nil {
+		t.Fatalf("RegisterPassengerCapacity(van) error = %v", err)
+	}
+	if err := RegisterPassengerCapacity(VehicleCategoryXL, 5); err != nil {
+		t.Fatalf("RegisterPassengerCapacity(xl) error = %v", err)
+	}
+	if err := ValidatePassengerCount("van", 8); err != nil {
+		t.Errorf("ValidatePassengerCount(van, 8) error = %v", err)
+	}
+	if err := ValidatePassengerCount(VehicleCategoryXL, 6); err == nil {
+		t.Error("ValidatePassengerCount(xl, 6) should fail after lowering the capacity")
+	}
+	want := []string{"comfort", "economy", "moto", "van", "xl"}
+	if got := PassengerCategories(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PassengerCategories() = %v, want %v", got, want)
+	}
+
+	ResetPassengerCapacity()
+	if _, ok := MaxPassengersFor("van"); ok {
+		t.Error("ResetPassengerCapacity() kept registered category")
+	}
+	if got, _ := MaxPassengersFor(VehicleCategoryXL); got != DefaultPassengerCapacity[VehicleCategoryXL] {
+		t.Errorf("MaxPassengersFor(xl) after reset = %d", got)
+	}
+}
+
+func TestRegisterPassengerCapacity_Invalid(t *testing.T) {
+	t.Cleanup(ResetPassengerCapacity)
+
+	tests := []struct {
+		name          string
+		category      string
+		maxPassengers int
+		errCode       string
+	}{
+		{"empty category", "  ", 4, valerrors.CodeRequired},
+		{"zero capacity", "van", 0, valerrors.CodeOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterPassengerCapacity(tt.category, tt.maxPassengers)
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Code != tt.errCode {
+				t.Errorf("RegisterPassengerCapacity() error = %v, want %s", err, tt.errCode)
+			}
+			if ve.Code == valerrors.CodeOutOfRange {
+				if ve.Param("min") != MinPassengers {
+					t.Errorf("Params[min] = %v, want %d", ve.Param("min"), MinPassengers)
+				}
+				if ve.Param("max") != nil {
+					t.Errorf("Params[max] = %v, want no max", ve.Param("max"))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package ride
+
+import (
+	"fmt"
+	"math"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// MaxCommissionRate is the highest commission the platform may take from a
+// driver's gross fares.
+const MaxCommissionRate = 0.5
+
+// CalculatePayout returns a driver's payout in centavos for grossCentavos of
+// completed fares at the given commission rate: gross × (1 − rate), rounded to
+// the nearest centavo with halves rounded to even (banker's rounding), so that
+// rounding errors do not drift in one direction over many payouts. Inputs are
+// not validated; see ValidatePayout.
+func CalculatePayout(grossCentavos int64, rate float64) int64 {
+	return int64(math.RoundToEven(float64(grossCentavos) * (1 - rate)))
+}
+
+// ValidatePayout validates a driver payout computed from grossFaresCentavos of
+// completed fares across rides rides. It reports:
+//   - a commission rate outside [0, MaxCommissionRate] as OUT_OF_RANGE on
+//     "commission_rate";
+//   - a negative gross as OUT_OF_RANGE on "gross", and a negative ride count
+//     as OUT_OF_RANGE on "rides";
+//   - a negative payout or one above the gross as OUT_OF_RANGE on "payout";
+//   - a payout differing from CalculatePayout by more than one centavo per
+//     ride as OUT_OF_RANGE on "payout", with the expected payout in
+//     Param("expected_payout").
+//
+// Each ride's payout may be rounded separately, so the tolerance grows with
+// the number of rides.
+func ValidatePayout(payoutCentavos, grossFaresCentavos int64, commissionRate float64, rides int) error {
+	if !(commissionRate >= 0 && commissionRate <= MaxCommissionRate) {
+		return valerrors.OutOfRangeWithValue("commission_rate", 0, MaxCommissionRate, commissionRate)
+	}
+	if grossFaresCentavos < 0 {
+		return valerrors.NewWithValue("gross", valerrors.CodeOutOfRange,
+			"gross must not be negative", grossFaresCentavos)
+	}
+	if rides < 0 {
+		return valerrors.NewWithValue("rides", valerrors.CodeOutOfRange,
+			"rides must not be negative", rides)
+	}
+	if payoutCentavos < 0 || payoutCentavos > grossFaresCentavos {
+		return valerrors.OutOfRangeWithValue("payout", 0, grossFaresCentavos, payoutCentavos).
+			WithParam("min", 0).
+			WithParam("max", grossFaresCentavos)
+	}
+
+	expected := CalculatePayout(grossFaresCentavos, commissionRate)
+	tolerance := int64(rides)
+	if diff := payoutCentavos - expected; diff < -tolerance || diff > tolerance {
+		return valerrors.NewWithValue("payout", valerrors.CodeOutOfRange,
+			fmt.Sprintf("payout must be %d centavos within %d", expected, tolerance), payoutCentavos).
+			WithParam("expected_payout", expected).
+			WithParam("tolerance", tolerance)
+	}
+	return nil
+}
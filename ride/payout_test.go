@@ -0,0 +1,84 @@
+package ride
+
+import (
+	"math"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestCalculatePayout(t *testing.T) {
+	tests := []struct {
+		name  string
+		gross int64
+		rate  float64
+		want  int64
+	}{
+		{"twenty percent", 150000, 0.2, 120000},
+		{"no commission", 150000, 0, 150000},
+		{"maximum commission", 150000, 0.5, 75000},
+		// 5 * 0.9 = 4.5 rounds to even.
+		{"half rounds down to even", 5, 0.1, 4},
+		// 7 * 0.5 = 3.5 rounds to even.
+		{"half rounds up to even", 7, 0.5, 4},
+		{"zero gross", 0, 0.2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculatePayout(tt.gross, tt.rate); got != tt.want {
+				t.Errorf("CalculatePayout(%d, %v) = %d, want %d", tt.gross, tt.rate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		payout   int64
+		gross    int64
+		rate     float64
+		rides    int
+		errField string
+	}{
+		{"exact agreement", 120000, 150000, 0.2, 10, ""},
+		{"within tolerance", 120002, 150000, 0.2, 2, ""},
+		{"zero rate", 150000, 150000, 0, 10, ""},
+		{"maximum rate", 75000, 150000, 0.5, 10, ""},
+		{"empty week", 0, 0, 0.2, 0, ""},
+		{"off by two beyond tolerance", 120002, 150000, 0.2, 1, "payout"},
+		{"off by two without rides", 119998, 150000, 0.2, 0, "payout"},
+		{"negative rate", 150000, 150000, -0.1, 10, "commission_rate"},
+		{"rate above maximum", 60000, 150000, 0.6, 10, "commission_rate"},
+		{"NaN rate", 120000, 150000, math.NaN(), 10, "commission_rate"},
+		{"negative payout", -100, 150000, 0.2, 10, "payout"},
+		{"payout above gross", 150001, 150000, 0, 10, "payout"},
+		{"negative gross", 0, -100, 0.2, 10, "gross"},
+		{"negative rides", 120000, 150000, 0.2, -1, "rides"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePayout(tt.payout, tt.gross, tt.rate, tt.rides)
+			if tt.errField == "" {
+				if err != nil {
+					t.Errorf("ValidatePayout() error = %v", err)
+				}
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Field != tt.errField || ve.Code != valerrors.CodeOutOfRange {
+				t.Errorf("ValidatePayout() error = %v, want OUT_OF_RANGE on %s", err, tt.errField)
+			}
+		})
+	}
+}
+
+func TestValidatePayout_Params(t *testing.T) {
+	err := ValidatePayout(120002, 150000, 0.2, 1)
+	ve := err.(valerrors.ValidationError)
+	if ve.Param("expected_payout") != int64(120000) || ve.Param("tolerance") != int64(1) {
+		t.Errorf("Params = %v, want expected_payout 120000 and tolerance 1", ve.Params)
+	}
+}
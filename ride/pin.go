@@ -0,0 +1,186 @@
+package ride
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// PIN generation limits.
+const (
+	// MaxPINBatch is the largest number of PINs GeneratePINs returns at once.
+	// It keeps batches well below the ~10,000 valid PINs so that drawing
+	// unique ones stays cheap.
+	MaxPINBatch = 1000
+
+	// maxPINAttempts bounds the draws spent on one PIN. About 8% of draws
+	// are discarded to avoid modulo bias and about 0.2% produce a PIN that
+	// ValidatePIN rejects, so with a working random source the chance of
+	// exhausting 100 draws is far below 1e-100.
+	maxPINAttempts = 100
+
+	// pinSpace is the number of 4-digit strings, and pinDrawLimit the
+	// largest multiple of it that fits a uint16. Draws at or above the
+	// limit are discarded so every PIN is equally likely.
+	pinSpace     = 10000
+	pinDrawLimit = 60000
+)
+
+// GeneratePIN returns a random 4-digit ride verification PIN that passes
+// ValidatePIN. It uses crypto/rand and draws again whenever a candidate is
+// sequential or repeated, giving up with an error after a bounded number of
+// draws or if the random source fails.
+func GeneratePIN() (string, error) {
+	return generatePIN(rand.Reader)
+}
+
+// GeneratePINs returns n distinct PINs, each generated as by GeneratePIN.
+// It returns an error if n is negative or greater than MaxPINBatch.
+func GeneratePINs(n int) ([]string, error) {
+	return generatePINs(rand.Reader, n)
+}
+
+// generatePIN draws a PIN from r. Tests pass a deterministic reader.
+func generatePIN(r io.Reader) (string, error) {
+	return drawPIN(r, nil)
+}
+
+// generatePINs draws n distinct PINs from r.
+func generatePINs(r io.Reader, n int) ([]string, error) {
+	if n < 0 || n > MaxPINBatch {
+		return nil, fmt.Errorf("generating PINs: count %d out of range 0-%d", n, MaxPINBatch)
+	}
+	pins := make([]string, 0, n)
+	seen := make(map[string]bool, n)
+	for range n {
+		pin, err := drawPIN(r, seen)
+		if err != nil {
+			return nil, err
+		}
+		seen[pin] = true
+		pins = append(pins, pin)
+	}
+	return pins, nil
+}
+
+// drawPIN draws candidates from r until one passes ValidatePIN and is not in
+// exclude, for at most maxPINAttempts draws.
+func drawPIN(r io.Reader, exclude map[string]bool) (string, error) {
+	var buf [2]byte
+	for range maxPINAttempts {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return "", fmt.Errorf("generating PIN: %w", err)
+		}
+		v := binary.BigEndian.Uint16(buf[:])
+		if v >= pinDrawLimit {
+			continue
+		}
+		pin := fmt.Sprintf("%04d", v%pinSpace)
+		if ValidatePIN(pin) == nil && !exclude[pin] {
+			return pin, nil
+		}
+	}
+	return "", fmt.Errorf("generating PIN: no valid PIN after %d attempts", maxPINAttempts)
+}
+
+// ErrPINSecretNotConfigured is returned by HashPIN when no secret provider is
+// set or the provider returns an empty secret.
+var ErrPINSecretNotConfigured = errors.New("PIN hash secret not configured")
+
+// PINSecretProvider returns the secret key used by HashPIN and VerifyPINHash.
+// It is called on every hash, so it can fetch the current key from a secret
+// store and pick up rotations.
+type PINSecretProvider func() ([]byte, error)
+
+// pinSecretProvider holds the provider set by SetPINSecretProvider.
+var pinSecretProvider atomic.Pointer[PINSecretProvider]
+
+// SetPINSecretProvider sets the secret provider used by HashPIN and
+// VerifyPINHash for the whole process. Passing nil removes it. It is safe
+// for concurrent use.
+func SetPINSecretProvider(provider PINSecretProvider) {
+	if provider == nil {
+		pinSecretProvider.Store(nil)
+		return
+	}
+	pinSecretProvider.Store(&provider)
+}
+
+// ComparePIN reports whether provided matches expected in constant time.
+// Both PINs must pass ValidatePIN; if either does not, it returns false
+// without revealing which one failed.
+func ComparePIN(expected, provided string) bool {
+	expectedOK := ValidatePIN(expected) == nil
+	providedOK := ValidatePIN(provided) == nil
+	equal := subtle.ConstantTimeCompare([]byte(expected), []byte(provided)) == 1
+	return expectedOK && providedOK && equal
+}
+
+// HashPIN returns a hex-encoded HMAC-SHA256 of pin keyed with the configured
+// PINSecretProvider secret and bound to rideID, so a hash stored for one ride
+// does not verify on another. The PIN must pass ValidatePIN and rideID must
+// not be empty.
+//
+// This hardens verification against leaked ride records; it is not a
+// password hash. With only ~10,000 possible PINs, anyone holding the secret
+// can recover a PIN by trying them all, so the secret must be kept apart
+// from the stored hashes.
+func HashPIN(pin, rideID string) (string, error) {
+	if err := ValidatePIN(pin); err != nil {
+		return "", err
+	}
+	if rideID == "" {
+		return "", valerrors.Required("ride_id")
+	}
+	mac, err := pinMAC(pin, rideID)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(mac), nil
+}
+
+// VerifyPINHash reports whether provided is the PIN that HashPIN hashed to
+// hash for rideID. The hashes are compared in constant time. It returns
+// false if provided fails ValidatePIN, hash is not a valid hash, or no
+// secret is configured.
+func VerifyPINHash(hash, provided, rideID string) bool {
+	want, err := hex.DecodeString(hash)
+	if err != nil || ValidatePIN(provided) != nil || rideID == "" {
+		return false
+	}
+	got, err := pinMAC(provided, rideID)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(got, want)
+}
+
+// pinMAC computes the HMAC of pin for rideID with the configured secret.
+func pinMAC(pin, rideID string) ([]byte, error) {
+	provider := pinSecretProvider.Load()
+	if provider == nil {
+		return nil, ErrPINSecretNotConfigured
+	}
+	secret, err := (*provider)()
+	if err != nil {
+		return nil, fmt.Errorf("loading PIN hash secret: %w", err)
+	}
+	if len(secret) == 0 {
+		return nil, ErrPINSecretNotConfigured
+	}
+	mac := hmac.New(sha256.New, secret)
+	// The separator keeps ride ID and PIN from running together.
+	mac.Write([]byte(rideID))
+	mac.Write([]byte{0})
+	mac.Write([]byte(pin))
+	return mac.Sum(nil), nil
+}
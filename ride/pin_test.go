@@ -0,0 +1,272 @@
+package ride
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// pinBytes encodes draws as the big-endian uint16 values drawPIN reads.
+func pinBytes(draws ...uint16) []byte {
+	b := make([]byte, 0, 2*len(draws))
+	for _, d := range draws {
+		b = append(b, byte(d>>8), byte(d))
+	}
+	return b
+}
+
+func TestGeneratePIN_Deterministic(t *testing.T) {
+	tests := []struct {
+		name    string
+		draws   []byte
+		want    string
+		wantErr bool
+	}{
+		{"first draw valid", pinBytes(7392), "7392", false},
+		{"leading zeros kept", pinBytes(42), "0042", false},
+		{"value reduced modulo 10000", pinBytes(17392), "7392", false},
+		{"repeated rejected", pinBytes(1111, 4826), "4826", false},
+		{"sequential rejected", pinBytes(1234, 4321, 3000), "3000", false},
+		{"biased draw discarded", pinBytes(60000, 65535, 5071), "5071", false},
+		{"source exhausted", pinBytes(1111), "", true},
+		{"truncated draw", []byte{0x1c}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := generatePIN(bytes.NewReader(tt.draws))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generatePIN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("generatePIN() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeneratePIN_ReaderError(t *testing.T) {
+	_, err := generatePIN(io.MultiReader())
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("generatePIN() error = %v, want wrapped io.EOF", err)
+	}
+}
+
+func TestGeneratePIN_AttemptsExhausted(t *testing.T) {
+	draws := make([]uint16, maxPINAttempts+1)
+	for i := range draws {
+		draws[i] = 2222
+	}
+	_, err := generatePIN(bytes.NewReader(pinBytes(draws...)))
+	if err == nil {
+		t.Fatal("generatePIN() expected error when every draw is invalid")
+	}
+}
+
+func TestGeneratePIN_Distribution(t *testing.T) {
+	const n = 10000
+	var counts [4][10]int
+	for range n {
+		pin, err := GeneratePIN()
+		if err != nil {
+			t.Fatalf("GeneratePIN() error = %v", err)
+		}
+		if err := ValidatePIN(pin); err != nil {
+			t.Fatalf("GeneratePIN() = %q, fails ValidatePIN: %v", pin, err)
+		}
+		for pos, c := range pin {
+			counts[pos][c-'0']++
+		}
+	}
+	// Each digit is expected about 1000 times per position with a standard
+	// deviation of 30; 800-1200 is far outside chance but catches gross bias.
+	for pos := range counts {
+		for digit, c := range counts[pos] {
+			if c < 800 || c > 1200 {
+				t.Errorf("digit %d at position %d appeared %d times, want 800-1200", digit, pos, c)
+			}
+		}
+	}
+}
+
+func TestGeneratePINs(t *testing.T) {
+	pins, err := GeneratePINs(MaxPINBatch)
+	if err != nil {
+		t.Fatalf("GeneratePINs() error = %v", err)
+	}
+	if len(pins) != MaxPINBatch {
+		t.Fatalf("GeneratePINs() returned %d PINs, want %d", len(pins), MaxPINBatch)
+	}
+	seen := make(map[string]bool, len(pins))
+	for _, pin := range pins {
+		if !IsValidPIN(pin) {
+			t.Errorf("GeneratePINs() returned invalid PIN %q", pin)
+		}
+		if seen[pin] {
+			t.Errorf("GeneratePINs() returned %q twice", pin)
+		}
+		seen[pin] = true
+	}
+}
+
+func TestGeneratePINs_SkipsDuplicates(t *testing.T) {
+	got, err := generatePINs(bytes.NewReader(pinBytes(7392, 7392, 4826)), 2)
+	if err != nil {
+		t.Fatalf("generatePINs() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != "7392" || got[1] != "4826" {
+		t.Errorf("generatePINs() = %v, want [7392 4826]", got)
+	}
+}
+
+func TestGeneratePINs_Count(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		wantErr bool
+	}{
+		{"zero", 0, false},
+		{"negative", -1, true},
+		{"over batch limit", MaxPINBatch + 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GeneratePINs(tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GeneratePINs(%d) error = %v, wantErr %v", tt.n, err, tt.wantErr)
+			}
+			if !tt.wantErr && len(got) != tt.n {
+				t.Errorf("GeneratePINs(%d) returned %d PINs", tt.n, len(got))
+			}
+		})
+	}
+}
+
+// withPINSecret sets a fixed PIN hash secret for the duration of the test.
+func withPINSecret(t *testing.T, secret string) {
+	t.Helper()
+	SetPINSecretProvider(func() ([]byte, error) { return []byte(secret), nil })
+	t.Cleanup(func() { SetPINSecretProvider(nil) })
+}
+
+func TestComparePIN(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		provided string
+		want     bool
+	}{
+		{"match", "7392", "7392", true},
+		{"mismatch", "7392", "7393", false},
+		{"different length", "7392", "73920", false},
+		{"empty provided", "7392", "", false},
+		{"invalid expected", "1234", "1234", false},
+		{"invalid provided", "1111", "1111", false},
+		{"non-digit", "73a2", "73a2", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComparePIN(tt.expected, tt.provided); got != tt.want {
+				t.Errorf("ComparePIN(%q, %q) = %v, want %v", tt.expected, tt.provided, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashPIN_RoundTrip(t *testing.T) {
+	withPINSecret(t, "test-secret")
+
+	hash, err := HashPIN("7392", "ride-123")
+	if err != nil {
+		t.Fatalf("HashPIN() error = %v", err)
+	}
+	if len(hash) != 64 {
+		t.Errorf("HashPIN() = %q, want 64 hex characters", hash)
+	}
+	if again, _ := HashPIN("7392", "ride-123"); again != hash {
+		t.Errorf("HashPIN() not deterministic: %q != %q", again, hash)
+	}
+
+	tests := []struct {
+		name     string
+		hash     string
+		provided string
+		rideID   string
+		want     bool
+	}{
+		{"correct PIN", hash, "7392", "ride-123", true},
+		{"wrong PIN", hash, "4826", "ride-123", false},
+		{"wrong ride ID", hash, "7392", "ride-124", false},
+		{"empty ride ID", hash, "7392", "", false},
+		{"invalid provided PIN", hash, "1234", "ride-123", false},
+		{"malformed hash", "not-hex", "7392", "ride-123", false},
+		{"truncated hash", hash[:32], "7392", "ride-123", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyPINHash(tt.hash, tt.provided, tt.rideID); got != tt.want {
+				t.Errorf("VerifyPINHash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashPIN_SecretChange(t *testing.T) {
+	withPINSecret(t, "old-secret")
+	hash, err := HashPIN("7392", "ride-123")
+	if err != nil {
+		t.Fatalf("HashPIN() error = %v", err)
+	}
+	withPINSecret(t, "new-secret")
+	if VerifyPINHash(hash, "7392", "ride-123") {
+		t.Error("VerifyPINHash() = true after the secret changed")
+	}
+}
+
+func TestHashPIN_Errors(t *testing.T) {
+	t.Run("no provider", func(t *testing.T) {
+		SetPINSecretProvider(nil)
+		if _, err := HashPIN("7392", "ride-123"); !errors.Is(err, ErrPINSecretNotConfigured) {
+			t.Errorf("HashPIN() error = %v, want ErrPINSecretNotConfigured", err)
+		}
+		if VerifyPINHash("00", "7392", "ride-123") {
+			t.Error("VerifyPINHash() = true without a secret")
+		}
+	})
+
+	t.Run("empty secret", func(t *testing.T) {
+		withPINSecret(t, "")
+		if _, err := HashPIN("7392", "ride-123"); !errors.Is(err, ErrPINSecretNotConfigured) {
+			t.Errorf("HashPIN() error = %v, want ErrPINSecretNotConfigured", err)
+		}
+	})
+
+	t.Run("provider error", func(t *testing.T) {
+		errStore := errors.New("secret store unavailable")
+		SetPINSecretProvider(func() ([]byte, error) { return nil, errStore })
+		t.Cleanup(func() { SetPINSecretProvider(nil) })
+		if _, err := HashPIN("7392", "ride-123"); !errors.Is(err, errStore) {
+			t.Errorf("HashPIN() error = %v, want wrapped provider error", err)
+		}
+	})
+
+	t.Run("invalid PIN", func(t *testing.T) {
+		withPINSecret(t, "test-secret")
+		_, err := HashPIN("1234", "ride-123")
+		ve, ok := err.(valerrors.ValidationError)
+		if !ok || ve.Code != valerrors.CodeInvalidFormat || ve.Field != "pin" {
+			t.Errorf("HashPIN() error = %v, want INVALID_FORMAT on pin", err)
+		}
+	})
+
+	t.Run("empty ride ID", func(t *testing.T) {
+		withPINSecret(t, "test-secret")
+		_, err := HashPIN("7392", "")
+		ve, ok := err.(valerrors.ValidationError)
+		if !ok || ve.Code != valerrors.CodeRequired || ve.Field != "ride_id" {
+			t.Errorf("HashPIN() error = %v, want REQUIRED on ride_id", err)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package ride
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"strings"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// Ride reference format: RideReferencePrefix followed by
+// RideReferenceLength characters from RideReferenceAlphabet, e.g. TXV-7K3M9Q.
+const (
+	RideReferencePrefix = "TXV-"
+	RideReferenceLength = 6
+	// RideReferenceAlphabet holds the 32 characters used in ride references:
+	// uppercase letters without I and O, and digits 2 to 9, so that no two
+	// characters are easily confused when read aloud or from a receipt.
+	RideReferenceAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+// rideReferenceExpected describes the ride reference format in errors.
+const rideReferenceExpected = "TXV- followed by 6 characters from A-Z (no I or O) and 2-9"
+
+// ValidateRideReference validates a ride reference such as TXV-7K3M9Q.
+// The reference must already be normalized; see NormalizeRideReference.
+func ValidateRideReference(ref string) error {
+	code, ok := strings.CutPrefix(ref, RideReferencePrefix)
+	if !ok || len(code) != RideReferenceLength {
+		return valerrors.InvalidFormatWithValue("ride_reference", rideReferenceExpected, ref)
+	}
+	for _, c := range code {
+		if !strings.ContainsRune(RideReferenceAlphabet, c) {
+			return valerrors.InvalidFormatWithValue("ride_reference", rideReferenceExpected, ref)
+		}
+	}
+	return nil
+}
+
+// NormalizeRideReference trims surrounding whitespace, uppercases the
+// reference, and inserts the dash after TXV if it is missing, so
+// " txv7k3m9q " becomes "TXV-7K3M9Q". The result still needs
+// ValidateRideReference.
+func NormalizeRideReference(ref string) string {
+	ref = strings.ToUpper(strings.TrimSpace(ref))
+	prefix := strings.TrimSuffix(RideReferencePrefix, "-")
+	if code, ok := strings.CutPrefix(ref, prefix); ok && !strings.HasPrefix(code, "-") {
+		return RideReferencePrefix + code
+	}
+	return ref
+}
+
+// GenerateRideReference returns a random ride reference drawn from
+// crypto/rand. Each character carries 5 bits, so a reference has 30 bits of
+// entropy (about one billion values); by the birthday bound, collisions
+// become likely after some tens of thousands of references. References are
+// not guaranteed unique: callers must check for collisions when storing them.
+func GenerateRideReference() (string, error) {
+	return generateRideReference(rand.Reader)
+}
+
+// generateRideReference draws a ride reference from r. Tests pass a
+// deterministic reader.
+func generateRideReference(r io.Reader) (string, error) {
+	var buf [RideReferenceLength]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return "", fmt.Errorf("generating ride reference: %w", err)
+	}
+	// The alphabet's 32 characters divide 256, so reducing each byte is unbiased.
+	for i, b := range buf {
+		buf[i] = RideReferenceAlphabet[b%byte(len(RideReferenceAlphabet))]
+	}
+	return RideReferencePrefix + string(buf[:]), nil
+}
+
+// IsValidRideReference returns true if the ride reference is valid.
+func IsValidRideReference(ref string) bool {
+	return ValidateRideReference(ref) == nil
+}
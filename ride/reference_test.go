@@ -0,0 +1,101 @@
+package ride
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateRideReference(t *testing.T) {
+	tests := []struct {
+		name    string
+		ref     string
+		wantErr bool
+	}{
+		{"valid", "TXV-7K3M9Q", false},
+		{"all letters", "TXV-ABCDEF", false},
+		{"all digits", "TXV-234567", false},
+		{"lowercase", "txv-7k3m9q", true},
+		{"missing dash", "TXV7K3M9Q", true},
+		{"wrong prefix", "TXO-7K3M9Q", true},
+		{"too short", "TXV-7K3M9", true},
+		{"too long", "TXV-7K3M9QA", true},
+		{"forbidden I", "TXV-7K3I9Q", true},
+		{"forbidden O", "TXV-7KOM9Q", true},
+		{"forbidden 0", "TXV-7K0M9Q", true},
+		{"forbidden 1", "TXV-7K1M9Q", true},
+		{"non-ASCII", "TXV-7K3M9Ä", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRideReference(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateRideReference(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+			}
+			if IsValidRideReference(tt.ref) == tt.wantErr {
+				t.Errorf("IsValidRideReference(%q) = %v", tt.ref, tt.wantErr)
+			}
+			if tt.wantErr {
+				if ve := err.(valerrors.ValidationError); ve.Field != "ride_reference" || ve.Code != valerrors.CodeInvalidFormat {
+					t.Errorf("error = %s/%s, want ride_reference/INVALID_FORMAT", ve.Field, ve.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeRideReference(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"TXV-7K3M9Q", "TXV-7K3M9Q"},
+		{"txv-7k3m9q", "TXV-7K3M9Q"},
+		{" txv7k3m9q ", "TXV-7K3M9Q"},
+		{"TXV7K3M9Q", "TXV-7K3M9Q"},
+		{"7K3M9Q", "7K3M9Q"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeRideReference(tt.input); got != tt.want {
+			t.Errorf("NormalizeRideReference(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateRideReference(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 1000 {
+		ref, err := GenerateRideReference()
+		if err != nil {
+			t.Fatalf("GenerateRideReference() error = %v", err)
+		}
+		if err := ValidateRideReference(ref); err != nil {
+			t.Fatalf("GenerateRideReference() = %q, fails validation: %v", ref, err)
+		}
+		seen[ref] = true
+	}
+	// 1000 draws from 2^30 values collide with probability below 0.1%.
+	if len(seen) < 999 {
+		t.Errorf("GenerateRideReference() produced only %d distinct references in 1000", len(seen))
+	}
+}
+
+func TestGenerateRideReference_Deterministic(t *testing.T) {
+	ref, err := generateRideReference(bytes.NewReader([]byte{0, 1, 31, 32, 255, 24}))
+	if err != nil {
+		t.Fatalf("generateRideReference() error = %v", err)
+	}
+	if ref != "TXV-AB9A92" {
+		t.Errorf("generateRideReference() = %q, want TXV-AB9A92", ref)
+	}
+
+	_, err = generateRideReference(bytes.NewReader([]byte{0, 1, 2}))
+	if err == nil || !strings.Contains(err.Error(), "generating ride reference") {
+		t.Errorf("generateRideReference() with short source error = %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package ride
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dorico-Dynamics/txova-go-types/geo"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+	geoval "github.com/Dorico-Dynamics/txova-go-validation/geo"
+)
+
+// Scheduling limits for rides booked in advance.
+const (
+	// MinScheduleLead is how far ahead a scheduled ride must be booked, so a
+	// driver can be assigned.
+	MinScheduleLead = 15 * time.Minute
+	// MaxScheduleAhead is how far ahead a ride may be scheduled.
+	MaxScheduleAhead = 30 * 24 * time.Hour
+)
+
+// RideRequest is a rider's request for a ride, as received by the booking
+// handler.
+type RideRequest struct {
+	Pickup  geo.Location
+	Dropoff geo.Location
+	// Stops are optional intermediate stops, in order.
+	Stops        []geo.Location
+	FareCentavos int64
+	DistanceKM   float64
+	Category     string
+	// ScheduledAt is the requested pickup time, or nil for an immediate ride.
+	ScheduledAt *time.Time
+	// PromoCode is an optional referral or promo code.
+	PromoCode  string
+	Passengers int
+}
+
+// RequestOptions configures ValidateRideRequest.
+type RequestOptions struct {
+	// Now is the current time for the schedule check. The zero value uses
+	// time.Now.
+	Now time.Time
+	// RequireSameArea rejects rides whose pickup and dropoff are in
+	// different service areas; see LocationOptions.
+	RequireSameArea bool
+}
+
+// ValidateScheduledAt validates the pickup time of a scheduled ride: it must
+// be at least MinScheduleLead and at most MaxScheduleAhead after now.
+// Returns OUT_OF_RANGE on "scheduled_at" with the accepted times in RFC 3339
+// as the range.
+func ValidateScheduledAt(at, now time.Time) error {
+	earliest, latest := now.Add(MinScheduleLead), now.Add(MaxScheduleAhead)
+	if at.Before(earliest) || at.After(latest) {
+		ve := valerrors.OutOfRangeWithValue("scheduled_at",
+			earliest.Format(time.RFC3339), latest.Format(time.RFC3339), at.Format(time.RFC3339))
+		ve.Message = fmt.Sprintf("scheduled_at must be between %v and %v from now", MinScheduleLead, MaxScheduleAhead)
+		return ve
+	}
+	return nil
+}
+
+// ValidateRideRequest runs every applicable check on a ride request and
+// returns all failures, in this order:
+//   - pickup and dropoff with ValidateRideLocations;
+//   - stops with ValidateWaypoints, on fields such as "stops[0]" and
+//     "route", when there are any;
+//   - distance and fare with ValidateDistanceInArea and ValidateFareInArea
+//     for the pickup's service area;
+//   - category with ValidateVehicleCategory on "vehicle_category", then
+//     passengers with ValidatePassengerCount when the category is valid;
+//   - ScheduledAt with ValidateScheduledAt, unless it is nil;
+//   - PromoCode, after NormalizeReferralCode, with ValidateReferralCode on
+//     "promo_code", unless it is empty.
+//
+// Returns nil if the request is valid.
+func ValidateRideRequest(r RideRequest, opts RequestOptions) valerrors.ValidationErrors {
+	errs := ValidateRideLocations(r.Pickup, r.Dropoff, LocationOptions{RequireSameArea: opts.RequireSameArea})
+	if len(r.Stops) > 0 {
+		for _, e := range ValidateWaypoints(r.Pickup, r.Dropoff, r.Stops) {
+			// A missing pickup or dropoff is already reported above.
+			if e.Field != "pickup" && e.Field != "dropoff" {
+				errs.Add(e)
+			}
+		}
+	}
+
+	area := geoval.FindServiceArea(r.Pickup.Latitude(), r.Pickup.Longitude())
+	addError(&errs, ValidateDistanceInArea(r.DistanceKM, area))
+	addError(&errs, ValidateFareInArea(r.FareCentavos, area))
+
+	if category, err := NormalizeVehicleCategory(r.Category); err != nil {
+		addError(&errs, err)
+	} else {
+		addError(&errs, ValidatePassengerCount(category, r.Passengers))
+	}
+
+	if r.ScheduledAt != nil {
+		now := opts.Now
+		if now.IsZero() {
+			now = time.Now()
+		}
+		addError(&errs, ValidateScheduledAt(*r.ScheduledAt, now))
+	}
+
+	if r.PromoCode != "" {
+		if err := ValidateReferralCode(NormalizeReferralCode(r.PromoCode)); err != nil {
+			ve := err.(valerrors.ValidationError)
+			ve.Field = "promo_code"
+			ve.Value = r.PromoCode
+			errs.Add(ve)
+		}
+	}
+	return errs
+}
+
+// addError adds err to errs if it is a ValidationError.
+func addError(errs *valerrors.ValidationErrors, err error) {
+	if ve, ok := err.(valerrors.ValidationError); ok {
+		errs.Add(ve)
+	}
+}
@@ -0,0 +1,116 @@
+package ride
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dorico-Dynamics/txova-go-types/geo"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateRideRequest(t *testing.T) {
+	now := time.Date(2025, time.June, 10, 12, 0, 0, 0, time.UTC)
+	inOneHour := now.Add(time.Hour)
+	yesterday := now.Add(-24 * time.Hour)
+
+	valid := RideRequest{
+		Pickup:       geo.MustNewLocation(-25.9692, 32.5732),
+		Dropoff:      geo.MustNewLocation(-25.9000, 32.6000),
+		FareCentavos: 25000,
+		DistanceKM:   8.5,
+		Category:     "Comfort",
+		ScheduledAt:  &inOneHour,
+		PromoCode:    "txova 3a9b2c",
+		Passengers:   3,
+	}
+
+	type fieldCode struct{ field, code string }
+	tests := []struct {
+		name   string
+		modify func(r *RideRequest)
+		want   []fieldCode
+	}{
+		{"fully valid", func(r *RideRequest) {}, nil},
+		{"immediate ride without promo", func(r *RideRequest) {
+			r.ScheduledAt = nil
+			r.PromoCode = ""
+		}, nil},
+		{"five failures", func(r *RideRequest) {
+			r.FareCentavos = 1000
+			r.DistanceKM = 0.1
+			r.Category = "limo"
+			r.ScheduledAt = &yesterday
+			r.PromoCode = "BAD"
+		}, []fieldCode{
+			{"distance", valerrors.CodeOutOfRange},
+			{"fare", valerrors.CodeOutOfRange},
+			{"vehicle_category", valerrors.CodeInvalidOption},
+			{"scheduled_at", valerrors.CodeOutOfRange},
+			{"promo_code", valerrors.CodeInvalidFormat},
+		}},
+		{"too many passengers", func(r *RideRequest) { r.Category = "moto" },
+			[]fieldCode{{"passengers", valerrors.CodeOutOfRange}}},
+		{"missing dropoff and category", func(r *RideRequest) {
+			r.Dropoff = geo.Location{}
+			r.Category = ""
+		}, []fieldCode{
+			{"dropoff", valerrors.CodeRequired},
+			{"vehicle_category", valerrors.CodeRequired},
+		}},
+		{"invalid stop", func(r *RideRequest) {
+			r.Stops = []geo.Location{geo.MustNewLocation(-26.2041, 28.0473)}
+		}, []fieldCode{{"stops[0]", valerrors.CodeOutsideServiceArea}, {"route", valerrors.CodeOutOfRange}}},
+		{"scheduled too soon", func(r *RideRequest) {
+			soon := now.Add(5 * time.Minute)
+			r.ScheduledAt = &soon
+		}, []fieldCode{{"scheduled_at", valerrors.CodeOutOfRange}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid
+			tt.modify(&r)
+			errs := ValidateRideRequest(r, RequestOptions{Now: now})
+			var got []fieldCode
+			for _, e := range errs {
+				got = append(got, fieldCode{e.Field, e.Code})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateRideRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateScheduledAt(t *testing.T) {
+	now := time.Date(2025, time.June, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		at      time.Time
+		wantErr bool
+	}{
+		{"minimum lead", now.Add(MinScheduleLead), false},
+		{"maximum ahead", now.Add(MaxScheduleAhead), false},
+		{"just under lead", now.Add(MinScheduleLead - time.Second), true},
+		{"past", now.Add(-time.Minute), true},
+		{"too far ahead", now.Add(MaxScheduleAhead + time.Second), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateScheduledAt(tt.at, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateScheduledAt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				ve := err.(valerrors.ValidationError)
+				if ve.Field != "scheduled_at" || ve.Code != valerrors.CodeOutOfRange {
+					t.Errorf("error = %s/%s, want scheduled_at/OUT_OF_RANGE", ve.Field, ve.Code)
+				}
+			}
+		})
+	}
+}
@@ -2,11 +2,16 @@
 package ride
 
 import (
+	"fmt"
+	"strings"
+	"unicode"
+
 	"github.com/Dorico-Dynamics/txova-go-types/geo"
 	"github.com/Dorico-Dynamics/txova-go-types/money"
 	"github.com/Dorico-Dynamics/txova-go-types/ride"
 
 	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+	geoval "github.com/Dorico-Dynamics/txova-go-validation/geo"
 )
 
 // Distance constraints in kilometers.
@@ -21,9 +26,51 @@ const (
 	MaxFareCentavos = 5000000 // 50,000 MZN
 )
 
+// DefaultMaxTollFareCentavos is the default cap for a ride's toll component (100 MZN).
+const DefaultMaxTollFareCentavos = 10000
+
+// Referral code format: ReferralCodePrefix followed by ReferralCodeSuffixLength
+// uppercase letters or digits, e.g. TXOVA3A9B2C.
+const (
+	ReferralCodePrefix       = "TXOVA"
+	ReferralCodeSuffixLength = 6
+)
+
+// Luggage limits per ride.
+const (
+	MaxLargeBags    = 4
+	MaxSmallBags    = 6
+	MaxLuggageItems = 6
+	// MaxFragileLargeBags is the number of large bags above which fragile
+	// items draw a warning.
+	MaxFragileLargeBags = 2
+)
+
 // Minimum separation between pickup and dropoff in kilometers.
 const MinPickupDropoffSeparationKM = 0.1
 
+// Ride statuses. A ride moves REQUESTED → ACCEPTED → ARRIVING → IN_PROGRESS →
+// COMPLETED, and may be CANCELLED from any status before it completes.
+const (
+	StatusRequested  = "REQUESTED"
+	StatusAccepted   = "ACCEPTED"
+	StatusArriving   = "ARRIVING"
+	StatusInProgress = "IN_PROGRESS"
+	StatusCompleted  = "COMPLETED"
+	StatusCancelled  = "CANCELLED"
+)
+
+// rideStatusTransitions maps each status to the statuses it may move to.
+// COMPLETED and CANCELLED are terminal.
+var rideStatusTransitions = map[string][]string{
+	StatusRequested:  {StatusAccepted, StatusCancelled},
+	StatusAccepted:   {StatusArriving, StatusCancelled},
+	StatusArriving:   {StatusInProgress, StatusCancelled},
+	StatusInProgress: {StatusCompleted, StatusCancelled},
+	StatusCompleted:  nil,
+	StatusCancelled:  nil,
+}
+
 // ValidatePIN validates a 4-digit ride verification PIN.
 // Uses the types library which enforces no sequential (1234, 4321) or repeated (1111) patterns.
 func ValidatePIN(input string) error {
@@ -34,6 +81,45 @@ func ValidatePIN(input string) error {
 	return nil
 }
 
+// ValidatePINHistory validates a new PIN and rejects reuse of any PIN in recentPINs.
+// The caller is responsible for maintaining the history list.
+func ValidatePINHistory(newPIN string, recentPINs []string) error {
+	if err := ValidatePIN(newPIN); err != nil {
+		return err
+	}
+	for _, pin := range recentPINs {
+		if pin == newPIN {
+			return valerrors.New("pin", valerrors.CodeDuplicate, "PIN has been used recently")
+		}
+	}
+	return nil
+}
+
+// ValidateReferralCode validates a referral or promo code such as TXOVA3A9B2C.
+// The code must already be normalized; see NormalizeReferralCode.
+func ValidateReferralCode(code string) error {
+	suffix, ok := strings.CutPrefix(code, ReferralCodePrefix)
+	if !ok || len(suffix) != ReferralCodeSuffixLength {
+		return valerrors.InvalidFormatWithValue("referral_code", "TXOVA followed by 6 uppercase letters or digits", code)
+	}
+	for _, c := range suffix {
+		if !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') {
+			return valerrors.InvalidFormatWithValue("referral_code", "TXOVA followed by 6 uppercase letters or digits", code)
+		}
+	}
+	return nil
+}
+
+// NormalizeReferralCode removes all whitespace and uppercases the code.
+func NormalizeReferralCode(code string) string {
+	return strings.ToUpper(strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, code))
+}
+
 // ValidateDistance validates that a ride distance is within acceptable range.
 func ValidateDistance(km float64) error {
 	if km < MinDistanceKM || km > MaxDistanceKM {
@@ -50,6 +136,39 @@ func ValidateFare(centavos int64) error {
 	return nil
 }
 
+// ValidateTollFare validates the toll component of a fare (in centavos) against
+// an operator-configured cap. Zero is valid and means no toll was charged.
+func ValidateTollFare(tollCentavos, maxTollCentavos int64) error {
+	if tollCentavos < 0 || tollCentavos > maxTollCentavos {
+		return valerrors.OutOfRangeWithValue("toll", 0, maxTollCentavos, tollCentavos)
+	}
+	return nil
+}
+
+// ValidateLuggageInfo validates the luggage declared for a ride request.
+// The returned error is a valerrors.ValidationErrors with one entry per violation.
+// Declaring fragile items with more than MaxFragileLargeBags large bags adds a
+// SeverityWarning entry on "luggage"; callers that only block on errors should
+// check IsWarning.
+func ValidateLuggageInfo(largeBags, smallBags int, hasFragile bool) error {
+	var errs valerrors.ValidationErrors
+	if largeBags < 0 || largeBags > MaxLargeBags {
+		errs.Add(valerrors.OutOfRangeWithValue("large_bags", 0, MaxLargeBags, largeBags))
+	}
+	if smallBags < 0 || smallBags > MaxSmallBags {
+		errs.Add(valerrors.OutOfRangeWithValue("small_bags", 0, MaxSmallBags, smallBags))
+	}
+	if total := largeBags + smallBags; total > MaxLuggageItems {
+		errs.Add(valerrors.OutOfRangeWithValue("luggage", 0, MaxLuggageItems, total))
+	}
+	if hasFragile && largeBags > MaxFragileLargeBags {
+		ve := valerrors.NewWithValue("luggage", valerrors.CodeOutOfRange,
+			"fragile items with more than 2 large bags may be damaged", largeBags)
+		errs.Add(ve.WithSeverity(valerrors.SeverityWarning))
+	}
+	return errs.ToError()
+}
+
 // ValidateFareMoney validates a Money amount is within acceptable fare range.
 func ValidateFareMoney(m money.Money) error {
 	return ValidateFare(m.Centavos())
@@ -78,21 +197,63 @@ func ValidatePickupDropoff(pickupLat, pickupLon, dropoffLat, dropoffLon float64)
 }
 
 // ValidatePickupDropoffLocations validates pickup and dropoff using Location types.
+// It returns the first error from geo.ValidateDisjointPickupDropoff with
+// MinPickupDropoffSeparationKM; use that function to get every error.
 func ValidatePickupDropoffLocations(pickup, dropoff geo.Location) error {
-	if pickup.IsZero() {
-		return valerrors.Required("pickup")
+	if errs := geoval.ValidateDisjointPickupDropoff(pickup, dropoff, MinPickupDropoffSeparationKM); errs.HasErrors() {
+		return errs[0]
+	}
+	return nil
+}
+
+// ValidateRideStatus validates a ride status transition from current to next.
+// Statuses are matched case-insensitively. Returns INVALID_OPTION on
+// "current_status" or "status" for an unknown status and CONFLICT on "status"
+// when the ride cannot move from current to next.
+func ValidateRideStatus(current, next string) error {
+	normalizedCurrent := strings.ToUpper(strings.TrimSpace(current))
+	allowed, ok := rideStatusTransitions[normalizedCurrent]
+	if !ok {
+		return valerrors.InvalidOptionWithValue("current_status", AllRideStatuses(), current)
 	}
-	if dropoff.IsZero() {
-		return valerrors.Required("dropoff")
+	normalizedNext := strings.ToUpper(strings.TrimSpace(next))
+	if _, ok := rideStatusTransitions[normalizedNext]; !ok {
+		return valerrors.InvalidOptionWithValue("status", AllRideStatuses(), next)
 	}
 
-	distance := geo.DistanceKM(pickup, dropoff)
-	if distance < MinPickupDropoffSeparationKM {
-		return valerrors.New("pickup_dropoff", valerrors.CodeOutOfRange,
-			"pickup and dropoff must be at least 100 meters apart")
+	for _, s := range allowed {
+		if s == normalizedNext {
+			return nil
+		}
 	}
+	return valerrors.NewWithValue("status", valerrors.CodeConflict,
+		fmt.Sprintf("cannot change ride status from %s to %s", normalizedCurrent, normalizedNext), next).
+		WithParam("current_status", normalizedCurrent)
+}
 
-	return nil
+// GetValidNextStatuses returns the statuses a ride in the current status may
+// move to. Returns nil for a terminal or unknown status.
+func GetValidNextStatuses(current string) []string {
+	next := rideStatusTransitions[strings.ToUpper(strings.TrimSpace(current))]
+	if len(next) == 0 {
+		return nil
+	}
+	return append([]string(nil), next...)
+}
+
+// AllRideStatuses returns every ride status in lifecycle order.
+func AllRideStatuses() []string {
+	return []string{StatusRequested, StatusAccepted, StatusArriving, StatusInProgress, StatusCompleted, StatusCancelled}
+}
+
+// IsValidRideStatusTransition returns true if a ride may move from current to next.
+func IsValidRideStatusTransition(current, next string) bool {
+	return ValidateRideStatus(current, next) == nil
+}
+
+// IsValidReferralCode returns true if the referral code is valid.
+func IsValidReferralCode(code string) bool {
+	return ValidateReferralCode(code) == nil
 }
 
 // IsValidPIN returns true if the PIN is valid.
@@ -112,7 +273,10 @@ func IsValidFare(centavos int64) bool {
 
 // CalculateEstimatedFare calculates an estimated fare based on distance.
 // This is a simplified calculation for validation purposes.
-// Returns fare in centavos.
+// Returns fare in centavos, rounded half up to the nearest centavo; earlier
+// versions truncated the distance component. Inputs are not validated and no
+// minimum fare applies; use EstimateFare for time-based pricing, surge, and
+// validation.
 func CalculateEstimatedFare(distanceKM float64, baseFareCentavos, perKMCentavos int64) int64 {
-	return baseFareCentavos + int64(distanceKM*float64(perKMCentavos))
+	return fareAmount(distanceKM, 0, FareConfig{BaseFare: baseFareCentavos, PerKM: perKMCentavos})
 }
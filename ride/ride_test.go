@@ -1,6 +1,7 @@
 package ride
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/Dorico-Dynamics/txova-go-types/geo"
@@ -51,6 +52,46 @@ func TestValidatePIN(t *testing.T) {
 	}
 }
 
+func TestValidatePINHistory(t *testing.T) {
+	recent := []string{"7392", "4826", "0392"}
+
+	tests := []struct {
+		name    string
+		pin     string
+		recent  []string
+		wantErr bool
+		errCode string
+	}{
+		{"new pin", "5190", recent, false, ""},
+		{"no history", "7392", nil, false, ""},
+		{"empty history", "7392", []string{}, false, ""},
+		{"reused most recent", "7392", recent, true, valerrors.CodeDuplicate},
+		{"reused oldest", "0392", recent, true, valerrors.CodeDuplicate},
+		{"invalid format", "1234", recent, true, valerrors.CodeInvalidFormat},
+		{"invalid and in history", "1111", []string{"1111"}, true, valerrors.CodeInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePINHistory(tt.pin, tt.recent)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePINHistory(%q) error = %v, wantErr %v", tt.pin, err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if ve, ok := err.(valerrors.ValidationError); ok {
+					if ve.Code != tt.errCode {
+						t.Errorf("error code = %v, want %v", ve.Code, tt.errCode)
+					}
+					if ve.Field != "pin" {
+						t.Errorf("error field = %v, want pin", ve.Field)
+					}
+				}
+			}
+		})
+	}
+}
+
 func TestValidateDistance(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -123,6 +164,160 @@ func TestValidateFare(t *testing.T) {
 	}
 }
 
+func TestValidateReferralCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		wantErr bool
+	}{
+		{"valid", "TXOVA3A9B2C", false},
+		{"all digits", "TXOVA123456", false},
+		{"all letters", "TXOVAABCDEF", false},
+		{"wrong prefix", "TXOBA3A9B2C", true},
+		{"missing prefix", "3A9B2C", true},
+		{"prefix only", "TXOVA", true},
+		{"suffix too short", "TXOVA3A9B2", true},
+		{"suffix too long", "TXOVA3A9B2C1", true},
+		{"lowercase", "txova3a9b2c", true},
+		{"lowercase suffix", "TXOVA3a9b2c", true},
+		{"symbol", "TXOVA3A9-2C", true},
+		{"inner space", "TXOVA 3A9B2C", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReferralCode(tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateReferralCode(%q) error = %v, wantErr %v", tt.code, err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if ve, ok := err.(valerrors.ValidationError); !ok || ve.Code != valerrors.CodeInvalidFormat {
+					t.Errorf("error = %v, want %v", err, valerrors.CodeInvalidFormat)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeReferralCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"already normalized", "TXOVA3A9B2C", "TXOVA3A9B2C"},
+		{"lowercase", "txova3a9b2c", "TXOVA3A9B2C"},
+		{"surrounding whitespace", "  TXOVA3A9B2C\n", "TXOVA3A9B2C"},
+		{"inner whitespace", "txova 3a9 b2c", "TXOVA3A9B2C"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NormalizeReferralCode(tt.input)
+			if got != tt.want {
+				t.Errorf("NormalizeReferralCode(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if err := ValidateReferralCode(got); tt.want != "" && err != nil {
+				t.Errorf("ValidateReferralCode(NormalizeReferralCode(%q)) error = %v", tt.input, err)
+			}
+		})
+	}
+}
+
+func TestValidateLuggageInfo(t *testing.T) {
+	tests := []struct {
+		name       string
+		large      int
+		small      int
+		fragile    bool
+		wantFields []string
+		wantWarn   bool
+	}{
+		{"no luggage", 0, 0, false, nil, false},
+		{"typical", 2, 2, true, nil, false},
+		{"at limits", 4, 2, false, nil, false},
+		{"small only at limit", 0, 6, false, nil, false},
+		{"negative large", -1, 0, false, []string{"large_bags"}, false},
+		{"too many large", 5, 0, false, []string{"large_bags"}, false},
+		{"too many small", 0, 7, false, []string{"small_bags", "luggage"}, false},
+		{"too many items", 3, 4, false, []string{"luggage"}, false},
+		{"fragile with 3 large", 3, 0, true, []string{"luggage"}, true},
+		{"all violations", 5, 7, true, []string{"large_bags", "small_bags", "luggage", "luggage"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLuggageInfo(tt.large, tt.small, tt.fragile)
+			if len(tt.wantFields) == 0 {
+				if err != nil {
+					t.Errorf("ValidateLuggageInfo(%d, %d, %v) error = %v", tt.large, tt.small, tt.fragile, err)
+				}
+				return
+			}
+
+			errs, ok := err.(valerrors.ValidationErrors)
+			if !ok {
+				t.Fatalf("ValidateLuggageInfo() error = %T, want ValidationErrors", err)
+			}
+			if len(errs) != len(tt.wantFields) {
+				t.Fatalf("ValidateLuggageInfo() = %v, want fields %v", errs, tt.wantFields)
+			}
+			warned := false
+			for i, e := range errs {
+				if e.Field != tt.wantFields[i] {
+					t.Errorf("error[%d].Field = %v, want %v", i, e.Field, tt.wantFields[i])
+				}
+				if e.Code != valerrors.CodeOutOfRange {
+					t.Errorf("error[%d].Code = %v, want %v", i, e.Code, valerrors.CodeOutOfRange)
+				}
+				warned = warned || e.IsWarning()
+			}
+			if warned != tt.wantWarn {
+				t.Errorf("warning present = %v, want %v", warned, tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestValidateTollFare(t *testing.T) {
+	tests := []struct {
+		name    string
+		toll    int64
+		maxToll int64
+		wantErr bool
+	}{
+		// Valid tolls
+		{"no toll", 0, DefaultMaxTollFareCentavos, false},
+		{"typical toll", 3500, DefaultMaxTollFareCentavos, false},
+		{"at cap", 10000, DefaultMaxTollFareCentavos, false},
+		{"custom cap", 15000, 20000, false},
+
+		// Invalid tolls
+		{"negative", -1, DefaultMaxTollFareCentavos, true},
+		{"above cap", 10001, DefaultMaxTollFareCentavos, true},
+		{"above custom cap", 5000, 4000, true},
+		{"any toll with zero cap", 1, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTollFare(tt.toll, tt.maxToll)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTollFare(%d, %d) error = %v, wantErr %v", tt.toll, tt.maxToll, err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if ve, ok := err.(valerrors.ValidationError); ok && ve.Code != valerrors.CodeOutOfRange {
+					t.Errorf("error code = %v, want %v", ve.Code, valerrors.CodeOutOfRange)
+				}
+			}
+		})
+	}
+}
+
 func TestValidateFareMoney(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -262,6 +457,96 @@ func TestIsValidDistance(t *testing.T) {
 	}
 }
 
+func TestValidateRideStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   string
+		next      string
+		wantField string
+		wantCode  string
+	}{
+		// Valid transitions
+		{"requested to accepted", StatusRequested, StatusAccepted, "", ""},
+		{"accepted to arriving", StatusAccepted, StatusArriving, "", ""},
+		{"arriving to in progress", StatusArriving, StatusInProgress, "", ""},
+		{"in progress to completed", StatusInProgress, StatusCompleted, "", ""},
+		{"requested to cancelled", StatusRequested, StatusCancelled, "", ""},
+		{"accepted to cancelled", StatusAccepted, StatusCancelled, "", ""},
+		{"arriving to cancelled", StatusArriving, StatusCancelled, "", ""},
+		{"in progress to cancelled", StatusInProgress, StatusCancelled, "", ""},
+		{"case insensitive", " requested ", "accepted", "", ""},
+
+		// Invalid transitions
+		{"completed to in progress", StatusCompleted, StatusInProgress, "status", valerrors.CodeConflict},
+		{"cancelled to requested", StatusCancelled, StatusRequested, "status", valerrors.CodeConflict},
+		{"completed to cancelled", StatusCompleted, StatusCancelled, "status", valerrors.CodeConflict},
+		{"skipping accepted", StatusRequested, StatusArriving, "status", valerrors.CodeConflict},
+		{"going backwards", StatusInProgress, StatusArriving, "status", valerrors.CodeConflict},
+		{"same status", StatusAccepted, StatusAccepted, "status", valerrors.CodeConflict},
+		{"completing early", StatusAccepted, StatusCompleted, "status", valerrors.CodeConflict},
+
+		// Unknown statuses
+		{"unknown current", "PAUSED", StatusAccepted, "current_status", valerrors.CodeInvalidOption},
+		{"unknown next", StatusRequested, "PAUSED", "status", valerrors.CodeInvalidOption},
+		{"empty current", "", StatusAccepted, "current_status", valerrors.CodeInvalidOption},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRideStatus(tt.current, tt.next)
+			if got := IsValidRideStatusTransition(tt.current, tt.next); got != (tt.wantCode == "") {
+				t.Errorf("IsValidRideStatusTransition(%q, %q) = %v", tt.current, tt.next, got)
+			}
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("ValidateRideStatus(%q, %q) error = %v, want nil", tt.current, tt.next, err)
+				}
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Field != tt.wantField || ve.Code != tt.wantCode {
+				t.Errorf("ValidateRideStatus(%q, %q) error = %v, want %s/%s",
+					tt.current, tt.next, err, tt.wantField, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestGetValidNextStatuses(t *testing.T) {
+	tests := []struct {
+		current string
+		want    []string
+	}{
+		{StatusRequested, []string{StatusAccepted, StatusCancelled}},
+		{StatusAccepted, []string{StatusArriving, StatusCancelled}},
+		{StatusArriving, []string{StatusInProgress, StatusCancelled}},
+		{StatusInProgress, []string{StatusCompleted, StatusCancelled}},
+		{StatusCompleted, nil},
+		{StatusCancelled, nil},
+		{"PAUSED", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current, func(t *testing.T) {
+			got := GetValidNextStatuses(tt.current)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetValidNextStatuses(%q) = %v, want %v", tt.current, got, tt.want)
+			}
+			for _, next := range got {
+				if err := ValidateRideStatus(tt.current, next); err != nil {
+					t.Errorf("ValidateRideStatus(%q, %q) error = %v", tt.current, next, err)
+				}
+			}
+		})
+	}
+
+	got := GetValidNextStatuses(StatusRequested)
+	got[0] = StatusCompleted
+	if GetValidNextStatuses(StatusRequested)[0] != StatusAccepted {
+		t.Error("GetValidNextStatuses() returned a slice aliasing internal state")
+	}
+}
+
 func TestIsValidFare(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -340,4 +625,7 @@ func TestConstants(t *testing.T) {
 	if MaxFareCentavos != 5000000 {
 		t.Errorf("MaxFareCentavos = %v, want 5000000 (50,000 MZN)", MaxFareCentavos)
 	}
+	if DefaultMaxTollFareCentavos != 10000 {
+		t.Errorf("DefaultMaxTollFareCentavos = %v, want 10000 (100 MZN)", DefaultMaxTollFareCentavos)
+	}
 }
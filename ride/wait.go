@@ -0,0 +1,107 @@
+package ride
+
+import (
+	"fmt"
+	"math"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+// Wait-time billing defaults.
+const (
+	// FreeWaitMinutes is how long a driver waits at pickup before the wait is
+	// billed.
+	FreeWaitMinutes = 5.0
+	// PerWaitMinuteCentavos is the fee per billed minute of waiting (5 MZN).
+	PerWaitMinuteCentavos = 500
+	// MaxWaitMinutes is the longest plausible wait at pickup.
+	MaxWaitMinutes = 120.0
+)
+
+// WaitBilling selects how billed wait time is measured.
+type WaitBilling int
+
+// Supported wait billing modes.
+const (
+	// WaitBillingExact bills the exact time waited beyond the free minutes,
+	// including fractions of a minute.
+	WaitBillingExact WaitBilling = iota
+	// WaitBillingPerStartedMinute bills every started minute beyond the free
+	// minutes as a whole minute.
+	WaitBillingPerStartedMinute
+)
+
+// WaitConfig holds the wait-time pricing used by CalculateWaitFee.
+type WaitConfig struct {
+	FreeMinutes       float64
+	PerMinuteCentavos int64
+	Billing           WaitBilling
+}
+
+// DefaultWaitConfig returns FreeWaitMinutes and PerWaitMinuteCentavos, billed
+// per started minute.
+func DefaultWaitConfig() WaitConfig {
+	return WaitConfig{
+		FreeMinutes:       FreeWaitMinutes,
+		PerMinuteCentavos: PerWaitMinuteCentavos,
+		Billing:           WaitBillingPerStartedMinute,
+	}
+}
+
+// ValidateWaitTime validates the minutes a driver waited at pickup, from 0 to
+// MaxWaitMinutes. Returns OUT_OF_RANGE on "wait_time" otherwise.
+func ValidateWaitTime(minutes float64) error {
+	if !(minutes >= 0 && minutes <= MaxWaitMinutes) {
+		return valerrors.OutOfRangeWithValue("wait_time", 0, MaxWaitMinutes, minutes)
+	}
+	return nil
+}
+
+// CalculateWaitFee returns the wait fee in centavos for a wait of the given
+// minutes. Only time beyond cfg.FreeMinutes is billed, measured as set by
+// cfg.Billing, and the fee is rounded half up to the nearest centavo. Returns
+// the ValidateWaitTime error for an invalid wait and INVALID_FORMAT on
+// "wait_config" for negative or non-finite prices or an unknown billing mode.
+func CalculateWaitFee(minutes float64, cfg WaitConfig) (int64, error) {
+	if !(cfg.FreeMinutes >= 0) || math.IsInf(cfg.FreeMinutes, 1) || cfg.PerMinuteCentavos < 0 ||
+		(cfg.Billing != WaitBillingExact && cfg.Billing != WaitBillingPerStartedMinute) {
+		return 0, valerrors.InvalidFormatWithValue("wait_config", "non-negative prices and a known billing mode", cfg)
+	}
+	if err := ValidateWaitTime(minutes); err != nil {
+		return 0, err
+	}
+
+	billed := minutes - cfg.FreeMinutes
+	if billed <= 0 {
+		return 0, nil
+	}
+	if cfg.Billing == WaitBillingPerStartedMinute {
+		// The tolerance keeps a wait of 7.0000000001 minutes from starting an
+		// extra minute due to floating-point error.
+		billed = math.Ceil(billed - fareBandTolerance)
+	}
+	return int64(math.Floor(billed*float64(cfg.PerMinuteCentavos) + 0.5 + fareBandTolerance)), nil
+}
+
+// ValidateWaitFee checks the wait fee on a receipt against CalculateWaitFee
+// for the wait time and config. A difference of up to 1 centavo is accepted
+// for rounding. Returns the CalculateWaitFee error if the inputs are invalid,
+// and OUT_OF_RANGE on "wait_fee" with the expected amount in the
+// "expected_fee" param on a mismatch.
+func ValidateWaitFee(feeCentavos int64, minutes float64, cfg WaitConfig) error {
+	expected, err := CalculateWaitFee(minutes, cfg)
+	if err != nil {
+		return err
+	}
+	if feeCentavos < expected-1 || feeCentavos > expected+1 {
+		ve := valerrors.OutOfRangeWithValue("wait_fee", expected-1, expected+1, feeCentavos)
+		ve.Message = fmt.Sprintf("wait_fee does not match the expected fee of %d for %.1f minutes", expected, minutes)
+		return ve.WithParam("expected_fee", expected)
+	}
+	return nil
+}
+
+// IsValidWaitTime returns true if the wait time is valid.
+func IsValidWaitTime(minutes float64) bool {
+	return ValidateWaitTime(minutes) == nil
+}
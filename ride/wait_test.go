@@ -0,0 +1,138 @@
+package ride
+
+import (
+	"math"
+	"testing"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateWaitTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		minutes float64
+		wantErr bool
+	}{
+		{"no wait", 0, false},
+		{"typical", 7.5, false},
+		{"at cap", MaxWaitMinutes, false},
+		{"above cap", MaxWaitMinutes + 0.1, true},
+		{"negative", -1, true},
+		{"NaN", math.NaN(), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWaitTime(tt.minutes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateWaitTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if IsValidWaitTime(tt.minutes) == tt.wantErr {
+				t.Errorf("IsValidWaitTime() = %v", tt.wantErr)
+			}
+			if tt.wantErr {
+				if ve := err.(valerrors.ValidationError); ve.Field != "wait_time" || ve.Code != valerrors.CodeOutOfRange {
+					t.Errorf("error = %s/%s, want wait_time/OUT_OF_RANGE", ve.Field, ve.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateWaitFee(t *testing.T) {
+	exact := WaitConfig{FreeMinutes: FreeWaitMinutes, PerMinuteCentavos: PerWaitMinuteCentavos, Billing: WaitBillingExact}
+	started := DefaultWaitConfig()
+
+	tests := []struct {
+		name    string
+		minutes float64
+		cfg     WaitConfig
+		want    int64
+	}{
+		{"within free time", 3, started, 0},
+		{"at free threshold exact", 5, exact, 0},
+		{"at free threshold per started minute", 5, started, 0},
+		{"just past threshold per started minute", 5.01, started, 500},
+		// 2.5 billed minutes.
+		{"fractional wait exact", 7.5, exact, 1250},
+		{"fractional wait per started minute", 7.5, started, 1500},
+		{"whole minutes per started minute", 7, started, 1000},
+		// 0.3333 * 500 = 166.65
+		{"exact rounds to nearest centavo", 5.3333, exact, 167},
+		// 0.001 * 500 = 0.5
+		{"exact rounds half up", 5.001, exact, 1},
+		{"no free minutes", 2, WaitConfig{PerMinuteCentavos: 300}, 600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CalculateWaitFee(tt.minutes, tt.cfg)
+			if err != nil {
+				t.Fatalf("CalculateWaitFee() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CalculateWaitFee(%v) = %d, want %d", tt.minutes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateWaitFee_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		minutes  float64
+		cfg      WaitConfig
+		errField string
+	}{
+		{"negative wait", -1, DefaultWaitConfig(), "wait_time"},
+		{"wait above cap", 121, DefaultWaitConfig(), "wait_time"},
+		{"negative rate", 10, WaitConfig{PerMinuteCentavos: -1}, "wait_config"},
+		{"negative free minutes", 10, WaitConfig{FreeMinutes: -1}, "wait_config"},
+		{"unknown billing", 10, WaitConfig{Billing: WaitBilling(7)}, "wait_config"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CalculateWaitFee(tt.minutes, tt.cfg)
+			if ve, ok := err.(valerrors.ValidationError); !ok || ve.Field != tt.errField {
+				t.Errorf("CalculateWaitFee() error = %v, want error on %s", err, tt.errField)
+			}
+		})
+	}
+}
+
+func TestValidateWaitFee(t *testing.T) {
+	exact := WaitConfig{FreeMinutes: FreeWaitMinutes, PerMinuteCentavos: PerWaitMinuteCentavos, Billing: WaitBillingExact}
+
+	tests := []struct {
+		name     string
+		fee      int64
+		minutes  float64
+		cfg      WaitConfig
+		errField string
+	}{
+		{"matches", 1500, 7.5, DefaultWaitConfig(), ""},
+		{"within 1 centavo", 166, 5.3333, exact, ""},
+		{"free wait", 0, 4, DefaultWaitConfig(), ""},
+		{"billed exact fee under per started minute", 1250, 7.5, DefaultWaitConfig(), "wait_fee"},
+		{"fee for free wait", 500, 4, DefaultWaitConfig(), "wait_fee"},
+		{"off by two", 1252, 7.5, exact, "wait_fee"},
+		{"invalid wait", 0, -1, DefaultWaitConfig(), "wait_time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWaitFee(tt.fee, tt.minutes, tt.cfg)
+			if tt.errField == "" {
+				if err != nil {
+					t.Errorf("ValidateWaitFee() error = %v", err)
+				}
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Field != tt.errField {
+				t.Fatalf("ValidateWaitFee() error = %v, want error on %s", err, tt.errField)
+			}
+			if tt.errField == "wait_fee" && ve.Param("expected_fee") == nil {
+				t.Error("missing expected_fee param")
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package ride
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Dorico-Dynamics/txova-go-types/geo"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+	geoval "github.com/Dorico-Dynamics/txova-go-validation/geo"
+)
+
+// MaxWaypointStops is the most intermediate stops a ride may have.
+const MaxWaypointStops = 3
+
+// waypoint is a point on a ride's route with the field name used in errors.
+type waypoint struct {
+	field string
+	loc   geo.Location
+}
+
+// ValidateWaypoints validates a ride's route from pickup through the
+// intermediate stops to dropoff and reports every problem:
+//   - more than MaxWaypointStops stops is OUT_OF_RANGE on "stops", and
+//     nothing else is checked;
+//   - a zero pickup, dropoff, or stop is REQUIRED on "pickup", "dropoff", or
+//     an indexed field such as "stops[1]";
+//   - a stop outside every active service area is OUTSIDE_SERVICE_AREA on its
+//     indexed field;
+//   - a stop at the same coordinates as the pickup or dropoff is DUPLICATE on
+//     its indexed field;
+//   - consecutive points closer than MinPickupDropoffSeparationKM are
+//     OUT_OF_RANGE on the combined field, e.g. "stops[0]_stops[1]" or
+//     "pickup_stops[0]", with the distance as the value;
+//   - a route longer than MaxDistanceKM is OUT_OF_RANGE on "route".
+//
+// Distances are only checked when every point is set. Returns nil if the
+// route is valid.
+func ValidateWaypoints(pickup, dropoff geo.Location, stops []geo.Location) valerrors.ValidationErrors {
+	var errs valerrors.ValidationErrors
+	if len(stops) > MaxWaypointStops {
+		errs.Add(valerrors.OutOfRangeWithValue("stops", 0, MaxWaypointStops, len(stops)))
+		return errs
+	}
+
+	route := make([]waypoint, 0, len(stops)+2)
+	route = append(route, waypoint{"pickup", pickup})
+	for i, stop := range stops {
+		route = append(route, waypoint{"stops[" + strconv.Itoa(i) + "]", stop})
+	}
+	route = append(route, waypoint{"dropoff", dropoff})
+
+	missing := false
+	for i, wp := range route {
+		if geoval.IsZeroLocation(wp.loc) {
+			errs.Add(valerrors.Required(wp.field))
+			missing = true
+			continue
+		}
+		if i == 0 || i == len(route)-1 {
+			continue
+		}
+		if ve, ok := geoval.ValidateAnyServiceArea(wp.loc.Latitude(), wp.loc.Longitude()).(valerrors.ValidationError); ok {
+			ve.Field = wp.field
+			errs.Add(ve)
+		}
+		if sameLocation(wp.loc, pickup) || sameLocation(wp.loc, dropoff) {
+			errs.Add(valerrors.New(wp.field, valerrors.CodeDuplicate,
+				wp.field+" must differ from the pickup and dropoff"))
+		}
+	}
+	if missing {
+		return errs
+	}
+
+	var total float64
+	for i := 1; i < len(route); i++ {
+		prev, next := route[i-1], route[i]
+		distance := geoval.DistanceBetween(prev.loc, next.loc)
+		total += distance
+		// A stop at the pickup or dropoff is already reported as DUPLICATE.
+		duplicate := (i == 1 || i == len(route)-1) && sameLocation(prev.loc, next.loc) && len(stops) > 0
+		if distance < MinPickupDropoffSeparationKM && !duplicate {
+			errs.Add(valerrors.NewWithValue(prev.field+"_"+next.field, valerrors.CodeOutOfRange,
+				fmt.Sprintf("%s and %s must be at least %g km apart", prev.field, next.field, MinPickupDropoffSeparationKM),
+				distance).WithParam("min_km", MinPickupDropoffSeparationKM))
+		}
+	}
+	if total > MaxDistanceKM {
+		errs.Add(valerrors.NewWithValue("route", valerrors.CodeOutOfRange,
+			fmt.Sprintf("route must be at most %g km", MaxDistanceKM), total).
+			WithParam("max_km", MaxDistanceKM))
+	}
+	return errs
+}
+
+// sameLocation reports whether two locations have the same coordinates.
+func sameLocation(a, b geo.Location) bool {
+	return a.Latitude() == b.Latitude() && a.Longitude() == b.Longitude()
+}
@@ -0,0 +1,78 @@
+package ride
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dorico-Dynamics/txova-go-types/geo"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateWaypoints(t *testing.T) {
+	pickup := geo.MustNewLocation(-25.9692, 32.5732)
+	dropoff := geo.MustNewLocation(-25.9000, 32.6000)
+	stopA := geo.MustNewLocation(-25.9500, 32.5800)
+	stopB := geo.MustNewLocation(-25.9300, 32.5700)
+	// About 20 meters north of stopA.
+	nearA := geo.MustNewLocation(-25.95018, 32.5800)
+	johannesburg := geo.MustNewLocation(-26.2041, 28.0473)
+	beira := geo.MustNewLocation(-19.8436, 34.8389)
+
+	type fieldCode struct{ field, code string }
+	tests := []struct {
+		name    string
+		pickup  geo.Location
+		dropoff geo.Location
+		stops   []geo.Location
+		want    []fieldCode
+	}{
+		{"valid two stops", pickup, dropoff, []geo.Location{stopA, stopB}, nil},
+		{"valid without stops", pickup, dropoff, nil, nil},
+		{"too many stops", pickup, dropoff, []geo.Location{stopA, stopB, stopA, stopB},
+			[]fieldCode{{"stops", valerrors.CodeOutOfRange}}},
+		{"stop outside Mozambique", pickup, dropoff, []geo.Location{stopA, johannesburg},
+			[]fieldCode{{"stops[1]", valerrors.CodeOutsideServiceArea}, {"route", valerrors.CodeOutOfRange}}},
+		{"adjacent stops 20 meters apart", pickup, dropoff, []geo.Location{stopA, nearA},
+			[]fieldCode{{"stops[0]_stops[1]", valerrors.CodeOutOfRange}}},
+		{"stop too close to pickup", pickup, dropoff, []geo.Location{geo.MustNewLocation(-25.9695, 32.5732)},
+			[]fieldCode{{"pickup_stops[0]", valerrors.CodeOutOfRange}}},
+		{"stop duplicates pickup", pickup, dropoff, []geo.Location{pickup, stopA},
+			[]fieldCode{{"stops[0]", valerrors.CodeDuplicate}}},
+		{"stop duplicates dropoff", pickup, dropoff, []geo.Location{stopA, stopB, dropoff},
+			[]fieldCode{{"stops[2]", valerrors.CodeDuplicate}}},
+		{"missing stop", pickup, dropoff, []geo.Location{stopA, {}},
+			[]fieldCode{{"stops[1]", valerrors.CodeRequired}}},
+		{"missing pickup and dropoff", geo.Location{}, geo.Location{}, []geo.Location{stopA},
+			[]fieldCode{{"pickup", valerrors.CodeRequired}, {"dropoff", valerrors.CodeRequired}}},
+		{"route too long", pickup, dropoff, []geo.Location{beira},
+			[]fieldCode{{"route", valerrors.CodeOutOfRange}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateWaypoints(tt.pickup, tt.dropoff, tt.stops)
+			var got []fieldCode
+			for _, e := range errs {
+				got = append(got, fieldCode{e.Field, e.Code})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ValidateWaypoints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWaypoints_SeparationValue(t *testing.T) {
+	a := geo.MustNewLocation(-25.9500, 32.5800)
+	b := geo.MustNewLocation(-25.95018, 32.5800)
+	errs := ValidateWaypoints(geo.MustNewLocation(-25.9692, 32.5732), geo.MustNewLocation(-25.9000, 32.6000),
+		[]geo.Location{a, b})
+	if len(errs) != 1 {
+		t.Fatalf("ValidateWaypoints() = %v, want one error", errs)
+	}
+	distance, _ := errs[0].Value.(float64)
+	if distance < 0.015 || distance > 0.025 || errs[0].Param("min_km") != MinPickupDropoffSeparationKM {
+		t.Errorf("error = %+v, want about 0.02 km with min_km", errs[0])
+	}
+}
@@ -0,0 +1,107 @@
+package ride
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+	geoval "github.com/Dorico-Dynamics/txova-go-validation/geo"
+)
+
+// minutesPerDay is the number of minutes in a day.
+const minutesPerDay = 24 * 60
+
+// TimeWindow is a daily window of local time, such as the night-fare hours.
+// The start is inclusive and the end exclusive, with minute precision. A
+// window whose end is earlier than its start wraps past midnight, so
+// 22:00-05:00 covers 22:00:00 through 04:59:59.
+type TimeWindow struct {
+	// StartMinute is the first minute of the window, counted from midnight.
+	StartMinute int
+	// EndMinute is the first minute after the window, counted from midnight.
+	EndMinute int
+}
+
+// NightWindow is the night-fare window, 22:00 to 05:00 local time.
+var NightWindow = TimeWindow{StartMinute: 22 * 60, EndMinute: 5 * 60}
+
+// String returns the window in "HH:MM-HH:MM" form.
+func (w TimeWindow) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", w.StartMinute/60, w.StartMinute%60, w.EndMinute/60, w.EndMinute%60)
+}
+
+// ParseTimeWindow parses a window from "HH:MM" start and end times on a
+// 24-hour clock. See ValidateTimeWindow for the errors returned.
+func ParseTimeWindow(start, end string) (TimeWindow, error) {
+	startMinute, ok := parseClock(start)
+	if !ok {
+		return TimeWindow{}, valerrors.InvalidFormatWithValue("start", "HH:MM", start)
+	}
+	endMinute, ok := parseClock(end)
+	if !ok {
+		return TimeWindow{}, valerrors.InvalidFormatWithValue("end", "HH:MM", end)
+	}
+	if startMinute == endMinute {
+		return TimeWindow{}, valerrors.NewWithValue("end", valerrors.CodeInvalidFormat, "end must differ from start", end)
+	}
+	return TimeWindow{StartMinute: startMinute, EndMinute: endMinute}, nil
+}
+
+// ValidateTimeWindow validates a daily window given as "HH:MM" start and end
+// times on a 24-hour clock, e.g. "22:00" and "05:00". Windows that wrap past
+// midnight are allowed. Returns INVALID_FORMAT on "start" or "end" for a time
+// that is not HH:MM with hours 00-23 and minutes 00-59, and on "end" when it
+// equals start.
+func ValidateTimeWindow(start, end string) error {
+	_, err := ParseTimeWindow(start, end)
+	return err
+}
+
+// parseClock parses "HH:MM" into minutes after midnight.
+func parseClock(s string) (int, bool) {
+	if len(s) != 5 || s[2] != ':' {
+		return 0, false
+	}
+	for _, i := range []int{0, 1, 3, 4} {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, false
+		}
+	}
+	hours := int(s[0]-'0')*10 + int(s[1]-'0')
+	minutes := int(s[3]-'0')*10 + int(s[4]-'0')
+	if hours > 23 || minutes > 59 {
+		return 0, false
+	}
+	return hours*60 + minutes, true
+}
+
+// InWindow reports whether t's wall-clock time, in t's own location, falls
+// within the window. The start is inclusive and the end exclusive.
+func InWindow(t time.Time, window TimeWindow) bool {
+	minute := t.Hour()*60 + t.Minute()
+	start, end := window.StartMinute%minutesPerDay, window.EndMinute%minutesPerDay
+	if start <= end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// defaultLocation returns the geo.DefaultTimezone location, or a fixed
+// UTC+2 zone if the time zone database is unavailable. Mozambique does not
+// observe daylight saving time.
+var defaultLocation = sync.OnceValue(func() *time.Location {
+	if loc, err := time.LoadLocation(geoval.DefaultTimezone); err == nil {
+		return loc
+	}
+	return time.FixedZone("CAT", 2*60*60)
+})
+
+// IsNightTime reports whether t falls within NightWindow in loc. If loc is
+// nil, Mozambique time (geo.DefaultTimezone) is used.
+func IsNightTime(t time.Time, loc *time.Location) bool {
+	if loc == nil {
+		loc = defaultLocation()
+	}
+	return InWindow(t.In(loc), NightWindow)
+}
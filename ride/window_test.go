@@ -0,0 +1,123 @@
+package ride
+
+import (
+	"testing"
+	"time"
+
+	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+)
+
+func TestValidateTimeWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    string
+		end      string
+		wantErr  bool
+		errField string
+	}{
+		{"night window wraps midnight", "22:00", "05:00", false, ""},
+		{"morning peak", "07:00", "09:30", false, ""},
+		{"midnight start", "00:00", "23:59", false, ""},
+		{"start equals end", "22:00", "22:00", true, "end"},
+		{"hour out of range", "24:00", "05:00", true, "start"},
+		{"minute out of range", "22:00", "05:60", true, "end"},
+		{"single-digit hour", "7:00", "09:00", true, "start"},
+		{"missing colon", "2200", "0500", true, "start"},
+		{"non-digit", "22:0a", "05:00", true, "start"},
+		{"empty end", "22:00", "", true, "end"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTimeWindow(tt.start, tt.end)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateTimeWindow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				ve := err.(valerrors.ValidationError)
+				if ve.Field != tt.errField || ve.Code != valerrors.CodeInvalidFormat {
+					t.Errorf("error = %s/%s, want %s/INVALID_FORMAT", ve.Field, ve.Code, tt.errField)
+				}
+			}
+		})
+	}
+}
+
+func TestParseTimeWindow(t *testing.T) {
+	w, err := ParseTimeWindow("22:00", "05:00")
+	if err != nil {
+		t.Fatalf("ParseTimeWindow() error = %v", err)
+	}
+	if w != NightWindow {
+		t.Errorf("ParseTimeWindow() = %+v, want NightWindow", w)
+	}
+	if got := w.String(); got != "22:00-05:00" {
+		t.Errorf("String() = %q, want 22:00-05:00", got)
+	}
+}
+
+func TestInWindow(t *testing.T) {
+	peak := TimeWindow{StartMinute: 7 * 60, EndMinute: 9*60 + 30}
+	at := func(hour, minute, second int) time.Time {
+		return time.Date(2025, time.June, 10, hour, minute, second, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		t      time.Time
+		window TimeWindow
+		want   bool
+	}{
+		{"peak start inclusive", at(7, 0, 0), peak, true},
+		{"peak inside", at(8, 15, 0), peak, true},
+		{"peak last second", at(9, 29, 59), peak, true},
+		{"peak end exclusive", at(9, 30, 0), peak, false},
+		{"before peak", at(6, 59, 59), peak, false},
+		{"night before midnight", at(23, 30, 0), NightWindow, true},
+		{"night after midnight", at(0, 30, 0), NightWindow, true},
+		{"night end exclusive", at(5, 0, 0), NightWindow, false},
+		{"midday outside night", at(12, 0, 0), NightWindow, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InWindow(tt.t, tt.window); got != tt.want {
+				t.Errorf("InWindow(%s, %s) = %v, want %v", tt.t.Format("15:04:05"), tt.window, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNightTime(t *testing.T) {
+	maputo, err := time.LoadLocation("Africa/Maputo")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"21:59:59", time.Date(2025, time.December, 31, 21, 59, 59, 0, maputo), false},
+		{"22:00:00 start inclusive", time.Date(2025, time.December, 31, 22, 0, 0, 0, maputo), true},
+		{"00:00:00 new year", time.Date(2026, time.January, 1, 0, 0, 0, 0, maputo), true},
+		{"04:59:59 after midnight", time.Date(2026, time.January, 1, 4, 59, 59, 0, maputo), true},
+		{"05:00:00 end exclusive", time.Date(2026, time.January, 1, 5, 0, 0, 0, maputo), false},
+		// 20:30 UTC is 22:30 in Maputo.
+		{"UTC time converted", time.Date(2025, time.December, 31, 20, 30, 0, 0, time.UTC), true},
+		// 03:00 UTC is 05:00 in Maputo.
+		{"UTC time at end", time.Date(2026, time.January, 1, 3, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNightTime(tt.t, maputo); got != tt.want {
+				t.Errorf("IsNightTime(%s) = %v, want %v", tt.t, got, tt.want)
+			}
+			if got := IsNightTime(tt.t, nil); got != tt.want {
+				t.Errorf("IsNightTime(%s, nil) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package sanitize
+
+import "unicode"
+
+// Fullwidth ASCII forms occupy U+FF01 through U+FF5E, offset from their ASCII
+// counterparts by a fixed amount.
+const (
+	fullwidthFirst  = '\uFF01'
+	fullwidthLast   = '\uFF5E'
+	fullwidthOffset = 0xFEE0
+)
+
+// confusables maps Cyrillic and Greek letters to the Latin letters they are
+// visually indistinguishable from. It is a curated subset of the Unicode
+// confusables data covering the homoglyphs seen in filter evasion.
+var confusables = map[rune]rune{
+	// Cyrillic lowercase.
+	'\u0430': 'a', // Cyrillic Small A
+	'\u0435': 'e', // Cyrillic Small Ie
+	'\u0451': 'e', // Cyrillic Small Io
+	'\u043E': 'o', // Cyrillic Small O
+	'\u0440': 'p', // Cyrillic Small Er
+	'\u0441': 'c', // Cyrillic Small Es
+	'\u0443': 'y', // Cyrillic Small U
+	'\u0445': 'x', // Cyrillic Small Ha
+	'\u0456': 'i', // Cyrillic Small Byelorussian-Ukrainian I
+	'\u0458': 'j', // Cyrillic Small Je
+	'\u0455': 's', // Cyrillic Small Dze
+	'\u0501': 'd', // Cyrillic Small Komi De
+	'\u051B': 'q', // Cyrillic Small Qa
+	'\u051D': 'w', // Cyrillic Small We
+	'\u04BB': 'h', // Cyrillic Small Shha
+	// Cyrillic uppercase.
+	'\u0410': 'A', // Cyrillic Capital A
+	'\u0412': 'B', // Cyrillic Capital Ve
+	'\u0415': 'E', // Cyrillic Capital Ie
+	'\u041A': 'K', // Cyrillic Capital Ka
+	'\u041C': 'M', // Cyrillic Capital Em
+	'\u041D': 'H', // Cyrillic Capital En
+	'\u041E': 'O', // Cyrillic Capital O
+	'\u0420': 'P', // Cyrillic Capital Er
+	'\u0421': 'C', // Cyrillic Capital Es
+	'\u0422': 'T', // Cyrillic Capital Te
+	'\u0425': 'X', // Cyrillic Capital Ha
+	'\u0406': 'I', // Cyrillic Capital Byelorussian-Ukrainian I
+	'\u0408': 'J', // Cyrillic Capital Je
+	'\u0405': 'S', // Cyrillic Capital Dze
+	// Greek lowercase.
+	'\u03B1': 'a', // Greek Small Alpha
+	'\u03BF': 'o', // Greek Small Omicron
+	'\u03C1': 'p', // Greek Small Rho
+	'\u03B9': 'i', // Greek Small Iota
+	'\u03BA': 'k', // Greek Small Kappa
+	'\u03BD': 'v', // Greek Small Nu
+	'\u03C5': 'u', // Greek Small Upsilon
+	'\u03C7': 'x', // Greek Small Chi
+	// Greek uppercase.
+	'\u0391': 'A', // Greek Capital Alpha
+	'\u0392': 'B', // Greek Capital Beta
+	'\u0395': 'E', // Greek Capital Epsilon
+	'\u0396': 'Z', // Greek Capital Zeta
+	'\u0397': 'H', // Greek Capital Eta
+	'\u0399': 'I', // Greek Capital Iota
+	'\u039A': 'K', // Greek Capital Kappa
+	'\u039C': 'M', // Greek Capital Mu
+	'\u039D': 'N', // Greek Capital Nu
+	'\u039F': 'O', // Greek Capital Omicron
+	'\u03A1': 'P', // Greek Capital Rho
+	'\u03A4': 'T', // Greek Capital Tau
+	'\u03A5': 'Y', // Greek Capital Upsilon
+	'\u03A7': 'X', // Greek Capital Chi
+}
+
+// FoldConfusables replaces lookalike characters with the Latin characters
+// they imitate, so "merda" written with a Cyrillic "\u0435" in place of the
+// "e" reads as plain "merda" again.
+//
+// Fullwidth forms (U+FF01 to U+FF5E) are always folded to ASCII. Cyrillic
+// and Greek homoglyphs are folded only within words that are mostly Latin:
+// the word must contain no other non-Latin letters and at least as many
+// Latin letters as homoglyphs. Words written in Cyrillic or Greek are left
+// untouched, so genuine Russian or Greek text is not mangled.
+//
+// Folding is rune-for-rune: the result has the same number of runes as s.
+func FoldConfusables(s string) string {
+	runes := []rune(s)
+	changed := false
+	for i, r := range runes {
+		if r >= fullwidthFirst && r <= fullwidthLast {
+			runes[i] = r - fullwidthOffset
+			changed = true
+		}
+	}
+
+	for start := 0; start < len(runes); {
+		if !isConfusableWordRune(runes[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(runes) && isConfusableWordRune(runes[end]) {
+			end++
+		}
+		if foldWord(runes[start:end]) {
+			changed = true
+		}
+		start = end
+	}
+
+	if !changed {
+		return s
+	}
+	return string(runes)
+}
+
+// FoldConfusables adds confusable folding to the pipeline.
+func (s *Sanitizer) FoldConfusables() *Sanitizer {
+	s.fns = append(s.fns, FoldConfusables)
+	return s
+}
+
+// isConfusableWordRune reports whether r belongs to a word for confusable
+// folding.
+func isConfusableWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)
+}
+
+// foldWord folds the homoglyphs in word in place if the word is mostly
+// Latin, and reports whether it changed anything.
+func foldWord(word []rune) bool {
+	var latin, homoglyphs int
+	for _, r := range word {
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case confusables[r] != 0:
+			homoglyphs++
+		case unicode.IsLetter(r):
+			return false
+		}
+	}
+	if homoglyphs == 0 || latin < homoglyphs {
+		return false
+	}
+
+	for i, r := range word {
+		if folded, ok := confusables[r]; ok {
+			word[i] = folded
+		}
+	}
+	return true
+}
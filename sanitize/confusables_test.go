@@ -0,0 +1,46 @@
+package sanitize
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFoldConfusables(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"cyrillic e", "mеrda", "merda"},
+		{"greek omicron", "pοrra", "porra"},
+		{"uppercase homoglyphs", "СRAP", "CRAP"},
+		{"fullwidth letters", "ｍｅｒｄａ", "merda"},
+		{"fullwidth punctuation", "ok！", "ok!"},
+		{"only the mixed word", "bom mеrda", "bom merda"},
+		{"russian word untouched", "сор", "сор"},
+		{"russian review untouched", "Водитель хороший", "Водитель хороший"},
+		{"more homoglyphs than latin", "расk", "расk"},
+		{"other cyrillic letters", "mеrдa", "mеrдa"},
+		{"plain ascii", "Great driver", "Great driver"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FoldConfusables(tt.input)
+			if got != tt.want {
+				t.Errorf("FoldConfusables(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if utf8.RuneCountInString(got) != utf8.RuneCountInString(tt.input) {
+				t.Errorf("FoldConfusables(%q) changed the rune count", tt.input)
+			}
+		})
+	}
+}
+
+func TestSanitizer_FoldConfusables(t *testing.T) {
+	got := NewSanitizer().TrimWhitespace().FoldConfusables().ToLowercase().Apply("  МеRDA ")
+	if got != "merda" {
+		t.Errorf("Apply() = %q, want %q", got, "merda")
+	}
+}
@@ -3,9 +3,12 @@
 package sanitize
 
 import (
+	"html"
 	"regexp"
 	"strings"
 	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // htmlTagPattern matches HTML tags for stripping.
@@ -26,12 +29,38 @@ func NormalizeSpaces(s string) string {
 	return strings.TrimSpace(result)
 }
 
+// typographicReplacer maps smart quotes and dashes to their ASCII equivalents.
+var typographicReplacer = strings.NewReplacer(
+	"\u201C", `"`, // left double quotation mark
+	"\u201D", `"`, // right double quotation mark
+	"\u2018", "'", // left single quotation mark
+	"\u2019", "'", // right single quotation mark
+	"\u2013", "-", // en dash
+	"\u2014", "-", // em dash
+)
+
+// NormalizePortugueseText normalizes Portuguese text for storage and comparison.
+// It applies Unicode NFC normalization (so "ã" is always a single code point),
+// converts smart quotes to straight quotes and en/em dashes to hyphens, and
+// collapses whitespace. All diacritics (ã, â, ç, ê, ô, ú, ...) are preserved.
+func NormalizePortugueseText(s string) string {
+	result := norm.NFC.String(s)
+	result = typographicReplacer.Replace(result)
+	return NormalizeSpaces(result)
+}
+
 // StripHTML removes all HTML tags from a string.
 // Does not decode HTML entities.
 func StripHTML(s string) string {
 	return htmlTagPattern.ReplaceAllString(s, "")
 }
 
+// DecodeHTMLEntities decodes HTML entities such as &amp; and &#39; to the
+// characters they represent.
+func DecodeHTMLEntities(s string) string {
+	return html.UnescapeString(s)
+}
+
 // EscapeHTML escapes HTML special characters to their entity equivalents.
 // Escapes: & < > " '.
 func EscapeHTML(s string) string {
@@ -122,6 +151,28 @@ func ToLowercase(s string) string {
 	return strings.ToLower(s)
 }
 
+// LowercaseURLSchemeHost lowercases the scheme and host of a URL, which are
+// case-insensitive, and leaves user info, path, query, and fragment unchanged.
+// Strings without a "://" separator are returned unchanged.
+func LowercaseURLSchemeHost(s string) string {
+	schemeEnd := strings.Index(s, "://")
+	if schemeEnd < 0 {
+		return s
+	}
+	authorityStart := schemeEnd + len("://")
+	authorityEnd := len(s)
+	if i := strings.IndexAny(s[authorityStart:], "/?#"); i >= 0 {
+		authorityEnd = authorityStart + i
+	}
+	hostStart := authorityStart
+	if i := strings.LastIndex(s[authorityStart:authorityEnd], "@"); i >= 0 {
+		hostStart = authorityStart + i + 1
+	}
+
+	return strings.ToLower(s[:authorityStart]) + s[authorityStart:hostStart] +
+		strings.ToLower(s[hostStart:authorityEnd]) + s[authorityEnd:]
+}
+
 // RemoveDigits removes all digit characters from a string.
 func RemoveDigits(s string) string {
 	var result strings.Builder
@@ -161,6 +212,48 @@ func KeepAlphanumeric(s string) string {
 	return result.String()
 }
 
+// TruncateWords truncates a string after at most maxWords whitespace-separated words.
+// Text up to the end of the last kept word is returned unchanged, so words are never cut.
+// Returns an empty string if maxWords is not positive.
+func TruncateWords(s string, maxWords int) string {
+	result, _ := truncateWords(s, maxWords)
+	return result
+}
+
+// TruncateWordsWithEllipsis truncates like TruncateWords and appends "…"
+// when any words were removed.
+func TruncateWordsWithEllipsis(s string, maxWords int) string {
+	result, truncated := truncateWords(s, maxWords)
+	if truncated {
+		return result + "…"
+	}
+	return result
+}
+
+// truncateWords returns s cut after maxWords words and whether any words were removed.
+func truncateWords(s string, maxWords int) (string, bool) {
+	if maxWords <= 0 {
+		return "", strings.TrimSpace(s) != ""
+	}
+
+	words := 0
+	inWord := false
+	for i, r := range s {
+		if unicode.IsSpace(r) {
+			if inWord && words == maxWords {
+				return s[:i], strings.TrimSpace(s[i:]) != ""
+			}
+			inWord = false
+			continue
+		}
+		if !inWord {
+			inWord = true
+			words++
+		}
+	}
+	return s, false
+}
+
 // Func is a function type for sanitization operations.
 type Func func(string) string
 
@@ -216,6 +309,12 @@ func (s *Sanitizer) NormalizeName() *Sanitizer {
 	return s
 }
 
+// NormalizePortuguese adds Portuguese text normalization to the sanitizer chain.
+func (s *Sanitizer) NormalizePortuguese() *Sanitizer {
+	s.fns = append(s.fns, NormalizePortugueseText)
+	return s
+}
+
 // NormalizeEmail adds email normalization to the pipeline.
 func (s *Sanitizer) NormalizeEmail() *Sanitizer {
 	s.fns = append(s.fns, NormalizeEmail)
@@ -228,6 +327,18 @@ func (s *Sanitizer) ToUppercase() *Sanitizer {
 	return s
 }
 
+// DecodeHTMLEntities adds HTML entity decoding to the pipeline.
+func (s *Sanitizer) DecodeHTMLEntities() *Sanitizer {
+	s.fns = append(s.fns, DecodeHTMLEntities)
+	return s
+}
+
+// LowercaseURLSchemeHost adds URL scheme and host lowercasing to the pipeline.
+func (s *Sanitizer) LowercaseURLSchemeHost() *Sanitizer {
+	s.fns = append(s.fns, LowercaseURLSchemeHost)
+	return s
+}
+
 // ToLowercase adds lowercase conversion to the pipeline.
 func (s *Sanitizer) ToLowercase() *Sanitizer {
 	s.fns = append(s.fns, ToLowercase)
@@ -258,12 +369,40 @@ func (s *Sanitizer) KeepAlphanumeric() *Sanitizer {
 	return s
 }
 
+// LimitWords adds word-boundary truncation to the pipeline.
+func (s *Sanitizer) LimitWords(maxWords int) *Sanitizer {
+	s.fns = append(s.fns, func(input string) string {
+		return TruncateWords(input, maxWords)
+	})
+	return s
+}
+
+// LimitWordsWithEllipsis adds word-boundary truncation with a trailing ellipsis to the pipeline.
+func (s *Sanitizer) LimitWordsWithEllipsis(maxWords int) *Sanitizer {
+	s.fns = append(s.fns, func(input string) string {
+		return TruncateWordsWithEllipsis(input, maxWords)
+	})
+	return s
+}
+
 // Custom adds a custom sanitization function to the pipeline.
+// It is equivalent to Append.
 func (s *Sanitizer) Custom(fn Func) *Sanitizer {
+	return s.Append(fn)
+}
+
+// Append adds fn as the last step of the pipeline.
+func (s *Sanitizer) Append(fn Func) *Sanitizer {
 	s.fns = append(s.fns, fn)
 	return s
 }
 
+// Prepend inserts fn as the first step of the pipeline, before existing steps.
+func (s *Sanitizer) Prepend(fn Func) *Sanitizer {
+	s.fns = append([]Func{fn}, s.fns...)
+	return s
+}
+
 // Apply applies all sanitization functions to the input.
 func (s *Sanitizer) Apply(input string) string {
 	return Chain(input, s.fns...)
@@ -297,6 +436,16 @@ func EmailSanitizer() *Sanitizer {
 		NormalizeEmail()
 }
 
+// URLSanitizer returns a sanitizer for URL fields.
+// Trims whitespace, decodes HTML entities, and lowercases the scheme and host
+// while preserving case in the path and query.
+func URLSanitizer() *Sanitizer {
+	return NewSanitizer().
+		TrimWhitespace().
+		DecodeHTMLEntities().
+		LowercaseURLSchemeHost()
+}
+
 // PhoneSanitizer returns a sanitizer for phone numbers.
 // Keeps only digits.
 func PhoneSanitizer() *Sanitizer {
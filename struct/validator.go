@@ -3,7 +3,10 @@ package structval
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -22,27 +25,32 @@ var (
 	validate *validator.Validate
 )
 
+// structCheck is one cross-field check run for a registered struct type.
+type structCheck func(sl validator.StructLevel)
+
+var (
+	// structChecksMu guards structChecks.
+	structChecksMu sync.RWMutex
+	// structChecks holds the cross-field checks of each struct type. A single
+	// struct-level function per type runs them all, since the validator keeps
+	// only one per type.
+	structChecks = make(map[reflect.Type][]structCheck)
+)
+
 // initValidator initializes the singleton validator with custom configuration.
 func initValidator() {
 	validate = validator.New(validator.WithRequiredStructEnabled())
 
 	// Use JSON tag names for field names in error messages
-	validate.RegisterTagNameFunc(func(fld reflect.StructField) string {
-		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
-		if name == "-" {
-			return fld.Name
-		}
-		if name == "" {
-			return fld.Name
-		}
-		return name
-	})
+	validate.RegisterTagNameFunc(jsonFieldName)
 
 	// Register custom validation tags.
 	// These registrations cannot fail as they are valid tag names with valid functions.
 	//nolint:errcheck // Registration errors are not possible with valid inputs
 	validate.RegisterValidation("mz_phone", validateMzPhone)
 	//nolint:errcheck // Registration errors are not possible with valid inputs
+	validate.RegisterValidation("mz_phone_any", validateMzPhoneAny)
+	//nolint:errcheck // Registration errors are not possible with valid inputs
 	validate.RegisterValidation("mz_plate", validateMzPlate)
 	//nolint:errcheck // Registration errors are not possible with valid inputs
 	validate.RegisterValidation("mz_location", validateMzLocation)
@@ -53,7 +61,31 @@ func initValidator() {
 	//nolint:errcheck // Registration errors are not possible with valid inputs
 	validate.RegisterValidation("txova_rating", validateTxovaRating)
 	//nolint:errcheck // Registration errors are not possible with valid inputs
+	validate.RegisterValidation("txova_rating_float", validateTxovaRatingFloat)
+	//nolint:errcheck // Registration errors are not possible with valid inputs
 	validate.RegisterValidation("txova_vehicle_year", validateTxovaVehicleYear)
+	//nolint:errcheck // Registration errors are not possible with valid inputs
+	validate.RegisterValidation("txova_insurance_policy", validateTxovaInsurancePolicy)
+	//nolint:errcheck // Registration errors are not possible with valid inputs
+	validate.RegisterValidation("txova_referral_code", validateTxovaReferralCode)
+	//nolint:errcheck // Registration errors are not possible with valid inputs
+	validate.RegisterValidation("txova_license_category", validateTxovaLicenseCategory)
+	//nolint:errcheck // Registration errors are not possible with valid inputs
+	validate.RegisterValidation("txova_ride_ref", validateTxovaRideRef)
+	//nolint:errcheck // Registration errors are not possible with valid inputs
+	validate.RegisterValidation("txova_vehicle_category", validateTxovaVehicleCategory)
+}
+
+// jsonFieldName returns the JSON tag name of a field, falling back to the Go field name.
+func jsonFieldName(fld reflect.StructField) string {
+	name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return fld.Name
+	}
+	if name == "" {
+		return fld.Name
+	}
+	return name
 }
 
 // getValidator returns the singleton validator instance.
@@ -83,6 +115,63 @@ func Validate(s interface{}) valerrors.ValidationErrors {
 	}
 }
 
+// ValidateWithFieldPrefix validates a struct like Validate and prefixes every
+// error's Field with prefix and a dot, e.g. "driver.phone", so the results of
+// a nested struct can be merged into its parent's errors.
+// An empty prefix leaves fields unchanged.
+func ValidateWithFieldPrefix(prefix string, s interface{}) valerrors.ValidationErrors {
+	errs := Validate(s)
+	if prefix == "" {
+		return errs
+	}
+	for i := range errs {
+		errs[i].Field = prefix + "." + errs[i].Field
+	}
+	return errs
+}
+
+// ValidateBatch validates each item with Validate.
+// The result has one entry per item, in the same order; entries for valid items are nil.
+func ValidateBatch(items []interface{}) []valerrors.ValidationErrors {
+	results := make([]valerrors.ValidationErrors, len(items))
+	for i, item := range items {
+		results[i] = Validate(item)
+	}
+	return results
+}
+
+// ValidateBatchConcurrent is like ValidateBatch but validates items on up to
+// workers goroutines. Results stay aligned with items.
+// Panics if workers is not positive.
+func ValidateBatchConcurrent(items []interface{}, workers int) []valerrors.ValidationErrors {
+	if workers <= 0 {
+		panic("structval: ValidateBatchConcurrent requires at least one worker")
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	results := make([]valerrors.ValidationErrors, len(items))
+	indexes := make(chan int, len(items))
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = Validate(items[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
 // ValidateVar validates a single variable against a tag.
 // Returns nil if validation passes.
 func ValidateVar(field interface{}, tag string) valerrors.ValidationErrors {
@@ -110,6 +199,158 @@ func RegisterValidation(tag string, fn validator.Func) error {
 	return v.RegisterValidation(tag, fn)
 }
 
+// RegisterLowRatingCommentValidation registers the txova_low_rating_comment
+// cross-field check for structType. When the integer ratingField is at or below
+// rating.LowRatingThreshold, the string commentField must hold at least
+// rating.DefaultLowRatingCommentLength characters after sanitization.
+// It runs alongside any other cross-field check registered for structType.
+// Call it during initialization, before structType is first validated.
+func RegisterLowRatingCommentValidation(structType interface{}, ratingField, commentField string) error {
+	t := reflect.TypeOf(structType)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("structval: %T is not a struct type", structType)
+	}
+
+	rf, ok := t.FieldByName(ratingField)
+	if !ok || !isIntKind(rf.Type.Kind()) {
+		return fmt.Errorf("structval: %s has no integer field %q", t.Name(), ratingField)
+	}
+	cf, ok := t.FieldByName(commentField)
+	if !ok || cf.Type.Kind() != reflect.String {
+		return fmt.Errorf("structval: %s has no string field %q", t.Name(), commentField)
+	}
+
+	fieldName := jsonFieldName(cf)
+	param := strconv.Itoa(rating.DefaultLowRatingCommentLength)
+
+	addStructCheck(t, structType, func(sl validator.StructLevel) {
+		current := sl.Current()
+		r, err := current.FieldByIndexErr(rf.Index)
+		if err != nil {
+			return
+		}
+		c, err := current.FieldByIndexErr(cf.Index)
+		if err != nil {
+			return
+		}
+
+		var value int
+		if r.CanInt() {
+			value = int(r.Int())
+		} else if r.Uint() <= rating.LowRatingThreshold {
+			value = int(r.Uint()) // #nosec G115 - bounds checked above
+		} else {
+			return
+		}
+
+		if rating.ValidateLowRatingCommentDefault(value, c.String()) != nil {
+			sl.ReportError(c.Interface(), fieldName, commentField, "txova_low_rating_comment", param)
+		}
+	})
+	return nil
+}
+
+// RegisterPassengerCountValidation registers the txova_passengers cross-field
+// check for structType. The integer countField must fit the capacity of the
+// vehicle category held in the string categoryField, as checked by
+// ride.ValidatePassengerCount. An unknown category is reported on
+// categoryField with the txova_vehicle_category tag; an empty category is
+// left to a required tag. Like RegisterLowRatingCommentValidation, it adds to
+// the checks of structType and belongs in initialization code.
+func RegisterPassengerCountValidation(structType interface{}, categoryField, countField string) error {
+	t := reflect.TypeOf(structType)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("structval: %T is not a struct type", structType)
+	}
+
+	cf, ok := t.FieldByName(categoryField)
+	if !ok || cf.Type.Kind() != reflect.String {
+		return fmt.Errorf("structval: %s has no string field %q", t.Name(), categoryField)
+	}
+	nf, ok := t.FieldByName(countField)
+	if !ok || !isIntKind(nf.Type.Kind()) {
+		return fmt.Errorf("structval: %s has no integer field %q", t.Name(), countField)
+	}
+
+	categoryName := jsonFieldName(cf)
+	countName := jsonFieldName(nf)
+
+	addStructCheck(t, structType, func(sl validator.StructLevel) {
+		current := sl.Current()
+		c, err := current.FieldByIndexErr(cf.Index)
+		if err != nil {
+			return
+		}
+		n, err := current.FieldByIndexErr(nf.Index)
+		if err != nil {
+			return
+		}
+
+		category := c.String()
+		if strings.TrimSpace(category) == "" {
+			return
+		}
+		if ride.ValidateVehicleCategory(category) != nil {
+			sl.ReportError(c.Interface(), categoryName, categoryField, "txova_vehicle_category", "")
+			return
+		}
+
+		count := math.MaxInt32
+		if n.CanInt() {
+			count = int(max(min(n.Int(), math.MaxInt32), math.MinInt32))
+		} else if n.Uint() < math.MaxInt32 {
+			count = int(n.Uint()) // #nosec G115 - bounds checked above
+		}
+		if !ride.IsValidPassengerCount(category, count) {
+			sl.ReportError(n.Interface(), countName, countField, "txova_passengers", category)
+		}
+	})
+	return nil
+}
+
+// addStructCheck adds a cross-field check for the struct type t, registering
+// the function that runs the checks of t the first time t is seen. The
+// validator caches struct metadata on first use, so the first check for t
+// must be added before t is validated; later checks are picked up at once.
+func addStructCheck(t reflect.Type, structType interface{}, check structCheck) {
+	structChecksMu.Lock()
+	defer structChecksMu.Unlock()
+	checks := structChecks[t]
+	structChecks[t] = append(checks[:len(checks):len(checks)], check)
+	if len(checks) == 0 {
+		getValidator().RegisterStructValidation(func(sl validator.StructLevel) {
+			runStructChecks(t, sl)
+		}, structType)
+	}
+}
+
+// runStructChecks runs the cross-field checks registered for the struct type t.
+func runStructChecks(t reflect.Type, sl validator.StructLevel) {
+	structChecksMu.RLock()
+	checks := structChecks[t]
+	structChecksMu.RUnlock()
+	for _, check := range checks {
+		check(sl)
+	}
+}
+
+// isIntKind returns true for signed and unsigned integer kinds.
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
 // translateErrors converts go-playground validator errors to our ValidationErrors.
 func translateErrors(errs validator.ValidationErrors) valerrors.ValidationErrors {
 	if len(errs) == 0 {
@@ -153,11 +394,15 @@ func translateError(err validator.FieldError) valerrors.ValidationError {
 
 // formatTagExpectations maps validation tags to expected format descriptions.
 var formatTagExpectations = map[string]string{
-	"email":     "valid email address",
-	"url":       "valid URL",
-	"mz_phone":  "valid Mozambique phone number",
-	"mz_plate":  "valid Mozambique license plate",
-	"txova_pin": "4-digit PIN (no sequential or repeated)",
+	"email":                  "valid email address",
+	"url":                    "valid URL",
+	"mz_phone":               "valid Mozambique phone number",
+	"mz_phone_any":           "valid Mozambique mobile or landline number",
+	"mz_plate":               "valid Mozambique license plate",
+	"txova_pin":              "4-digit PIN (no sequential or repeated)",
+	"txova_insurance_policy": "6-20 alphanumeric characters or hyphens",
+	"txova_referral_code":    "TXOVA followed by 6 uppercase letters or digits",
+	"txova_ride_ref":         "TXV- followed by 6 characters from A-Z (no I or O) and 2-9",
 }
 
 // isLowerBoundTag returns true if the tag is a lower bound validation.
@@ -189,6 +434,15 @@ func translateSpecialTag(err validator.FieldError, field, tag string, value inte
 		options := strings.Split(err.Param(), " ")
 		return valerrors.InvalidOptionWithValue(field, options, value), true
 
+	case "mz_phone":
+		s, _ := value.(string)
+		if errs := phone.ValidateDetailed(s); errs.HasErrors() {
+			ve := errs[0]
+			ve.Field = field
+			return ve, true
+		}
+		return valerrors.InvalidFormatWithValue(field, formatTagExpectations[tag], value), true
+
 	case "mz_location":
 		return valerrors.OutsideServiceArea(field), true
 
@@ -198,9 +452,32 @@ func translateSpecialTag(err validator.FieldError, field, tag string, value inte
 	case "txova_rating":
 		return valerrors.OutOfRangeWithValue(field, 1, 5, value), true
 
+	case "txova_rating_float":
+		return translateRatingFloatTag(err, field, value), true
+
 	case "txova_vehicle_year":
 		return valerrors.OutOfRangeWithValue(field, vehicle.MinVehicleYear, "current+1", value), true
 
+	case "txova_license_category":
+		return valerrors.InvalidOptionWithValue(field, vehicle.AllLicenseCategories(), value), true
+
+	case "txova_vehicle_category":
+		return valerrors.InvalidOptionWithValue(field, ride.AllVehicleCategories(), value), true
+
+	case "txova_passengers":
+		maxPassengers, _ := ride.MaxPassengersFor(err.Param())
+		return valerrors.OutOfRangeWithValue(field, ride.MinPassengers, maxPassengers, value).
+			WithParam("min", ride.MinPassengers).
+			WithParam("max", maxPassengers), true
+
+	case "txova_low_rating_comment":
+		s, _ := value.(string)
+		sanitized := rating.SanitizeReviewText(s)
+		if sanitized == "" {
+			return valerrors.Required(field), true
+		}
+		return valerrors.TooShortWithValue(field, parseIntParam(err.Param()), len([]rune(sanitized))), true
+
 	default:
 		return valerrors.ValidationError{}, false
 	}
@@ -228,6 +505,28 @@ func translateMaxTag(err validator.FieldError, field string, value interface{})
 	return valerrors.OutOfRangeWithValue(field, "-∞", param, value)
 }
 
+// translateRatingFloatTag reports the txova_rating_float failure for value,
+// which is OUT_OF_RANGE or INVALID_FORMAT depending on the value.
+func translateRatingFloatTag(err validator.FieldError, field string, value interface{}) valerrors.ValidationError {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64 {
+		if ve, ok := rating.ValidateRatingFloatWithStep(v.Float(), ratingStepParam(err.Param())).(valerrors.ValidationError); ok {
+			ve.Field = field
+			return ve
+		}
+	}
+	return valerrors.InvalidFormatWithValue(field, "rating in half-star steps", value)
+}
+
+// ratingStepParam parses the optional step parameter of txova_rating_float,
+// defaulting to rating.DefaultRatingStep.
+func ratingStepParam(param string) float64 {
+	if step, err := strconv.ParseFloat(param, 64); err == nil {
+		return step
+	}
+	return rating.DefaultRatingStep
+}
+
 // parseIntParam parses a string parameter to int, returning 0 on error.
 func parseIntParam(s string) int {
 	var n int
@@ -250,6 +549,15 @@ func validateMzPhone(fl validator.FieldLevel) bool {
 	return phone.Validate(value)
 }
 
+// validateMzPhoneAny validates Mozambique mobile or landline numbers.
+func validateMzPhoneAny(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value == "" {
+		return true // Empty is handled by required tag
+	}
+	return phone.ValidateAny(value)
+}
+
 // validateMzPlate validates Mozambique license plates.
 func validateMzPlate(fl validator.FieldLevel) bool {
 	value := fl.Field().String()
@@ -330,7 +638,11 @@ func validateTxovaPin(fl validator.FieldLevel) bool {
 }
 
 // validateTxovaMoney validates positive money amounts.
-// Expects an int64 value representing centavos.
+// Expects an int64 value representing centavos. Float values must be finite.
+// String values must be plain decimal amounts (e.g. "100" or "100.50"), so
+// "Inf", "NaN", exponents, and signs are rejected; such fields should be
+// sanitized (trimmed) before struct validation, as surrounding whitespace
+// fails parsing.
 func validateTxovaMoney(fl validator.FieldLevel) bool {
 	field := fl.Field()
 
@@ -340,13 +652,44 @@ func validateTxovaMoney(fl validator.FieldLevel) bool {
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		return field.Uint() > 0
 	case reflect.Float32, reflect.Float64:
-		return field.Float() > 0
+		v := field.Float()
+		return v > 0 && !math.IsInf(v, 1)
+	case reflect.String:
+		if !isDecimalAmount(field.String()) {
+			return false
+		}
+		v, err := strconv.ParseFloat(field.String(), 64)
+		if err != nil {
+			return false
+		}
+		return v > 0 && !math.IsInf(v, 1)
 	default:
 		return false
 	}
 }
 
+// isDecimalAmount reports whether s is one or more digits, optionally
+// followed by a dot and one or more digits.
+func isDecimalAmount(s string) bool {
+	whole, frac, hasDot := strings.Cut(s, ".")
+	return isDigits(whole) && (!hasDot || isDigits(frac))
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // validateTxovaRating validates rating values (1-5).
+// Float values (e.g. aggregated averages) are accepted anywhere in [1.0, 5.0].
 func validateTxovaRating(fl validator.FieldLevel) bool {
 	field := fl.Field()
 
@@ -364,6 +707,9 @@ func validateTxovaRating(fl validator.FieldLevel) bool {
 			return false
 		}
 		value = int(v) // #nosec G115 - bounds checked above, max value is 5
+	case reflect.Float32, reflect.Float64:
+		v := field.Float()
+		return v >= 1 && v <= 5
 	default:
 		return false
 	}
@@ -371,6 +717,17 @@ func validateTxovaRating(fl validator.FieldLevel) bool {
 	return rating.ValidateRating(value) == nil
 }
 
+// validateTxovaRatingFloat validates fractional ratings in [1.0, 5.0] in
+// half-star steps, or in the step given as the tag parameter, e.g.
+// txova_rating_float=0.25. Only float fields are accepted.
+func validateTxovaRatingFloat(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	if field.Kind() != reflect.Float32 && field.Kind() != reflect.Float64 {
+		return false
+	}
+	return rating.ValidateRatingFloatWithStep(field.Float(), ratingStepParam(fl.Param())) == nil
+}
+
 // validateTxovaVehicleYear validates vehicle years (2010 to current year + 1).
 func validateTxovaVehicleYear(fl validator.FieldLevel) bool {
 	field := fl.Field()
@@ -391,3 +748,48 @@ func validateTxovaVehicleYear(fl validator.FieldLevel) bool {
 
 	return vehicle.ValidateYear(year) == nil
 }
+
+// validateTxovaInsurancePolicy validates insurance policy numbers.
+func validateTxovaInsurancePolicy(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value == "" {
+		return true // Empty is handled by required tag
+	}
+	return vehicle.ValidateInsurancePolicyNumber(value) == nil
+}
+
+// validateTxovaReferralCode validates referral codes.
+func validateTxovaReferralCode(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value == "" {
+		return true // Empty is handled by required tag
+	}
+	return ride.ValidateReferralCode(value) == nil
+}
+
+// validateTxovaRideRef validates ride reference codes.
+func validateTxovaRideRef(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value == "" {
+		return true // Empty is handled by required tag
+	}
+	return ride.ValidateRideReference(value) == nil
+}
+
+// validateTxovaLicenseCategory validates driver's license categories.
+func validateTxovaLicenseCategory(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value == "" {
+		return true // Empty is handled by required tag
+	}
+	return vehicle.ValidateDriverLicenseCategory(value) == nil
+}
+
+// validateTxovaVehicleCategory validates a ride vehicle category.
+func validateTxovaVehicleCategory(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value == "" {
+		return true // Empty is handled by required tag
+	}
+	return ride.ValidateVehicleCategory(value) == nil
+}
@@ -1,6 +1,7 @@
 package structval
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -357,6 +358,130 @@ func TestValidate_OptionalFields(t *testing.T) {
 	})
 }
 
+// batchItems returns n users, every third of which is missing its email.
+func batchItems(n int) []interface{} {
+	items := make([]interface{}, n)
+	for i := range items {
+		u := UserRegistration{
+			Name:     "John Doe",
+			Email:    "john@example.com",
+			Phone:    "+258841234567",
+			Password: "password123",
+		}
+		if i%3 == 0 {
+			u.Email = ""
+		}
+		items[i] = u
+	}
+	return items
+}
+
+func TestValidateWithFieldPrefix(t *testing.T) {
+	invalid := UserRegistration{Name: "John Doe", Phone: "+258841234567", Password: "password123"}
+
+	t.Run("prefixes every field", func(t *testing.T) {
+		errs := ValidateWithFieldPrefix("driver", invalid)
+		want := Validate(invalid)
+		if len(errs) != len(want) || len(errs) == 0 {
+			t.Fatalf("ValidateWithFieldPrefix() = %v, want %d errors", errs, len(want))
+		}
+		for i, e := range errs {
+			if e.Field != "driver."+want[i].Field {
+				t.Errorf("errs[%d].Field = %q, want %q", i, e.Field, "driver."+want[i].Field)
+			}
+			if e.Code != want[i].Code || e.Message != want[i].Message {
+				t.Errorf("errs[%d] = %v, want same code and message as %v", i, e, want[i])
+			}
+		}
+		if !errs.HasField("driver.email") {
+			t.Errorf("ValidateWithFieldPrefix() = %v, want driver.email", errs)
+		}
+	})
+
+	t.Run("nested prefix", func(t *testing.T) {
+		errs := ValidateWithFieldPrefix("rides.driver", invalid)
+		if !errs.HasField("rides.driver.email") {
+			t.Errorf("ValidateWithFieldPrefix() = %v, want rides.driver.email", errs)
+		}
+	})
+
+	t.Run("empty prefix", func(t *testing.T) {
+		errs := ValidateWithFieldPrefix("", invalid)
+		if !errs.HasField("email") {
+			t.Errorf("ValidateWithFieldPrefix(\"\") = %v, want unprefixed email", errs)
+		}
+	})
+
+	t.Run("valid struct", func(t *testing.T) {
+		invalid.Email = "john@example.com"
+		if errs := ValidateWithFieldPrefix("driver", invalid); errs != nil {
+			t.Errorf("ValidateWithFieldPrefix() = %v, want nil", errs)
+		}
+	})
+}
+
+func TestValidateBatch(t *testing.T) {
+	results := ValidateBatch(batchItems(6))
+	if len(results) != 6 {
+		t.Fatalf("ValidateBatch() returned %d results, want 6", len(results))
+	}
+	for i, errs := range results {
+		if wantErr := i%3 == 0; (errs != nil) != wantErr {
+			t.Errorf("results[%d] = %v, wantErr %v", i, errs, wantErr)
+		}
+	}
+
+	if got := ValidateBatch(nil); len(got) != 0 {
+		t.Errorf("ValidateBatch(nil) = %v, want empty", got)
+	}
+}
+
+func TestValidateBatchConcurrent(t *testing.T) {
+	items := batchItems(200)
+	want := ValidateBatch(items)
+
+	for _, workers := range []int{1, 4, 16, 500} {
+		results := ValidateBatchConcurrent(items, workers)
+		if len(results) != len(items) {
+			t.Fatalf("workers=%d: got %d results, want %d", workers, len(results), len(items))
+		}
+		for i := range results {
+			if (results[i] == nil) != (want[i] == nil) {
+				t.Fatalf("workers=%d: results[%d] = %v, want %v", workers, i, results[i], want[i])
+			}
+			if results[i] != nil && results[i][0].Field != "email" {
+				t.Errorf("workers=%d: results[%d] field = %v, want email", workers, i, results[i][0].Field)
+			}
+		}
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		if got := ValidateBatchConcurrent(nil, 4); len(got) != 0 {
+			t.Errorf("ValidateBatchConcurrent(nil) = %v, want empty", got)
+		}
+	})
+
+	t.Run("mixed types", func(t *testing.T) {
+		items := []interface{}{
+			VehicleInfo{},
+			UserRegistration{Name: "John Doe", Email: "john@example.com", Phone: "+258841234567", Password: "password123"},
+		}
+		results := ValidateBatchConcurrent(items, 2)
+		if results[0] == nil || results[1] != nil {
+			t.Errorf("ValidateBatchConcurrent() = %v", results)
+		}
+	})
+
+	t.Run("panics without workers", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("ValidateBatchConcurrent(workers=0) did not panic")
+			}
+		}()
+		ValidateBatchConcurrent(items, 0)
+	})
+}
+
 func TestValidateVar(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -421,6 +546,29 @@ func TestRegisterValidation(t *testing.T) {
 	})
 }
 
+func TestValidateMzPhone_Reasons(t *testing.T) {
+	type PhoneTest struct {
+		Phone string `json:"phone" validate:"required,mz_phone"`
+	}
+
+	tests := []struct {
+		phone string
+		code  string
+		value string
+	}{
+		{"881234567", valerrors.CodeInvalidOption, "***4567"},
+		{"+254841234567", valerrors.CodeInvalidFormat, "***4567"},
+		{"8412345", valerrors.CodeInvalidFormat, "***2345"},
+	}
+
+	for _, tt := range tests {
+		errs := Validate(PhoneTest{Phone: tt.phone})
+		if len(errs) != 1 || errs[0].Field != "phone" || errs[0].Code != tt.code || errs[0].Value != tt.value {
+			t.Errorf("Validate(%q) = %v, want %s on phone with value %s", tt.phone, errs, tt.code, tt.value)
+		}
+	}
+}
+
 func TestValidateMzPhone(t *testing.T) {
 	type PhoneTest struct {
 		Phone string `json:"phone" validate:"required,mz_phone"`
@@ -655,6 +803,136 @@ func TestValidateTxovaVehicleYear(t *testing.T) {
 	}
 }
 
+func TestValidateTxovaInsurancePolicy(t *testing.T) {
+	type PolicyTest struct {
+		Policy string `json:"policy" validate:"omitempty,txova_insurance_policy"`
+	}
+
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr bool
+	}{
+		{"valid policy", "POL-123456", false},
+		{"empty optional", "", false},
+		{"too short", "AB1", true},
+		{"invalid characters", "POL#123456", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(PolicyTest{Policy: tt.policy})
+			if tt.wantErr && errs == nil {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && errs != nil {
+				t.Errorf("unexpected error: %v", errs)
+			}
+			if tt.wantErr && errs != nil && errs[0].Code != valerrors.CodeInvalidFormat {
+				t.Errorf("error code = %v, want %v", errs[0].Code, valerrors.CodeInvalidFormat)
+			}
+		})
+	}
+}
+
+func TestValidateTxovaReferralCode(t *testing.T) {
+	type ReferralTest struct {
+		Code string `json:"referral_code" validate:"omitempty,txova_referral_code"`
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		wantErr bool
+	}{
+		{"valid code", "TXOVA3A9B2C", false},
+		{"empty optional", "", false},
+		{"wrong prefix", "PROMO3A9B2C", true},
+		{"lowercase", "txova3a9b2c", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(ReferralTest{Code: tt.code})
+			if tt.wantErr && errs == nil {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && errs != nil {
+				t.Errorf("unexpected error: %v", errs)
+			}
+			if tt.wantErr && errs != nil && errs[0].Code != valerrors.CodeInvalidFormat {
+				t.Errorf("error code = %v, want %v", errs[0].Code, valerrors.CodeInvalidFormat)
+			}
+		})
+	}
+}
+
+func TestValidateMzPhoneAny(t *testing.T) {
+	type ContactTest struct {
+		Phone string `json:"emergency_phone" validate:"omitempty,mz_phone_any"`
+	}
+
+	tests := []struct {
+		name    string
+		phone   string
+		wantErr bool
+	}{
+		{"maputo landline", "21123456", false},
+		{"mobile", "+258841234567", false},
+		{"empty optional", "", false},
+		{"7-digit partial", "2112345", true},
+		{"unknown area code", "22123456", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(ContactTest{Phone: tt.phone})
+			if tt.wantErr && errs == nil {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && errs != nil {
+				t.Errorf("unexpected error: %v", errs)
+			}
+			if tt.wantErr && errs != nil && errs[0].Code != valerrors.CodeInvalidFormat {
+				t.Errorf("error code = %v, want %v", errs[0].Code, valerrors.CodeInvalidFormat)
+			}
+		})
+	}
+}
+
+func TestValidateTxovaRideRef(t *testing.T) {
+	type ReceiptTest struct {
+		Reference string `json:"ride_reference" validate:"omitempty,txova_ride_ref"`
+	}
+
+	tests := []struct {
+		name    string
+		ref     string
+		wantErr bool
+	}{
+		{"valid reference", "TXV-7K3M9Q", false},
+		{"empty optional", "", false},
+		{"lowercase", "txv-7k3m9q", true},
+		{"missing dash", "TXV7K3M9Q", true},
+		{"ambiguous character", "TXV-7K3M0Q", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(ReceiptTest{Reference: tt.ref})
+			if tt.wantErr && errs == nil {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && errs != nil {
+				t.Errorf("unexpected error: %v", errs)
+			}
+			if tt.wantErr && errs != nil && errs[0].Code != valerrors.CodeInvalidFormat {
+				t.Errorf("error code = %v, want %v", errs[0].Code, valerrors.CodeInvalidFormat)
+			}
+		})
+	}
+}
+
 func TestFieldNameMapping(t *testing.T) {
 	type TestStruct struct {
 		UserName string `json:"user_name" validate:"required"`
@@ -828,15 +1106,50 @@ func TestMoneyValidationTypes(t *testing.T) {
 		if errs != nil {
 			t.Errorf("float money should be valid: %v", errs)
 		}
+		if errs := Validate(FloatMoney{Amount: math.Inf(1)}); errs == nil {
+			t.Error("infinite float money should be invalid")
+		}
+		if errs := Validate(FloatMoney{Amount: math.NaN()}); errs == nil {
+			t.Error("NaN float money should be invalid")
+		}
 	})
 
-	t.Run("string type fails", func(t *testing.T) {
+	t.Run("string type", func(t *testing.T) {
 		type StringMoney struct {
 			Amount string `json:"amount" validate:"txova_money"`
 		}
-		errs := Validate(StringMoney{Amount: "100"})
-		if errs == nil {
-			t.Error("string money should fail validation")
+
+		tests := []struct {
+			name    string
+			amount  string
+			wantErr bool
+		}{
+			{"integer amount", "100", false},
+			{"decimal amount", "100.50", false},
+			{"zero", "0", true},
+			{"negative", "-1", true},
+			{"non-numeric", "abc", true},
+			{"infinity", "Inf", true},
+			{"signed infinity", "+Inf", true},
+			{"spelled infinity", "infinity", true},
+			{"NaN", "NaN", true},
+			{"exponent", "1e400", true},
+			{"hex float", "0x1p3", true},
+			{"leading plus", "+100", true},
+			{"trailing dot", "100.", true},
+			{"leading dot", ".50", true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				errs := Validate(StringMoney{Amount: tt.amount})
+				if tt.wantErr && errs == nil {
+					t.Error("expected validation error")
+				}
+				if !tt.wantErr && errs != nil {
+					t.Errorf("unexpected error: %v", errs)
+				}
+			})
 		}
 	})
 }
@@ -852,6 +1165,37 @@ func TestRatingValidationTypes(t *testing.T) {
 		}
 	})
 
+	t.Run("float64 type", func(t *testing.T) {
+		type FloatRating struct {
+			Rating float64 `json:"rating" validate:"txova_rating"`
+		}
+
+		tests := []struct {
+			name    string
+			rating  float64
+			wantErr bool
+		}{
+			{"average 4.5", 4.5, false},
+			{"min 1.0", 1.0, false},
+			{"max 5.0", 5.0, false},
+			{"zero", 0.0, true},
+			{"below min 0.9", 0.9, true},
+			{"above max 5.1", 5.1, true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				errs := Validate(FloatRating{Rating: tt.rating})
+				if tt.wantErr && errs == nil {
+					t.Error("expected validation error")
+				}
+				if !tt.wantErr && errs != nil {
+					t.Errorf("unexpected error: %v", errs)
+				}
+			})
+		}
+	})
+
 	t.Run("string type fails", func(t *testing.T) {
 		type StringRating struct {
 			Rating string `json:"rating" validate:"txova_rating"`
@@ -863,6 +1207,53 @@ func TestRatingValidationTypes(t *testing.T) {
 	})
 }
 
+func TestValidateTxovaRatingFloat(t *testing.T) {
+	type HalfStar struct {
+		Rating float64 `json:"rating" validate:"txova_rating_float"`
+	}
+	type QuarterStar struct {
+		Rating float32 `json:"rating" validate:"txova_rating_float=0.25"`
+	}
+
+	tests := []struct {
+		name     string
+		data     interface{}
+		wantCode string
+	}{
+		{"half star", HalfStar{Rating: 3.5}, ""},
+		{"whole star", HalfStar{Rating: 5}, ""},
+		{"not a half step", HalfStar{Rating: 3.7}, valerrors.CodeInvalidFormat},
+		{"above range", HalfStar{Rating: 5.5}, valerrors.CodeOutOfRange},
+		{"zero value", HalfStar{}, valerrors.CodeOutOfRange},
+		{"quarter star", QuarterStar{Rating: 3.75}, ""},
+		{"not a quarter step", QuarterStar{Rating: 3.8}, valerrors.CodeInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(tt.data)
+			if tt.wantCode == "" {
+				if errs != nil {
+					t.Errorf("Validate() = %v, want nil", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Field != "rating" || errs[0].Code != tt.wantCode {
+				t.Errorf("Validate() = %v, want rating/%s", errs, tt.wantCode)
+			}
+		})
+	}
+
+	t.Run("int field fails", func(t *testing.T) {
+		type IntRating struct {
+			Rating int `json:"rating" validate:"txova_rating_float"`
+		}
+		if errs := Validate(IntRating{Rating: 4}); errs == nil {
+			t.Error("int field should fail txova_rating_float")
+		}
+	})
+}
+
 func TestLocationValidationInvalidKind(t *testing.T) {
 	type InvalidLocation struct {
 		Location string `json:"location" validate:"mz_location"`
@@ -874,3 +1265,253 @@ func TestLocationValidationInvalidKind(t *testing.T) {
 		t.Error("string location should fail mz_location validation")
 	}
 }
+
+type RideReview struct {
+	Rating  int    `json:"rating" validate:"required,txova_rating"`
+	Comment string `json:"comment"`
+}
+
+func TestLowRatingCommentValidation(t *testing.T) {
+	if err := RegisterLowRatingCommentValidation(RideReview{}, "Rating", "Comment"); err != nil {
+		t.Fatalf("RegisterLowRatingCommentValidation() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		review  RideReview
+		wantErr bool
+		errCode string
+	}{
+		{"high rating without comment", RideReview{Rating: 5}, false, ""},
+		{"rating 3 without comment", RideReview{Rating: 3}, false, ""},
+		{"low rating without comment", RideReview{Rating: 1}, true, valerrors.CodeRequired},
+		{"low rating html only comment", RideReview{Rating: 2, Comment: "<br>"}, true, valerrors.CodeRequired},
+		{"low rating short comment", RideReview{Rating: 2, Comment: "bad"}, true, valerrors.CodeTooShort},
+		{"low rating long comment", RideReview{Rating: 1, Comment: "Driver ignored the route and was rude"}, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(tt.review)
+			if (errs != nil) != tt.wantErr {
+				t.Fatalf("Validate() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+			}
+			if errs[0].Field != "comment" {
+				t.Errorf("error field = %v, want comment", errs[0].Field)
+			}
+			if errs[0].Code != tt.errCode {
+				t.Errorf("error code = %v, want %v", errs[0].Code, tt.errCode)
+			}
+		})
+	}
+}
+
+func TestRegisterLowRatingCommentValidation_Invalid(t *testing.T) {
+	tests := []struct {
+		name         string
+		structType   interface{}
+		ratingField  string
+		commentField string
+	}{
+		{"not a struct", 42, "Rating", "Comment"},
+		{"nil", nil, "Rating", "Comment"},
+		{"missing rating field", RideReview{}, "Stars", "Comment"},
+		{"missing comment field", RideReview{}, "Rating", "Text"},
+		{"rating field wrong type", RideReview{}, "Comment", "Comment"},
+		{"comment field wrong type", RideReview{}, "Rating", "Rating"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterLowRatingCommentValidation(tt.structType, tt.ratingField, tt.commentField); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+type PassengerRideRequest struct {
+	Category   string `json:"category" validate:"required"`
+	Passengers uint8  `json:"passengers"`
+}
+
+func TestPassengerCountValidation(t *testing.T) {
+	if err := RegisterPassengerCountValidation(&PassengerRideRequest{}, "Category", "Passengers"); err != nil {
+		t.Fatalf("RegisterPassengerCountValidation() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		request  PassengerRideRequest
+		errField string
+		errCode  string
+		wantMax  int
+	}{
+		{"economy at capacity", PassengerRideRequest{Category: "economy", Passengers: 4}, "", "", 0},
+		{"economy over capacity", PassengerRideRequest{Category: "economy", Passengers: 5}, "passengers", valerrors.CodeOutOfRange, 4},
+		{"xl at capacity", PassengerRideRequest{Category: "XL", Passengers: 6}, "", "", 0},
+		{"moto nine passengers", PassengerRideRequest{Category: "moto", Passengers: 9}, "passengers", valerrors.CodeOutOfRange, 1},
+		{"no passengers", PassengerRideRequest{Category: "comfort", Passengers: 0}, "passengers", valerrors.CodeOutOfRange, 4},
+		{"unknown category", PassengerRideRequest{Category: "limo", Passengers: 2}, "category", valerrors.CodeInvalidOption, 0},
+		{"missing category", PassengerRideRequest{Passengers: 2}, "category", valerrors.CodeRequired, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(tt.request)
+			if tt.errField == "" {
+				if errs != nil {
+					t.Fatalf("Validate() errors = %v, want none", errs)
+				}
+				return
+			}
+			if len(errs) != 1 {
+				t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+			}
+			if errs[0].Field != tt.errField || errs[0].Code != tt.errCode {
+				t.Errorf("error = %s/%s, want %s/%s", errs[0].Field, errs[0].Code, tt.errField, tt.errCode)
+			}
+			if tt.wantMax != 0 && errs[0].Param("max") != tt.wantMax {
+				t.Errorf("max param = %v, want %d", errs[0].Param("max"), tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestRegisterPassengerCountValidation_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		structType    interface{}
+		categoryField string
+		countField    string
+	}{
+		{"not a struct", "moto", "Category", "Passengers"},
+		{"nil", nil, "Category", "Passengers"},
+		{"missing category field", PassengerRideRequest{}, "Type", "Passengers"},
+		{"missing count field", PassengerRideRequest{}, "Category", "Seats"},
+		{"category field wrong type", PassengerRideRequest{}, "Passengers", "Passengers"},
+		{"count field wrong type", PassengerRideRequest{}, "Category", "Category"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := RegisterPassengerCountValidation(tt.structType, tt.categoryField, tt.countField); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+type ReviewedRide struct {
+	Category   string `json:"category" validate:"required"`
+	Passengers int    `json:"passengers"`
+	Rating     int    `json:"rating" validate:"required,txova_rating"`
+	Comment    string `json:"comment"`
+}
+
+func TestStructChecks_Combined(t *testing.T) {
+	if err := RegisterPassengerCountValidation(ReviewedRide{}, "Category", "Passengers"); err != nil {
+		t.Fatalf("RegisterPassengerCountValidation() error = %v", err)
+	}
+	if err := RegisterLowRatingCommentValidation(ReviewedRide{}, "Rating", "Comment"); err != nil {
+		t.Fatalf("RegisterLowRatingCommentValidation() error = %v", err)
+	}
+
+	errs := Validate(ReviewedRide{Category: "moto", Passengers: 3, Rating: 1, Comment: "bad"})
+	if len(errs) != 2 || !errs.HasField("passengers") || !errs.HasField("comment") {
+		t.Errorf("Validate() errors = %v, want passengers and comment errors", errs)
+	}
+	if errs := Validate(ReviewedRide{Category: "moto", Passengers: 1, Rating: 5}); errs != nil {
+		t.Errorf("Validate() errors = %v, want none", errs)
+	}
+}
+
+type ReviewDetails struct {
+	Comment string `json:"comment"`
+}
+
+type EmbeddedReview struct {
+	Rating int `json:"rating" validate:"required,txova_rating"`
+	*ReviewDetails
+}
+
+func TestLowRatingCommentValidation_NilEmbedded(t *testing.T) {
+	if err := RegisterLowRatingCommentValidation(EmbeddedReview{}, "Rating", "Comment"); err != nil {
+		t.Fatalf("RegisterLowRatingCommentValidation() error = %v", err)
+	}
+
+	if errs := Validate(EmbeddedReview{Rating: 1}); errs != nil {
+		t.Errorf("Validate() with nil embedded struct errors = %v, want none", errs)
+	}
+	errs := Validate(EmbeddedReview{Rating: 1, ReviewDetails: &ReviewDetails{Comment: "bad"}})
+	if len(errs) != 1 || errs[0].Field != "comment" {
+		t.Errorf("Validate() errors = %v, want comment error", errs)
+	}
+}
+
+func TestValidateTxovaVehicleCategory(t *testing.T) {
+	type CategoryTest struct {
+		Category string `json:"vehicle_category" validate:"omitempty,txova_vehicle_category"`
+	}
+
+	tests := []struct {
+		name     string
+		category string
+		wantErr  bool
+	}{
+		{"economy", "economy", false},
+		{"mixed case", "Comfort", false},
+		{"empty", "", false},
+		{"unknown", "limo", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(CategoryTest{Category: tt.category})
+			if (errs != nil) != tt.wantErr {
+				t.Fatalf("Validate() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+			if tt.wantErr && (errs[0].Field != "vehicle_category" || errs[0].Code != valerrors.CodeInvalidOption) {
+				t.Errorf("error = %s/%s, want vehicle_category/%s", errs[0].Field, errs[0].Code, valerrors.CodeInvalidOption)
+			}
+		})
+	}
+}
+
+func TestValidateTxovaLicenseCategory(t *testing.T) {
+	type LicenseTest struct {
+		Category string `json:"license_category" validate:"omitempty,txova_license_category"`
+	}
+
+	tests := []struct {
+		name     string
+		category string
+		wantErr  bool
+	}{
+		{"category A", "A", false},
+		{"lowercase", "b", false},
+		{"empty optional", "", false},
+		{"unknown category", "F", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(LicenseTest{Category: tt.category})
+			if tt.wantErr && errs == nil {
+				t.Error("expected validation error")
+			}
+			if !tt.wantErr && errs != nil {
+				t.Errorf("unexpected error: %v", errs)
+			}
+			if tt.wantErr && errs != nil && errs[0].Code != valerrors.CodeInvalidOption {
+				t.Errorf("error code = %v, want %v", errs[0].Code, valerrors.CodeInvalidOption)
+			}
+		})
+	}
+}
@@ -3,11 +3,16 @@ package vehicle
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/Dorico-Dynamics/txova-go-types/vehicle"
 
 	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+	"github.com/Dorico-Dynamics/txova-go-validation/ride"
 )
 
 // Vehicle year constraints.
@@ -15,6 +20,57 @@ const (
 	MinVehicleYear = 2010
 )
 
+// Vehicle make length constraint.
+const (
+	MaxMakeLength = 30
+)
+
+// Passenger seat constraints. Vehicles with more than 8 passenger seats
+// require a category D license and are not accepted.
+const (
+	MinVehicleSeats = 1
+	MaxVehicleSeats = 8
+)
+
+// Insurance policy number length constraints.
+const (
+	MinInsurancePolicyLength = 6
+	MaxInsurancePolicyLength = 20
+)
+
+// Driver's license categories issued in Mozambique.
+const (
+	LicenseCategoryA = "A" // motorcycles
+	LicenseCategoryB = "B" // light vehicles
+	LicenseCategoryC = "C" // heavy goods vehicles
+	LicenseCategoryD = "D" // passenger vehicles over 8 seats
+	LicenseCategoryE = "E" // articulated vehicles and heavy trailers
+)
+
+// Vehicle categories offered to riders, as defined by the ride package.
+const (
+	VehicleCategoryEconomy = ride.VehicleCategoryEconomy
+	VehicleCategoryComfort = ride.VehicleCategoryComfort
+	VehicleCategoryXL      = ride.VehicleCategoryXL
+	VehicleCategoryMoto    = ride.VehicleCategoryMoto
+)
+
+// requiredLicenseCategory maps each built-in vehicle category to the license
+// it requires. Other ride categories require defaultRequiredLicenseCategory.
+var requiredLicenseCategory = map[string]string{
+	VehicleCategoryEconomy: LicenseCategoryB,
+	VehicleCategoryComfort: LicenseCategoryB,
+	VehicleCategoryXL:      LicenseCategoryB,
+	VehicleCategoryMoto:    LicenseCategoryA,
+}
+
+// defaultRequiredLicenseCategory is the license required by a vehicle
+// category added with ride.RegisterPassengerCapacity.
+const defaultRequiredLicenseCategory = LicenseCategoryB
+
+// DefaultAllowedColors lists the accepted vehicle colors, in lowercase.
+var DefaultAllowedColors = []string{"white", "black", "silver", "red", "blue"}
+
 // ValidatePlate validates a Mozambique license plate format.
 // Accepts both standard (AAA-NNN-LL) and old (LL-NN-NN) formats.
 func ValidatePlate(input string) error {
@@ -51,6 +107,156 @@ func ValidateYear(year int) error {
 	return nil
 }
 
+// ValidateColor validates a vehicle color against DefaultAllowedColors.
+// Matching is case-insensitive and ignores surrounding whitespace.
+func ValidateColor(color string) error {
+	normalized := strings.ToLower(strings.TrimSpace(color))
+	if normalized == "" {
+		return valerrors.Required("color")
+	}
+	for _, allowed := range DefaultAllowedColors {
+		if normalized == allowed {
+			return nil
+		}
+	}
+	return valerrors.InvalidOptionWithValue("color", AllowedColors(), color)
+}
+
+// ValidateMake validates a vehicle make such as "Toyota" or "Mercedes-Benz".
+// It must be at most MaxMakeLength characters of letters, digits, spaces, and
+// hyphens; surrounding whitespace is ignored.
+func ValidateMake(vehicleMake string) error {
+	trimmed := strings.TrimSpace(vehicleMake)
+	if trimmed == "" {
+		return valerrors.Required("make")
+	}
+	if n := utf8.RuneCountInString(trimmed); n > MaxMakeLength {
+		return valerrors.TooLongWithValue("make", MaxMakeLength, n)
+	}
+	for _, c := range trimmed {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != ' ' && c != '-' {
+			return valerrors.InvalidFormatWithValue("make", "letters, digits, spaces, or hyphens", vehicleMake)
+		}
+	}
+	return nil
+}
+
+// ValidateCapacity validates the number of passenger seats, which must be
+// between MinVehicleSeats (1) and MaxVehicleSeats (8).
+func ValidateCapacity(seats int) error {
+	if seats < MinVehicleSeats || seats > MaxVehicleSeats {
+		return valerrors.OutOfRangeWithValue("seats", MinVehicleSeats, MaxVehicleSeats, seats)
+	}
+	return nil
+}
+
+// ValidateVehicleSet validates a vehicle's plate, color, make, year, and seat
+// count together and returns every failure, each on its own field.
+// Returns nil if all five are valid.
+func ValidateVehicleSet(plate, color, vehicleMake string, year, seats int) valerrors.ValidationErrors {
+	var errs valerrors.ValidationErrors
+	for _, err := range []error{
+		ValidatePlate(plate),
+		ValidateColor(color),
+		ValidateMake(vehicleMake),
+		ValidateYear(year),
+		ValidateCapacity(seats),
+	} {
+		if ve, ok := err.(valerrors.ValidationError); ok {
+			errs.Add(ve)
+		}
+	}
+	return errs
+}
+
+// AllowedColors returns a copy of the accepted vehicle colors.
+func AllowedColors() []string {
+	colors := make([]string, len(DefaultAllowedColors))
+	copy(colors, DefaultAllowedColors)
+	return colors
+}
+
+// ValidateDriverLicenseCategory validates a driver's license category.
+// Matching is case-insensitive and ignores surrounding whitespace.
+func ValidateDriverLicenseCategory(category string) error {
+	normalized := strings.ToUpper(strings.TrimSpace(category))
+	if normalized == "" {
+		return valerrors.Required("license_category")
+	}
+	for _, allowed := range AllLicenseCategories() {
+		if normalized == allowed {
+			return nil
+		}
+	}
+	return valerrors.InvalidOptionWithValue("license_category", AllLicenseCategories(), category)
+}
+
+// AllLicenseCategories returns the driver's license categories in order.
+func AllLicenseCategories() []string {
+	return []string{LicenseCategoryA, LicenseCategoryB, LicenseCategoryC, LicenseCategoryD, LicenseCategoryE}
+}
+
+// RequiredLicenseCategory returns the driver's license category needed for a
+// ride vehicle category, matched case-insensitively, and whether the vehicle
+// category is known to the ride package. Moto rides require category A and
+// every other category, including ones added with
+// ride.RegisterPassengerCapacity, category B.
+func RequiredLicenseCategory(vehicleCategory string) (string, bool) {
+	normalized, err := ride.NormalizeVehicleCategory(vehicleCategory)
+	if err != nil {
+		return "", false
+	}
+	if required, ok := requiredLicenseCategory[normalized]; ok {
+		return required, true
+	}
+	return defaultRequiredLicenseCategory, true
+}
+
+// ValidateLicenseCategoryForVehicle checks that a driver's license category
+// permits driving the given ride vehicle category, as reported by
+// RequiredLicenseCategory. Returns the ride.ValidateVehicleCategory error for
+// an unknown vehicle category and NOT_ALLOWED when the license does not match.
+func ValidateLicenseCategoryForVehicle(licenseCategory, vehicleCategory string) error {
+	if err := ValidateDriverLicenseCategory(licenseCategory); err != nil {
+		return err
+	}
+	required, ok := RequiredLicenseCategory(vehicleCategory)
+	if !ok {
+		return ride.ValidateVehicleCategory(vehicleCategory)
+	}
+	normalizedVehicle := strings.ToLower(strings.TrimSpace(vehicleCategory))
+
+	if strings.ToUpper(strings.TrimSpace(licenseCategory)) != required {
+		return valerrors.NewWithValue("license_category", valerrors.CodeNotAllowed,
+			fmt.Sprintf("%s rides require a category %s license", normalizedVehicle, required), licenseCategory)
+	}
+	return nil
+}
+
+// ValidateInsurancePolicyNumber validates an insurance policy number.
+// It must be 6-20 characters of ASCII letters, digits, and hyphens.
+func ValidateInsurancePolicyNumber(policyNumber string) error {
+	if len(policyNumber) < MinInsurancePolicyLength || len(policyNumber) > MaxInsurancePolicyLength {
+		return valerrors.InvalidFormatWithValue("insurance_policy", "6-20 alphanumeric characters or hyphens", policyNumber)
+	}
+	for _, c := range policyNumber {
+		if !isPolicyChar(c) {
+			return valerrors.InvalidFormatWithValue("insurance_policy", "6-20 alphanumeric characters or hyphens", policyNumber)
+		}
+	}
+	return nil
+}
+
+// NormalizeInsurancePolicyNumber trims surrounding whitespace and uppercases the policy number.
+func NormalizeInsurancePolicyNumber(policyNumber string) string {
+	return strings.ToUpper(strings.TrimSpace(policyNumber))
+}
+
+// isPolicyChar returns true for characters allowed in insurance policy numbers.
+func isPolicyChar(c rune) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-'
+}
+
 // GetProvince extracts the province code from a license plate.
 // Returns the province code string or empty if invalid.
 func GetProvince(input string) string {
@@ -98,3 +304,28 @@ func IsValidPlate(input string) bool {
 func IsValidYear(year int) bool {
 	return ValidateYear(year) == nil
 }
+
+// IsValidColor returns true if the color is an accepted vehicle color.
+func IsValidColor(color string) bool {
+	return ValidateColor(color) == nil
+}
+
+// IsValidMake returns true if the vehicle make is valid.
+func IsValidMake(vehicleMake string) bool {
+	return ValidateMake(vehicleMake) == nil
+}
+
+// IsValidCapacity returns true if the seat count is within acceptable range.
+func IsValidCapacity(seats int) bool {
+	return ValidateCapacity(seats) == nil
+}
+
+// IsValidInsurancePolicyNumber returns true if the policy number is valid.
+func IsValidInsurancePolicyNumber(policyNumber string) bool {
+	return ValidateInsurancePolicyNumber(policyNumber) == nil
+}
+
+// IsValidDriverLicenseCategory returns true if the license category is valid.
+func IsValidDriverLicenseCategory(category string) bool {
+	return ValidateDriverLicenseCategory(category) == nil
+}
@@ -1,10 +1,13 @@
 package vehicle
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	valerrors "github.com/Dorico-Dynamics/txova-go-validation/errors"
+	"github.com/Dorico-Dynamics/txova-go-validation/ride"
 )
 
 func TestValidatePlate(t *testing.T) {
@@ -271,3 +274,363 @@ func TestIsValidYear(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		color   string
+		wantErr bool
+		errCode string
+	}{
+		// Valid colors
+		{"lowercase", "white", false, ""},
+		{"uppercase", "BLACK", false, ""},
+		{"mixed case", "Silver", false, ""},
+		{"surrounding whitespace", "  red ", false, ""},
+		{"blue", "blue", false, ""},
+
+		// Invalid colors
+		{"empty", "", true, valerrors.CodeRequired},
+		{"whitespace only", "   ", true, valerrors.CodeRequired},
+		{"unknown color", "green", true, valerrors.CodeInvalidOption},
+		{"portuguese name", "branco", true, valerrors.CodeInvalidOption},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateColor(tt.color)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateColor(%q) error = %v, wantErr %v", tt.color, err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if ve, ok := err.(valerrors.ValidationError); ok {
+					if ve.Code != tt.errCode {
+						t.Errorf("error code = %v, want %v", ve.Code, tt.errCode)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestValidateMake(t *testing.T) {
+	tests := []struct {
+		name    string
+		make    string
+		wantErr bool
+		errCode string
+	}{
+		{"simple", "Toyota", false, ""},
+		{"hyphenated", "Mercedes-Benz", false, ""},
+		{"with space and digit", "Alfa Romeo 4C", false, ""},
+		{"accented", "Citroën", false, ""},
+		{"surrounding whitespace", "  Nissan ", false, ""},
+
+		{"empty", "", true, valerrors.CodeRequired},
+		{"whitespace only", "   ", true, valerrors.CodeRequired},
+		{"symbols", "Toyota!", true, valerrors.CodeInvalidFormat},
+		{"too long", strings.Repeat("a", MaxMakeLength+1), true, valerrors.CodeTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMake(tt.make)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateMake(%q) error = %v, wantErr %v", tt.make, err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				ve, ok := err.(valerrors.ValidationError)
+				if !ok || ve.Code != tt.errCode || ve.Field != "make" {
+					t.Errorf("ValidateMake(%q) error = %v, want make/%s", tt.make, err, tt.errCode)
+				}
+			}
+			if got := IsValidMake(tt.make); got != !tt.wantErr {
+				t.Errorf("IsValidMake(%q) = %v, want %v", tt.make, got, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCapacity(t *testing.T) {
+	tests := []struct {
+		name    string
+		seats   int
+		wantErr bool
+	}{
+		{"moto", 1, false},
+		{"sedan", 4, false},
+		{"maximum", MaxVehicleSeats, false},
+		{"zero", 0, true},
+		{"negative", -1, true},
+		{"minibus", MaxVehicleSeats + 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCapacity(tt.seats)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCapacity(%d) error = %v, wantErr %v", tt.seats, err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				ve, ok := err.(valerrors.ValidationError)
+				if !ok || ve.Code != valerrors.CodeOutOfRange || ve.Field != "seats" {
+					t.Errorf("ValidateCapacity(%d) error = %v, want seats/OUT_OF_RANGE", tt.seats, err)
+				}
+			}
+			if got := IsValidCapacity(tt.seats); got != !tt.wantErr {
+				t.Errorf("IsValidCapacity(%d) = %v, want %v", tt.seats, got, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateVehicleSet(t *testing.T) {
+	year := time.Now().Year()
+
+	tests := []struct {
+		name       string
+		plate      string
+		color      string
+		make       string
+		year       int
+		seats      int
+		wantFields []string
+	}{
+		{"all valid", "AAA-123-MC", "white", "Toyota", year, 4, nil},
+		{"one invalid", "AAA-123-MC", "green", "Toyota", year, 4, []string{"color"}},
+		{"all invalid", "bad", "", "", 1999, 0, []string{"plate", "color", "make", "year", "seats"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateVehicleSet(tt.plate, tt.color, tt.make, tt.year, tt.seats)
+			if tt.wantFields == nil {
+				if errs != nil {
+					t.Errorf("ValidateVehicleSet() = %v, want nil", errs)
+				}
+				return
+			}
+			if got := errs.Fields(); !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("ValidateVehicleSet() fields = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestIsValidColor(t *testing.T) {
+	tests := []struct {
+		color string
+		want  bool
+	}{
+		{"white", true},
+		{"WHITE", true},
+		{"bLuE", true},
+		{"green", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			if got := IsValidColor(tt.color); got != tt.want {
+				t.Errorf("IsValidColor(%q) = %v, want %v", tt.color, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowedColors(t *testing.T) {
+	colors := AllowedColors()
+	if len(colors) != len(DefaultAllowedColors) {
+		t.Fatalf("AllowedColors() returned %d colors, want %d", len(colors), len(DefaultAllowedColors))
+	}
+
+	colors[0] = "modified"
+	if DefaultAllowedColors[0] == "modified" {
+		t.Error("AllowedColors() should return a copy")
+	}
+}
+
+func TestValidateInsurancePolicyNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		// Valid policy numbers
+		{"alphanumeric", "POL123456", false},
+		{"with hyphens", "EMOSE-2024-0001", false},
+		{"lowercase", "abc123", false},
+		{"minimum length", "ABC123", false},
+		{"maximum length", "ABCDEFGHIJ1234567890", false},
+
+		// Invalid policy numbers
+		{"empty", "", true},
+		{"too short", "AB12", true},
+		{"too long", "ABCDEFGHIJ12345678901", true},
+		{"with spaces", "POL 123456", true},
+		{"surrounding whitespace", " POL123456 ", true},
+		{"with slash", "POL/123456", true},
+		{"non-ascii letters", "APÓLICE123", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateInsurancePolicyNumber(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateInsurancePolicyNumber(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if ve, ok := err.(valerrors.ValidationError); ok && ve.Code != valerrors.CodeInvalidFormat {
+					t.Errorf("error code = %v, want %v", ve.Code, valerrors.CodeInvalidFormat)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeInsurancePolicyNumber(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"pol-123456", "POL-123456"},
+		{"  abc123  ", "ABC123"},
+		{"ABC123", "ABC123"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeInsurancePolicyNumber(tt.input); got != tt.want {
+				t.Errorf("NormalizeInsurancePolicyNumber(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidInsurancePolicyNumber(t *testing.T) {
+	if !IsValidInsurancePolicyNumber("POL-123456") {
+		t.Error("IsValidInsurancePolicyNumber(POL-123456) = false, want true")
+	}
+	if IsValidInsurancePolicyNumber("bad") {
+		t.Error("IsValidInsurancePolicyNumber(bad) = true, want false")
+	}
+}
+
+func TestValidateDriverLicenseCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		wantErr  bool
+		errCode  string
+	}{
+		{"category A", "A", false, ""},
+		{"category B", "B", false, ""},
+		{"category E", "E", false, ""},
+		{"lowercase", "c", false, ""},
+		{"surrounding whitespace", " D ", false, ""},
+
+		{"empty", "", true, valerrors.CodeRequired},
+		{"whitespace only", "  ", true, valerrors.CodeRequired},
+		{"unknown category", "F", true, valerrors.CodeInvalidOption},
+		{"subcategory", "A1", true, valerrors.CodeInvalidOption},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDriverLicenseCategory(tt.category)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDriverLicenseCategory(%q) error = %v, wantErr %v", tt.category, err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				if ve, ok := err.(valerrors.ValidationError); !ok || ve.Code != tt.errCode {
+					t.Errorf("error = %v, want code %v", err, tt.errCode)
+				}
+			}
+			if got := IsValidDriverLicenseCategory(tt.category); got == tt.wantErr {
+				t.Errorf("IsValidDriverLicenseCategory(%q) = %v, want %v", tt.category, got, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAllLicenseCategories(t *testing.T) {
+	categories := AllLicenseCategories()
+	if len(categories) != 5 || categories[0] != LicenseCategoryA || categories[4] != LicenseCategoryE {
+		t.Errorf("AllLicenseCategories() = %v, want A-E", categories)
+	}
+
+	categories[0] = "Z"
+	if AllLicenseCategories()[0] != LicenseCategoryA {
+		t.Error("AllLicenseCategories() should return a copy")
+	}
+}
+
+func TestValidateLicenseCategoryForVehicle(t *testing.T) {
+	tests := []struct {
+		name      string
+		license   string
+		vehicle   string
+		wantErr   bool
+		wantField string
+		errCode   string
+	}{
+		{"moto with A", "A", VehicleCategoryMoto, false, "", ""},
+		{"economy with B", "B", VehicleCategoryEconomy, false, "", ""},
+		{"comfort with B", "B", VehicleCategoryComfort, false, "", ""},
+		{"xl with B", "B", VehicleCategoryXL, false, "", ""},
+		{"case-insensitive", "b", " Economy ", false, "", ""},
+
+		{"moto with B", "B", VehicleCategoryMoto, true, "license_category", valerrors.CodeNotAllowed},
+		{"economy with A", "A", VehicleCategoryEconomy, true, "license_category", valerrors.CodeNotAllowed},
+		{"xl with C", "C", VehicleCategoryXL, true, "license_category", valerrors.CodeNotAllowed},
+		{"invalid license", "F", VehicleCategoryMoto, true, "license_category", valerrors.CodeInvalidOption},
+		{"unknown vehicle", "B", "truck", true, "vehicle_category", valerrors.CodeInvalidOption},
+		{"retired standard category", "B", "standard", true, "vehicle_category", valerrors.CodeInvalidOption},
+		{"empty vehicle", "B", " ", true, "vehicle_category", valerrors.CodeRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLicenseCategoryForVehicle(tt.license, tt.vehicle)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateLicenseCategoryForVehicle(%q, %q) error = %v, wantErr %v", tt.license, tt.vehicle, err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+			ve, ok := err.(valerrors.ValidationError)
+			if !ok || ve.Field != tt.wantField || ve.Code != tt.errCode {
+				t.Errorf("error = %v, want %s/%s", err, tt.wantField, tt.errCode)
+			}
+		})
+	}
+}
+
+func TestRequiredLicenseCategory(t *testing.T) {
+	for _, category := range ride.AllVehicleCategories() {
+		if _, ok := RequiredLicenseCategory(category); !ok {
+			t.Errorf("RequiredLicenseCategory(%q) not found", category)
+		}
+	}
+
+	if err := ride.RegisterPassengerCapacity("van", 8); err != nil {
+		t.Fatalf("RegisterPassengerCapacity() error = %v", err)
+	}
+	t.Cleanup(ride.ResetPassengerCapacity)
+
+	if got, ok := RequiredLicenseCategory("Van"); !ok || got != LicenseCategoryB {
+		t.Errorf("RequiredLicenseCategory(Van) = %q, %v, want B", got, ok)
+	}
+	if got, ok := RequiredLicenseCategory("moto"); !ok || got != LicenseCategoryA {
+		t.Errorf("RequiredLicenseCategory(moto) = %q, %v, want A", got, ok)
+	}
+	if _, ok := RequiredLicenseCategory("standard"); ok {
+		t.Error("RequiredLicenseCategory(standard) should not be found")
+	}
+}